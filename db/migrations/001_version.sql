@@ -0,0 +1,11 @@
+-- Adds versions of events and organizers for the optimistic concurrency control, existing rows start from the first one.
+
+BEGIN;
+
+ALTER TABLE organizer
+    ADD COLUMN organizer_version INT NOT NULL DEFAULT 1;
+
+ALTER TABLE event
+    ADD COLUMN event_version INT NOT NULL DEFAULT 1;
+
+COMMIT;
@@ -0,0 +1,16 @@
+-- Adds the full-text search document of events, it's generated from existing rows by postgres.
+-- Title is weighted over founding type, and both over the result text,
+-- russian configuration stems ascii words with english stemmer, so english one is added only for its stop words.
+
+BEGIN;
+
+ALTER TABLE event
+    ADD COLUMN event_search TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(event_founding_type, '')), 'B') ||
+        setweight(to_tsvector('russian', coalesce(event_result, '')), 'C')) STORED;
+
+CREATE INDEX event_search_idx ON event USING GIN (event_search);
+
+COMMIT;
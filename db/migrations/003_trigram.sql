@@ -0,0 +1,11 @@
+-- Adds trigram indexes of the names for typo-tolerant suggestions, the extension requires a role allowed to create it.
+
+BEGIN;
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX organizer_name_trgm_idx ON organizer USING GIN (lower(organizer_name) gin_trgm_ops);
+CREATE INDEX competitor_name_trgm_idx ON competitor USING GIN (lower(competitor_name) gin_trgm_ops);
+CREATE INDEX subject_name_trgm_idx ON subject USING GIN (lower(subject_name) gin_trgm_ops);
+
+COMMIT;
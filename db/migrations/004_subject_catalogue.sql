@@ -0,0 +1,47 @@
+-- Turns per-event subject rows into the shared catalogue of subjects.
+-- Rows with the same name(case-insensitive) are merged into one subject,
+-- events are linked to it through event_subject.
+
+BEGIN;
+
+CREATE TABLE event_subject
+(
+    event_id   UUID NOT NULL,
+    FOREIGN KEY (event_id) REFERENCES event (event_id) ON DELETE CASCADE,
+    subject_id UUID NOT NULL,
+    FOREIGN KEY (subject_id) REFERENCES subject (subject_id) ON DELETE CASCADE,
+    PRIMARY KEY (event_id, subject_id)
+);
+
+UPDATE subject
+SET subject_name = trim(subject_name);
+
+-- the smallest id of the rows with the same name becomes the id of the merged subject
+CREATE TEMPORARY TABLE subject_merge AS
+SELECT subject_id,
+       subject_event,
+       first_value(subject_id) OVER (PARTITION BY lower(subject_name) ORDER BY subject_id) AS merged_id
+FROM subject;
+
+INSERT INTO event_subject (event_id, subject_id)
+SELECT DISTINCT subject_event, merged_id
+FROM subject_merge
+WHERE subject_event IS NOT NULL;
+
+ALTER TABLE subject
+    DROP COLUMN subject_event;
+
+DELETE
+FROM subject
+WHERE subject_id IN (SELECT subject_id FROM subject_merge WHERE subject_id <> merged_id);
+
+DROP TABLE subject_merge;
+
+ALTER TABLE subject
+    ADD COLUMN subject_parent   UUID REFERENCES subject (subject_id),
+    ADD COLUMN subject_synonyms VARCHAR(255)[] NOT NULL DEFAULT '{}',
+    ADD COLUMN subject_codes    JSONB          NOT NULL DEFAULT '[]';
+
+CREATE UNIQUE INDEX subject_name_idx ON subject (lower(subject_name));
+
+COMMIT;
@@ -0,0 +1,13 @@
+-- Adds eligibility criteria to the competitors, existing competitors have no restrictions.
+
+BEGIN;
+
+ALTER TABLE competitor
+    ADD COLUMN competitor_category           VARCHAR(32)    NOT NULL DEFAULT '',
+    ADD COLUMN competitor_min_age            INT            NOT NULL DEFAULT 0,
+    ADD COLUMN competitor_max_age            INT            NOT NULL DEFAULT 0,
+    ADD COLUMN competitor_min_degree         VARCHAR(32)    NOT NULL DEFAULT '',
+    ADD COLUMN competitor_regions            VARCHAR(255)[] NOT NULL DEFAULT '{}',
+    ADD COLUMN competitor_organization_types VARCHAR(64)[]  NOT NULL DEFAULT '{}';
+
+COMMIT;
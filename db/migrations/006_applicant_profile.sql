@@ -0,0 +1,20 @@
+-- Stores applicant(team) profiles used to recommend events.
+
+BEGIN;
+
+CREATE TABLE applicant_profile
+(
+    profile_id                  UUID PRIMARY KEY,
+    profile_name                VARCHAR(255) NOT NULL,
+    profile_category            VARCHAR(32)  NOT NULL,
+    profile_age                 INT          NOT NULL DEFAULT 0,
+    profile_degree              VARCHAR(32)  NOT NULL DEFAULT '',
+    profile_region              VARCHAR(255) NOT NULL DEFAULT '',
+    profile_organization_type   VARCHAR(64)  NOT NULL DEFAULT '',
+    profile_trl                 INT          NOT NULL,
+    profile_subjects            UUID[]       NOT NULL DEFAULT '{}',
+    profile_needed_funding      INT          NOT NULL DEFAULT 0,
+    profile_co_funding_capacity INT          NOT NULL DEFAULT 0
+);
+
+COMMIT;
@@ -0,0 +1,45 @@
+-- Tracks applications of teams(applicant profiles) to the events.
+
+BEGIN;
+
+CREATE TABLE application
+(
+    application_id         UUID PRIMARY KEY,
+    application_event      UUID        NOT NULL,
+    FOREIGN KEY (application_event) REFERENCES event (event_id) ON DELETE CASCADE,
+    application_team       UUID        NOT NULL,
+    FOREIGN KEY (application_team) REFERENCES applicant_profile (profile_id) ON DELETE CASCADE,
+    application_stage      VARCHAR(32) NOT NULL,
+    application_created_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (application_event, application_team)
+);
+
+CREATE TABLE application_stage_change
+(
+    application_id UUID        NOT NULL,
+    FOREIGN KEY (application_id) REFERENCES application (application_id) ON DELETE CASCADE,
+    stage          VARCHAR(32) NOT NULL,
+    changed_at     TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE application_note
+(
+    note_id         UUID PRIMARY KEY,
+    application_id  UUID        NOT NULL,
+    FOREIGN KEY (application_id) REFERENCES application (application_id) ON DELETE CASCADE,
+    note_text       TEXT        NOT NULL,
+    note_created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE application_file
+(
+    application_id   UUID         NOT NULL,
+    FOREIGN KEY (application_id) REFERENCES application (application_id) ON DELETE CASCADE,
+    file_link        VARCHAR(512) NOT NULL,
+    FOREIGN KEY (file_link) REFERENCES images (link),
+    file_name        VARCHAR(255) NOT NULL,
+    file_attached_at TIMESTAMPTZ  NOT NULL,
+    PRIMARY KEY (application_id, file_link)
+);
+
+COMMIT;
@@ -0,0 +1,15 @@
+-- Stores events starred by the users.
+
+BEGIN;
+
+CREATE TABLE favourite
+(
+    favourite_user       UUID        NOT NULL,
+    FOREIGN KEY (favourite_user) REFERENCES authenticatedUsers (id) ON DELETE CASCADE,
+    favourite_event      UUID        NOT NULL,
+    FOREIGN KEY (favourite_event) REFERENCES event (event_id) ON DELETE CASCADE,
+    favourite_created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (favourite_user, favourite_event)
+);
+
+COMMIT;
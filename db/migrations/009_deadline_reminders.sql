@@ -0,0 +1,33 @@
+-- Stores notification preferences of the users and the sent deadline reminders.
+-- The submission deadline was stored as a time of day, it becomes a full timestamp.
+-- Dates of existing deadlines were never stored, so they become NULL(unknown deadline),
+-- such events are not reminded about and are not checked against the deadline.
+
+BEGIN;
+
+ALTER TABLE event
+    ALTER COLUMN event_submission_deadline DROP NOT NULL,
+    ALTER COLUMN event_submission_deadline TYPE TIMESTAMPTZ USING NULL;
+
+CREATE TABLE notification_preferences
+(
+    np_user        UUID PRIMARY KEY,
+    FOREIGN KEY (np_user) REFERENCES authenticatedUsers (id) ON DELETE CASCADE,
+    np_email       VARCHAR(255) NOT NULL DEFAULT '',
+    np_language    VARCHAR(2)   NOT NULL DEFAULT 'ru',
+    np_days_before INT[]        NOT NULL DEFAULT '{}',
+    np_enabled     BOOLEAN      NOT NULL DEFAULT TRUE
+);
+
+CREATE TABLE reminder_delivery
+(
+    rd_user        UUID        NOT NULL,
+    FOREIGN KEY (rd_user) REFERENCES authenticatedUsers (id) ON DELETE CASCADE,
+    rd_event       UUID        NOT NULL,
+    FOREIGN KEY (rd_event) REFERENCES event (event_id) ON DELETE CASCADE,
+    rd_days_before INT         NOT NULL,
+    rd_sent_at     TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (rd_user, rd_event, rd_days_before)
+);
+
+COMMIT;
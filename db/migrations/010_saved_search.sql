@@ -0,0 +1,33 @@
+-- Stores search filters saved by the users and alerts about events matching them.
+
+BEGIN;
+
+CREATE TABLE saved_search
+(
+    search_id                UUID PRIMARY KEY,
+    search_user              UUID         NOT NULL,
+    FOREIGN KEY (search_user) REFERENCES authenticatedUsers (id) ON DELETE CASCADE,
+    search_name              VARCHAR(255) NOT NULL,
+    search_filter            JSONB        NOT NULL DEFAULT '{}',
+    search_delivery          VARCHAR(16)  NOT NULL,
+    search_frequency         VARCHAR(16)  NOT NULL,
+    search_created_at        TIMESTAMPTZ  NOT NULL,
+    search_last_delivered_at TIMESTAMPTZ
+);
+
+CREATE TABLE search_alert
+(
+    alert_id           UUID PRIMARY KEY,
+    alert_search       UUID         NOT NULL,
+    FOREIGN KEY (alert_search) REFERENCES saved_search (search_id) ON DELETE CASCADE,
+    alert_event        UUID         NOT NULL,
+    FOREIGN KEY (alert_event) REFERENCES event (event_id) ON DELETE CASCADE,
+    alert_title        VARCHAR(255) NOT NULL,
+    alert_reason       VARCHAR(16)  NOT NULL,
+    alert_created_at   TIMESTAMPTZ  NOT NULL,
+    alert_delivered_at TIMESTAMPTZ,
+    alert_read_at      TIMESTAMPTZ,
+    UNIQUE (alert_search, alert_event)
+);
+
+COMMIT;
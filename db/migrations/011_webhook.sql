@@ -0,0 +1,41 @@
+-- Stores webhook subscriptions, their deliveries and the log of delivery attempts.
+
+BEGIN;
+
+CREATE TABLE webhook
+(
+    webhook_id         UUID PRIMARY KEY,
+    webhook_url        VARCHAR(1024) NOT NULL,
+    webhook_secret     VARCHAR(255)  NOT NULL,
+    webhook_types      VARCHAR(32)[] NOT NULL,
+    webhook_active     BOOLEAN       NOT NULL DEFAULT TRUE,
+    webhook_created_at TIMESTAMPTZ   NOT NULL
+);
+
+CREATE TABLE webhook_delivery
+(
+    delivery_id              UUID PRIMARY KEY,
+    delivery_webhook         UUID        NOT NULL,
+    FOREIGN KEY (delivery_webhook) REFERENCES webhook (webhook_id) ON DELETE CASCADE,
+    delivery_type            VARCHAR(32) NOT NULL,
+    delivery_payload         JSONB       NOT NULL,
+    delivery_status          VARCHAR(16) NOT NULL,
+    delivery_attempts        INT         NOT NULL DEFAULT 0,
+    delivery_next_attempt_at TIMESTAMPTZ NOT NULL,
+    delivery_created_at      TIMESTAMPTZ NOT NULL,
+    delivery_delivered_at    TIMESTAMPTZ
+);
+
+CREATE INDEX webhook_delivery_due_idx ON webhook_delivery (delivery_status, delivery_next_attempt_at);
+
+CREATE TABLE webhook_attempt
+(
+    delivery_id         UUID        NOT NULL,
+    FOREIGN KEY (delivery_id) REFERENCES webhook_delivery (delivery_id) ON DELETE CASCADE,
+    attempt_at          TIMESTAMPTZ NOT NULL,
+    attempt_status_code INT         NOT NULL DEFAULT 0,
+    attempt_error       TEXT        NOT NULL DEFAULT '',
+    attempt_duration_ms BIGINT      NOT NULL DEFAULT 0
+);
+
+COMMIT;
@@ -0,0 +1,27 @@
+-- Stores domain events written in the transactions of the changes until the relay publishes them,
+-- and the publishers every event is already published to.
+
+BEGIN;
+
+CREATE TABLE outbox
+(
+    outbox_seq          BIGSERIAL PRIMARY KEY,
+    outbox_id           UUID        NOT NULL UNIQUE,
+    outbox_type         VARCHAR(32) NOT NULL,
+    outbox_aggregate_id UUID        NOT NULL,
+    outbox_payload      JSONB       NOT NULL,
+    outbox_occurred_at  TIMESTAMPTZ NOT NULL,
+    outbox_published_at TIMESTAMPTZ
+);
+
+CREATE INDEX outbox_unpublished_idx ON outbox (outbox_seq) WHERE outbox_published_at IS NULL;
+
+CREATE TABLE outbox_delivery
+(
+    od_event     UUID        NOT NULL,
+    FOREIGN KEY (od_event) REFERENCES outbox (outbox_id) ON DELETE CASCADE,
+    od_publisher VARCHAR(64) NOT NULL,
+    PRIMARY KEY (od_event, od_publisher)
+);
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Stores the founding ranges as amounts in the minor units of their currencies, existing ranges are in rubles.
+
+BEGIN;
+
+ALTER TABLE founding_range
+    ALTER COLUMN founding_range_low TYPE BIGINT USING founding_range_low::BIGINT * 100,
+    ALTER COLUMN founding_range_high TYPE BIGINT USING founding_range_high::BIGINT * 100,
+    ADD COLUMN founding_range_currency CHAR(3) NOT NULL DEFAULT 'RUB';
+
+COMMIT;
@@ -0,0 +1,33 @@
+-- Stores the founding and co-founding ranges in the columns of their event, the range tables are dropped.
+
+BEGIN;
+
+ALTER TABLE event
+    ADD COLUMN event_founding_low      BIGINT  NOT NULL DEFAULT 0,
+    ADD COLUMN event_founding_high     BIGINT  NOT NULL DEFAULT 0,
+    ADD COLUMN event_founding_currency CHAR(3) NOT NULL DEFAULT 'RUB',
+    ADD COLUMN event_co_founding_low   INT     NOT NULL DEFAULT 0,
+    ADD COLUMN event_co_founding_high  INT     NOT NULL DEFAULT 0;
+
+UPDATE event e
+SET event_founding_low      = r.founding_range_low,
+    event_founding_high     = r.founding_range_high,
+    event_founding_currency = r.founding_range_currency
+FROM founding_range r
+WHERE r.founding_range_id = e.event_founding_range;
+
+UPDATE event e
+SET event_co_founding_low  = r.co_founding_low,
+    event_co_founding_high = r.co_founding_high
+FROM co_founding_range r
+WHERE r.co_founding_range_id = e.event_co_founding_range;
+
+-- the foreign keys are dropped with the columns
+ALTER TABLE event
+    DROP COLUMN event_founding_range,
+    DROP COLUMN event_co_founding_range;
+
+DROP TABLE founding_range;
+DROP TABLE co_founding_range;
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Stores the SHA-256 hashes of the session tokens instead of the tokens, the existing sessions stay valid.
+
+BEGIN;
+
+UPDATE sessions
+SET token = encode(sha256(convert_to(token, 'UTF8')), 'hex');
+
+COMMENT ON COLUMN sessions.token IS 'hex of SHA-256 of the token given to the client';
+
+COMMIT;
@@ -1,3 +1,5 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
 CREATE TABLE organizer_level
 (
     organizer_level_id   UUID PRIMARY KEY,
@@ -11,27 +13,20 @@ CREATE TABLE organizer
     organizer_name  VARCHAR(255) NOT NULL,
     organizer_image VARCHAR(255) NOT NULL,
     organizer_level UUID         NOT NULL,
-    FOREIGN KEY (organizer_level) REFERENCES organizer_level (organizer_level_id)
+    FOREIGN KEY (organizer_level) REFERENCES organizer_level (organizer_level_id),
+    organizer_version INT        NOT NULL DEFAULT 1
 );
 
 CREATE TABLE competitor
 (
-    competitor_id   UUID PRIMARY KEY,
-    competitor_name VARCHAR(255) NOT NULL
-);
-
-CREATE TABLE founding_range
-(
-    founding_range_id   UUID PRIMARY KEY,
-    founding_range_low  INT NOT NULL DEFAULT 0,
-    founding_range_high INT NOT NULL DEFAULT 0
-);
-
-CREATE TABLE co_founding_range
-(
-    co_founding_range_id UUID PRIMARY KEY,
-    co_founding_low      INT NOT NULL DEFAULT 0,
-    co_founding_high     INT NOT NULL DEFAULT 0
+    competitor_id                 UUID PRIMARY KEY,
+    competitor_name               VARCHAR(255)   NOT NULL,
+    competitor_category           VARCHAR(32)    NOT NULL DEFAULT '',
+    competitor_min_age            INT            NOT NULL DEFAULT 0,
+    competitor_max_age            INT            NOT NULL DEFAULT 0,
+    competitor_min_degree         VARCHAR(32)    NOT NULL DEFAULT '',
+    competitor_regions            VARCHAR(255)[] NOT NULL DEFAULT '{}',
+    competitor_organization_types VARCHAR(64)[]  NOT NULL DEFAULT '{}'
 );
 
 CREATE TABLE event
@@ -41,28 +36,61 @@ CREATE TABLE event
     event_organizer            UUID          NOT NULL,
     FOREIGN KEY (event_organizer) REFERENCES organizer (organizer_id),
     event_founding_type        VARCHAR(1024) NOT NULL,
-    event_founding_range       UUID          NOT NULL,
-    FOREIGN KEY (event_founding_range) REFERENCES founding_range (founding_range_id),
-    event_co_founding_range    UUID          NOT NULL,
-    FOREIGN KEY (event_co_founding_range) REFERENCES co_founding_range (co_founding_range_id),
-    event_submission_deadline  TIME          NOT NULL,
+    -- the bounds of the founding range are in the minor units of the currency
+    event_founding_low         BIGINT        NOT NULL DEFAULT 0,
+    event_founding_high        BIGINT        NOT NULL DEFAULT 0,
+    event_founding_currency    CHAR(3)       NOT NULL DEFAULT 'RUB',
+    event_co_founding_low      INT           NOT NULL DEFAULT 0,
+    event_co_founding_high     INT           NOT NULL DEFAULT 0,
+    -- NULL if the deadline is unknown
+    event_submission_deadline  TIMESTAMPTZ,
     event_consideration_period VARCHAR(255),
     event_realisation_period   VARCHAR(255),
     event_result               TEXT,
     event_site                 VARCHAR(1024),
     event_document             VARCHAR(1024),
     event_internal_contacts    VARCHAR(255),
-    event_trl                  INT           NOT NULL DEFAULT 5
+    event_trl                  INT           NOT NULL DEFAULT 5,
+    event_version              INT           NOT NULL DEFAULT 1
 );
 
+-- title is weighted over founding type, and both over the result text.
+-- russian configuration stems ascii words with english stemmer, so english one is added only for its stop words.
+ALTER TABLE event
+    ADD COLUMN event_search TSVECTOR GENERATED ALWAYS AS (
+                setweight(to_tsvector('russian', coalesce(title, '')), 'A') ||
+                setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+                setweight(to_tsvector('russian', coalesce(event_founding_type, '')), 'B') ||
+                setweight(to_tsvector('russian', coalesce(event_result, '')), 'C')
+        ) STORED;
+
+CREATE INDEX event_search_idx ON event USING GIN (event_search);
+
 CREATE TABLE subject
 (
-    subject_id    UUID PRIMARY KEY,
-    subject_name  VARCHAR(255) NOT NULL,
-    subject_event UUID,
-    FOREIGN KEY (subject_event) REFERENCES event (event_id)
+    subject_id       UUID PRIMARY KEY,
+    subject_name     VARCHAR(255) NOT NULL,
+    subject_parent   UUID,
+    FOREIGN KEY (subject_parent) REFERENCES subject (subject_id),
+    subject_synonyms VARCHAR(255)[] NOT NULL DEFAULT '{}',
+    subject_codes    JSONB          NOT NULL DEFAULT '[]'
+);
+
+CREATE UNIQUE INDEX subject_name_idx ON subject (lower(subject_name));
+
+CREATE TABLE event_subject
+(
+    event_id   UUID NOT NULL,
+    FOREIGN KEY (event_id) REFERENCES event (event_id) ON DELETE CASCADE,
+    subject_id UUID NOT NULL,
+    FOREIGN KEY (subject_id) REFERENCES subject (subject_id) ON DELETE CASCADE,
+    PRIMARY KEY (event_id, subject_id)
 );
 
+CREATE INDEX organizer_name_trgm_idx ON organizer USING GIN (lower(organizer_name) gin_trgm_ops);
+CREATE INDEX competitor_name_trgm_idx ON competitor USING GIN (lower(competitor_name) gin_trgm_ops);
+CREATE INDEX subject_name_trgm_idx ON subject USING GIN (lower(subject_name) gin_trgm_ops);
+
 CREATE TABLE competitor_requirements
 (
     cr_id         UUID PRIMARY KEY,
@@ -90,4 +118,177 @@ CREATE TABLE sessions
 (
     token VARCHAR(512) PRIMARY KEY,
     owner UUID NOT NULL UNIQUE
-)
+);
+
+COMMENT ON COLUMN sessions.token IS 'hex of SHA-256 of the token given to the client';
+
+CREATE TABLE applicant_profile
+(
+    profile_id                  UUID PRIMARY KEY,
+    profile_name                VARCHAR(255) NOT NULL,
+    profile_category            VARCHAR(32)  NOT NULL,
+    profile_age                 INT          NOT NULL DEFAULT 0,
+    profile_degree              VARCHAR(32)  NOT NULL DEFAULT '',
+    profile_region              VARCHAR(255) NOT NULL DEFAULT '',
+    profile_organization_type   VARCHAR(64)  NOT NULL DEFAULT '',
+    profile_trl                 INT          NOT NULL,
+    profile_subjects            UUID[]       NOT NULL DEFAULT '{}',
+    profile_needed_funding      INT          NOT NULL DEFAULT 0,
+    profile_co_funding_capacity INT          NOT NULL DEFAULT 0
+);
+
+CREATE TABLE application
+(
+    application_id         UUID PRIMARY KEY,
+    application_event      UUID        NOT NULL,
+    FOREIGN KEY (application_event) REFERENCES event (event_id) ON DELETE CASCADE,
+    application_team       UUID        NOT NULL,
+    FOREIGN KEY (application_team) REFERENCES applicant_profile (profile_id) ON DELETE CASCADE,
+    application_stage      VARCHAR(32) NOT NULL,
+    application_created_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (application_event, application_team)
+);
+
+CREATE TABLE application_stage_change
+(
+    application_id UUID        NOT NULL,
+    FOREIGN KEY (application_id) REFERENCES application (application_id) ON DELETE CASCADE,
+    stage          VARCHAR(32) NOT NULL,
+    changed_at     TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE application_note
+(
+    note_id         UUID PRIMARY KEY,
+    application_id  UUID        NOT NULL,
+    FOREIGN KEY (application_id) REFERENCES application (application_id) ON DELETE CASCADE,
+    note_text       TEXT        NOT NULL,
+    note_created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE application_file
+(
+    application_id   UUID         NOT NULL,
+    FOREIGN KEY (application_id) REFERENCES application (application_id) ON DELETE CASCADE,
+    file_link        VARCHAR(512) NOT NULL,
+    FOREIGN KEY (file_link) REFERENCES images (link),
+    file_name        VARCHAR(255) NOT NULL,
+    file_attached_at TIMESTAMPTZ  NOT NULL,
+    PRIMARY KEY (application_id, file_link)
+);
+
+CREATE TABLE favourite
+(
+    favourite_user       UUID        NOT NULL,
+    FOREIGN KEY (favourite_user) REFERENCES authenticatedUsers (id) ON DELETE CASCADE,
+    favourite_event      UUID        NOT NULL,
+    FOREIGN KEY (favourite_event) REFERENCES event (event_id) ON DELETE CASCADE,
+    favourite_created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (favourite_user, favourite_event)
+);
+
+CREATE TABLE notification_preferences
+(
+    np_user        UUID PRIMARY KEY,
+    FOREIGN KEY (np_user) REFERENCES authenticatedUsers (id) ON DELETE CASCADE,
+    np_email       VARCHAR(255) NOT NULL DEFAULT '',
+    np_language    VARCHAR(2)   NOT NULL DEFAULT 'ru',
+    np_days_before INT[]        NOT NULL DEFAULT '{}',
+    np_enabled     BOOLEAN      NOT NULL DEFAULT TRUE
+);
+
+CREATE TABLE reminder_delivery
+(
+    rd_user        UUID        NOT NULL,
+    FOREIGN KEY (rd_user) REFERENCES authenticatedUsers (id) ON DELETE CASCADE,
+    rd_event       UUID        NOT NULL,
+    FOREIGN KEY (rd_event) REFERENCES event (event_id) ON DELETE CASCADE,
+    rd_days_before INT         NOT NULL,
+    rd_sent_at     TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (rd_user, rd_event, rd_days_before)
+);
+
+CREATE TABLE saved_search
+(
+    search_id                UUID PRIMARY KEY,
+    search_user              UUID         NOT NULL,
+    FOREIGN KEY (search_user) REFERENCES authenticatedUsers (id) ON DELETE CASCADE,
+    search_name              VARCHAR(255) NOT NULL,
+    search_filter            JSONB        NOT NULL DEFAULT '{}',
+    search_delivery          VARCHAR(16)  NOT NULL,
+    search_frequency         VARCHAR(16)  NOT NULL,
+    search_created_at        TIMESTAMPTZ  NOT NULL,
+    search_last_delivered_at TIMESTAMPTZ
+);
+
+CREATE TABLE search_alert
+(
+    alert_id           UUID PRIMARY KEY,
+    alert_search       UUID         NOT NULL,
+    FOREIGN KEY (alert_search) REFERENCES saved_search (search_id) ON DELETE CASCADE,
+    alert_event        UUID         NOT NULL,
+    FOREIGN KEY (alert_event) REFERENCES event (event_id) ON DELETE CASCADE,
+    alert_title        VARCHAR(255) NOT NULL,
+    alert_reason       VARCHAR(16)  NOT NULL,
+    alert_created_at   TIMESTAMPTZ  NOT NULL,
+    alert_delivered_at TIMESTAMPTZ,
+    alert_read_at      TIMESTAMPTZ,
+    UNIQUE (alert_search, alert_event)
+);
+
+CREATE TABLE webhook
+(
+    webhook_id         UUID PRIMARY KEY,
+    webhook_url        VARCHAR(1024) NOT NULL,
+    webhook_secret     VARCHAR(255)  NOT NULL,
+    webhook_types      VARCHAR(32)[] NOT NULL,
+    webhook_active     BOOLEAN       NOT NULL DEFAULT TRUE,
+    webhook_created_at TIMESTAMPTZ   NOT NULL
+);
+
+CREATE TABLE webhook_delivery
+(
+    delivery_id              UUID PRIMARY KEY,
+    delivery_webhook         UUID        NOT NULL,
+    FOREIGN KEY (delivery_webhook) REFERENCES webhook (webhook_id) ON DELETE CASCADE,
+    delivery_type            VARCHAR(32) NOT NULL,
+    delivery_payload         JSONB       NOT NULL,
+    delivery_status          VARCHAR(16) NOT NULL,
+    delivery_attempts        INT         NOT NULL DEFAULT 0,
+    delivery_next_attempt_at TIMESTAMPTZ NOT NULL,
+    delivery_created_at      TIMESTAMPTZ NOT NULL,
+    delivery_delivered_at    TIMESTAMPTZ
+);
+
+CREATE INDEX webhook_delivery_due_idx ON webhook_delivery (delivery_status, delivery_next_attempt_at);
+
+CREATE TABLE webhook_attempt
+(
+    delivery_id         UUID        NOT NULL,
+    FOREIGN KEY (delivery_id) REFERENCES webhook_delivery (delivery_id) ON DELETE CASCADE,
+    attempt_at          TIMESTAMPTZ NOT NULL,
+    attempt_status_code INT         NOT NULL DEFAULT 0,
+    attempt_error       TEXT        NOT NULL DEFAULT '',
+    attempt_duration_ms BIGINT      NOT NULL DEFAULT 0
+);
+
+CREATE TABLE outbox
+(
+    outbox_seq          BIGSERIAL PRIMARY KEY,
+    outbox_id           UUID        NOT NULL UNIQUE,
+    outbox_type         VARCHAR(32) NOT NULL,
+    outbox_aggregate_id UUID        NOT NULL,
+    outbox_payload      JSONB       NOT NULL,
+    outbox_occurred_at  TIMESTAMPTZ NOT NULL,
+    outbox_published_at TIMESTAMPTZ
+);
+
+CREATE INDEX outbox_unpublished_idx ON outbox (outbox_seq) WHERE outbox_published_at IS NULL;
+
+CREATE TABLE outbox_delivery
+(
+    od_event     UUID        NOT NULL,
+    FOREIGN KEY (od_event) REFERENCES outbox (outbox_id) ON DELETE CASCADE,
+    od_publisher VARCHAR(64) NOT NULL,
+    PRIMARY KEY (od_event, od_publisher)
+);
@@ -4,19 +4,16 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"time"
 
-	"github.com/gin-contrib/cors"
-	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/indigowar/map-of-events/internal/config"
-	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/v1/files"
-	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/v1/json"
-	json2 "github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/v2/json"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/grpc"
 )
 
 func Run(cfg *config.Config) {
@@ -34,55 +31,24 @@ func Run(cfg *config.Config) {
 	}
 	defer postgresCPool.Close()
 
-	services := initServices(postgresCPool)
+	services := initServices(postgresCPool, cfg)
 
-	eventHandler := json.NewEventHandler(services)
-
-	r := gin.Default()
-
-	r.Use(cors.Default())
-
-	v1 := r.Group("api/v1")
-	{
-		v1.GET("/competitor", json.GetAllCompetitorsHandler(services.Competitor))
-		v1.POST("/competitor", json.CreateCompetitorHandler(services.Competitor))
-
-		v1.GET("/founding_range/:id", json.GetByIDRangeHandler(services.FoundingRange))
-		v1.GET("/founding_range", json.GetMaximumRangeHandler(services.FoundingRange))
-
-		v1.GET("/co_founding_range/:id", json.GetByIDRangeHandler(services.CoFoundingRange))
-		v1.GET("/co_founding_range", json.GetMaximumRangeHandler(services.CoFoundingRange))
-
-		v1.GET("/organizer_level", json.GetAllOrganizerLevelsHandler(services.Organizer))
-		v1.POST("/organizer_level", json.CreateOrganizerLevelHandler(services.Organizer))
-
-		v1.GET("/organizer", json.GetAllOrganizersHandler(services.Organizer))
-		v1.POST("/organizer", json.CreateOrganizerHandler(services.Organizer))
-		v1.GET("/organizer/:id", json.GetByIDOrganizerHandler(services.Organizer))
-		v1.PUT("/organizer/:id", json.UpdateOrganizerHandler(services.Organizer))
-		v1.DELETE("/organizer/:id", json.DeleteOrganizerHandler(services.Organizer))
-
-		v1.GET("/event", eventHandler.GetAllEvents)
-		v1.POST("/event", eventHandler.Create)
-
-		v1.GET("/event/:id", eventHandler.GetEventByID)
-		v1.DELETE("/event/:id", eventHandler.DeleteEvent)
-		v1.PUT("/event/:id", eventHandler.DeleteEvent)
-
-		v1.GET("/minimal_event", eventHandler.GetAllAsMinimal)
-		v1.GET("/minimal_event/:id", eventHandler.GetByIDMinimal)
-
-		v1.POST("/image", files.UploadHandler(services.Image))
-		v1.GET("/image/:link", files.RetrievingHandler(services.Image))
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	// the outbox is always relayed, the saved searches and the webhooks are told about the changes by it
+	go runPeriodically(jobsCtx, "outbox relay", cfg.Outbox.Interval, services.Outbox.Relay)
+	if cfg.Reminder.Enabled {
+		go runPeriodically(jobsCtx, "deadline reminders", cfg.Reminder.Interval, services.Reminder.SendDue)
 	}
-
-	v2 := r.Group("/api/v2")
-	{
-		v2.GET("/organizer", json2.GetAllOrganizersHandler(services.Organizer, services.Image))
-		v2.POST("/organizer", json2.CreateOrganizerHandler(services.Organizer, services.Image))
-		v2.GET("/organizer/:id", json2.GetOrganizerByID(services.Organizer, services.Image))
+	if cfg.SavedSearch.Enabled {
+		go runPeriodically(jobsCtx, "saved search alerts", cfg.SavedSearch.Interval, services.SavedSearch.DeliverDue)
+	}
+	if cfg.Webhook.Enabled {
+		go runPeriodically(jobsCtx, "webhooks", cfg.Webhook.Interval, services.Webhook.DeliverDue)
 	}
 
+	r := newRouter(services, cfg)
+
 	server := &http.Server{
 		Handler:        r,
 		Addr:           ":" + cfg.HTTP.Port,
@@ -97,15 +63,35 @@ func Run(cfg *config.Config) {
 		}
 	}()
 
+	var grpcServer *grpc.Server
+	if cfg.GRPC.Enabled {
+		listener, err := net.Listen("tcp", ":"+cfg.GRPC.Port)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		grpcServer = grpc.NewServer(services, cfg.GRPC.Reflection)
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatalln(err)
+			}
+		}()
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt)
 
 	<-quit
 	log.Println("Start the shutdown...")
 
+	stopJobs()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	if grpcServer != nil {
+		grpcServer.Shutdown(ctx)
+	}
+
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatalln(err)
 	}
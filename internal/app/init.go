@@ -1,14 +1,24 @@
 package app
 
 import (
+	"context"
+	"log"
+	"time"
+
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/indigowar/map-of-events/internal/config"
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
 	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/infra/adapters/mail"
+	"github.com/indigowar/map-of-events/internal/infra/adapters/publisher"
+	"github.com/indigowar/map-of-events/internal/infra/adapters/storages/memory"
 	"github.com/indigowar/map-of-events/internal/infra/adapters/storages/postgres"
+	"github.com/indigowar/map-of-events/internal/infra/adapters/webhook"
 	svc "github.com/indigowar/map-of-events/internal/services"
 )
 
-func initServices(pool *pgxpool.Pool) services.Services {
+func initServices(pool *pgxpool.Pool, cfg *config.Config) services.Services {
 	competitorStorage := postgres.NewPostgresCompetitorStorage(pool)
 	organizerStorage := postgres.NewPostgresOrganizerStorage(pool)
 	foundingRangeStorage := postgres.NewFoundingRangePostgresStorage(pool)
@@ -16,16 +26,117 @@ func initServices(pool *pgxpool.Pool) services.Services {
 	subjectStorage := postgres.NewPostgresSubjectStorage(pool)
 	eventStorage := postgres.NewPostgresEventStorage(pool)
 	imageStorage := postgres.NewPostgresImageStorage(pool)
+	profileStorage := postgres.NewPostgresProfileStorage(pool)
+	applicationStorage := postgres.NewPostgresApplicationStorage(pool)
+	userStorage := postgres.NewPostgresUserStorage(pool)
+	sessionStorage := postgres.NewSessionStorage(pool)
+	favouriteStorage := postgres.NewPostgresFavouriteStorage(pool)
+	notificationPreferencesStorage := postgres.NewPostgresNotificationPreferencesStorage(pool)
+	reminderDeliveryStorage := postgres.NewPostgresReminderDeliveryStorage(pool)
+	savedSearchStorage := postgres.NewPostgresSavedSearchStorage(pool)
+	searchAlertStorage := postgres.NewPostgresSearchAlertStorage(pool)
+	webhookStorage := postgres.NewPostgresWebhookStorage(pool)
+	webhookDeliveryStorage := postgres.NewPostgresWebhookDeliveryStorage(pool)
+	outboxStorage := postgres.NewPostgresOutboxStorage(pool)
+	analyticsStorage := postgres.NewPostgresAnalyticsStorage(pool)
+	mailSender := mail.NewSMTPSender(cfg.SMTP)
+	searchStorage := initSearchStorage(pool, cfg.Search, eventStorage, subjectStorage)
+
+	bus := publisher.NewBus()
 
 	var s services.Services
 
+	currency, err := svc.NewCurrencyService(cfg.Currency)
+	if err != nil {
+		log.Fatalln("failed to init the currencies: ", err)
+	}
+	s.Currency = currency
+
+	s.Webhook = svc.NewWebhookService(webhookStorage, webhookDeliveryStorage, webhook.NewHTTPSender(cfg.Webhook.Timeout), cfg.Webhook)
 	s.Subject = svc.NewSubjectService(subjectStorage)
 	s.Image = svc.NewImageService(imageStorage)
-	s.Organizer, _ = svc.NewOrganizerService(organizerStorage, s.Image)
-	s.FoundingRange = svc.NewFoundingRangeService(foundingRangeStorage)
+	s.Organizer, _ = svc.NewOrganizerService(organizerStorage, s.Image, outboxStorage)
+	s.FoundingRange = svc.NewFoundingRangeService(foundingRangeStorage, s.Currency)
 	s.CoFoundingRange = svc.NewCoFoundingRangeService(coFoundingRangeStorage)
 	s.Competitor = svc.NewCompetitorService(competitorStorage)
-	s.Event = svc.NewEventServices(eventStorage, s.Subject, s.Organizer, s.FoundingRange, s.CoFoundingRange, s.Competitor)
+	s.SavedSearch = svc.NewSavedSearchService(savedSearchStorage, searchAlertStorage, notificationPreferencesStorage, eventStorage,
+		s.Subject, s.Currency, mailSender)
+	s.Event = svc.NewEventServices(eventStorage, searchStorage, s.Subject, s.Organizer, s.Competitor,
+		s.Currency, outboxStorage)
+	s.Eligibility = svc.NewEligibilityService(s.Event, s.Competitor)
+	s.Profile = svc.NewProfileService(profileStorage, s.Subject)
+	s.Recommendation = svc.NewRecommendationService(s.Event, s.Competitor, s.Subject, s.Currency)
+	s.Application = svc.NewApplicationService(applicationStorage, s.Event, s.Profile, s.Image)
+	s.Auth = svc.NewAuthService(userStorage, sessionStorage, cfg.Auth)
+	s.Favourite = svc.NewFavouriteService(favouriteStorage, s.Event)
+	s.Reminder = svc.NewReminderService(notificationPreferencesStorage, reminderDeliveryStorage, favouriteStorage, s.Event,
+		mailSender, cfg.Reminder)
+	s.Outbox = svc.NewOutboxService(outboxStorage, cfg.Outbox.Retention, initPublishers(cfg.Outbox, bus)...)
+
+	s.ChangeStream = svc.NewChangeStreamService(s.Subject, s.Currency, streamConfig(cfg))
+	s.Analytics = svc.NewAnalyticsService(analyticsStorage, s.Currency)
+
+	bus.Subscribe(s.SavedSearch)
+	bus.Subscribe(s.Webhook)
+	bus.Subscribe(s.ChangeStream)
 
 	return s
 }
+
+// unlimitedStreamDuration - the maximum duration of the stream, if the server has no write timeout
+const unlimitedStreamDuration = 10 * time.Minute
+
+// streamConfig - the stream's configuration with the default maximum duration
+func streamConfig(cfg *config.Config) config.StreamConfig {
+	c := cfg.Stream
+	if c.MaxDuration > 0 {
+		return c
+	}
+
+	switch {
+	case cfg.HTTP.WriteTimeout <= 0:
+		c.MaxDuration = unlimitedStreamDuration
+	case cfg.HTTP.WriteTimeout > 2*time.Second:
+		c.MaxDuration = cfg.HTTP.WriteTimeout - time.Second
+	default:
+		c.MaxDuration = cfg.HTTP.WriteTimeout / 2
+	}
+	return c
+}
+
+// initPublishers - creates the publishers of the domain events by the configuration, the bus is always the first
+func initPublishers(cfg config.OutboxConfig, bus *publisher.Bus) []adapters.DomainEventPublisher {
+	publishers := []adapters.DomainEventPublisher{bus}
+
+	if cfg.NATS.Enabled {
+		nats, err := publisher.NewNATSPublisher(cfg.NATS.URL, cfg.NATS.Subject, cfg.NATS.Timeout)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		publishers = append(publishers, nats)
+	}
+
+	if cfg.HTTP.Enabled {
+		publishers = append(publishers, publisher.NewHTTPPublisher(webhook.NewHTTPSender(cfg.HTTP.Timeout), cfg.HTTP.URL))
+	}
+
+	return publishers
+}
+
+// initSearchStorage - creates a search storage by the configuration,
+// the in-process index is filled with all stored events, unless it's disabled by the configuration
+func initSearchStorage(pool *pgxpool.Pool, cfg config.SearchConfig, events adapters.EventStorage, subjects adapters.SubjectStorage) adapters.EventSearchStorage {
+	if cfg.Engine != config.SearchEngineMemory {
+		return postgres.NewPostgresEventSearchStorage(pool)
+	}
+
+	index := memory.NewEventSearchStorage()
+	if !cfg.Fill {
+		return index
+	}
+
+	if err := memory.Fill(context.Background(), index, events, subjects); err != nil {
+		log.Println("failed to fill the search index: ", err)
+	}
+	return index
+}
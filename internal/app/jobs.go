@@ -0,0 +1,32 @@
+package app
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// job - a periodic job, returns the number of the processed items
+type job func(ctx context.Context, now time.Time) (int, error)
+
+// runPeriodically - runs the job every interval until ctx is done, the first run is made on the start,
+// so the work missed while the service was down is done
+func runPeriodically(ctx context.Context, name string, interval time.Duration, j job) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		processed, err := j(ctx, time.Now().UTC())
+		if err != nil {
+			log.Printf("%s has failed: %v\n", name, err)
+		} else if processed > 0 {
+			log.Printf("%s: %d processed\n", name, processed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package app
+
+import (
+	"mime"
+	"net/http"
+	"path"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+
+	"github.com/indigowar/map-of-events/internal/config"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/auth"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/graphql"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/openapi"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/v1/files"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/v1/json"
+	json2 "github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/v2/json"
+	json3 "github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/v3/json"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/versioning"
+	gql "github.com/indigowar/map-of-events/pkg/graphql"
+)
+
+const (
+	apiVersion = "1.0.0"
+
+	specPath = "/api/openapi.json"
+	docsPath = "/api/docs"
+)
+
+func newRouter(services services.Services, cfg *config.Config) *gin.Engine {
+	eventHandler := json.NewEventHandler(services)
+	schema := graphql.NewSchema(services)
+
+	r := gin.Default()
+
+	r.Use(cors.Default())
+
+	versions := versioning.New(versionPolicies(cfg.API), cfg.API.UsageLogInterval)
+
+	v1 := versions.Version("v1")
+	v1.Use(auth.Middleware(services.Auth))
+	{
+		v1.GET("/competitor", json.GetAllCompetitorsHandler(services.Competitor))
+		v1.POST("/competitor", json.CreateCompetitorHandler(services.Competitor))
+		v1.GET("/competitor/:id", json.GetCompetitorByIDHandler(services.Competitor))
+		v1.PUT("/competitor/:id", json.UpdateCompetitorHandler(services.Competitor))
+
+		v1.POST("/eligibility_check", json.EligibilityCheckHandler(services.Eligibility))
+
+		v1.GET("/founding_range/:id", json.RangeGoneHandler("founding_range"))
+		v1.GET("/founding_range", json.GetMaximumRangeHandler(services.FoundingRange))
+
+		v1.GET("/co_founding_range/:id", json.RangeGoneHandler("co_founding_range"))
+		v1.GET("/co_founding_range", json.GetMaximumRangeHandler(services.CoFoundingRange))
+
+		v1.GET("/organizer_level", json.GetAllOrganizerLevelsHandler(services.Organizer))
+		v1.POST("/organizer_level", json.CreateOrganizerLevelHandler(services.Organizer))
+
+		v1.GET("/organizer", json.GetAllOrganizersHandler(services.Organizer, json.LogoLinks))
+		v1.POST("/organizer", json.CreateOrganizerHandler(services.Organizer, json.LogoLinks))
+		v1.GET("/organizer/:id", json.GetByIDOrganizerHandler(services.Organizer, json.LogoLinks))
+		v1.PUT("/organizer/:id", json.UpdateOrganizerHandler(services.Organizer))
+		v1.PATCH("/organizer/:id", json.PatchOrganizerHandler(services.Organizer))
+		v1.DELETE("/organizer/:id", json.DeleteOrganizerHandler(services.Organizer))
+
+		v1.GET("/event", eventHandler.GetAllEvents)
+		v1.POST("/event", eventHandler.Create)
+
+		v1.GET("/event/:id", eventHandler.GetEventByID)
+		v1.GET("/event/:id/founding_range", json.GetEventRangeHandler(services.FoundingRange))
+		v1.GET("/event/:id/co_founding_range", json.GetEventRangeHandler(services.CoFoundingRange))
+		v1.DELETE("/event/:id", eventHandler.DeleteEvent)
+		v1.PUT("/event/:id", eventHandler.Update)
+		v1.PATCH("/event/:id", eventHandler.Patch)
+
+		v1.GET("/event_search", eventHandler.Search)
+		v1.POST("/event_batch", eventHandler.Batch)
+		v1.GET("/change_stream", eventHandler.Stream)
+
+		v1.GET("/subject", json.GetAllSubjectsHandler(services.Subject))
+		v1.POST("/subject", json.CreateSubjectHandler(services.Subject))
+		v1.GET("/subject/:id", json.GetSubjectByIDHandler(services.Subject))
+		v1.PUT("/subject/:id", json.UpdateSubjectHandler(services.Subject))
+		v1.DELETE("/subject/:id", json.DeleteSubjectHandler(services.Subject))
+		v1.GET("/subject_tree", json.GetSubjectTreeHandler(services.Subject))
+
+		v1.GET("/profile", json.GetAllProfilesHandler(services.Profile))
+		v1.POST("/profile", json.CreateProfileHandler(services.Profile))
+		v1.GET("/profile/:id", json.GetProfileByIDHandler(services.Profile))
+		v1.PUT("/profile/:id", json.UpdateProfileHandler(services.Profile))
+		v1.DELETE("/profile/:id", json.DeleteProfileHandler(services.Profile))
+		v1.GET("/profile/:id/recommendations", json.RecommendationsHandler(services.Profile, services.Recommendation))
+
+		v1.POST("/application", json.CreateApplicationHandler(services.Application))
+		v1.GET("/application/:id", json.GetApplicationByIDHandler(services.Application))
+		v1.DELETE("/application/:id", json.DeleteApplicationHandler(services.Application))
+		v1.PUT("/application/:id/stage", json.ChangeApplicationStageHandler(services.Application))
+		v1.POST("/application/:id/note", json.AddApplicationNoteHandler(services.Application))
+		v1.POST("/application/:id/file", json.AttachApplicationFileHandler(services.Application))
+		v1.GET("/event/:id/application", json.GetEventApplicationsHandler(services.Application))
+		v1.GET("/profile/:id/application", json.GetTeamApplicationsHandler(services.Application))
+
+		v1.POST("/user", json.RegisterHandler(services.Auth))
+		v1.POST("/session", json.LoginHandler(services.Auth))
+
+		favourites := v1.Group("/favourite", auth.Required())
+		favourites.GET("", eventHandler.GetFavourites)
+		favourites.PUT("/:id", eventHandler.AddFavourite)
+		favourites.DELETE("/:id", eventHandler.RemoveFavourite)
+
+		notifications := v1.Group("/notification_preferences", auth.Required())
+		notifications.GET("", json.GetNotificationPreferencesHandler(services.Reminder))
+		notifications.PUT("", json.UpdateNotificationPreferencesHandler(services.Reminder))
+
+		savedSearches := v1.Group("/saved_search", auth.Required())
+		savedSearches.GET("", json.GetAllSavedSearchesHandler(services.SavedSearch))
+		savedSearches.POST("", json.CreateSavedSearchHandler(services.SavedSearch))
+		savedSearches.GET("/:id", json.GetSavedSearchByIDHandler(services.SavedSearch))
+		savedSearches.PUT("/:id", json.UpdateSavedSearchHandler(services.SavedSearch))
+		savedSearches.DELETE("/:id", json.DeleteSavedSearchHandler(services.SavedSearch))
+
+		alerts := v1.Group("/search_alert", auth.Required())
+		alerts.GET("", json.GetSearchAlertsHandler(services.SavedSearch))
+		alerts.POST("/read", json.MarkSearchAlertsReadHandler(services.SavedSearch))
+
+		admin := v1.Group("", auth.Admin(services.Auth))
+		admin.GET("/webhook", json.GetAllWebhooksHandler(services.Webhook))
+		admin.POST("/webhook", json.CreateWebhookHandler(services.Webhook))
+		admin.GET("/webhook/:id", json.GetWebhookByIDHandler(services.Webhook))
+		admin.PUT("/webhook/:id", json.UpdateWebhookHandler(services.Webhook))
+		admin.DELETE("/webhook/:id", json.DeleteWebhookHandler(services.Webhook))
+		admin.GET("/webhook/:id/delivery", json.GetWebhookDeliveriesHandler(services.Webhook))
+		admin.GET("/webhook_dead_letter", json.GetWebhookDeadLettersHandler(services.Webhook))
+		admin.POST("/webhook_delivery/:id/retry", json.RetryWebhookDeliveryHandler(services.Webhook))
+
+		v1.GET("/minimal_event", eventHandler.GetAllAsMinimal)
+		v1.GET("/minimal_event/:id", eventHandler.GetByIDMinimal)
+
+		v1.GET("/organizer_suggestion", json.OrganizerSuggestionHandler(services.Organizer))
+		v1.GET("/competitor_suggestion", json.CompetitorSuggestionHandler(services.Competitor))
+		v1.GET("/subject_suggestion", json.SubjectSuggestionHandler(services.Subject))
+
+		v1.GET("/analytics/:dimension", json.AnalyticsHandler(services.Analytics))
+
+		v1.POST("/image", files.UploadHandler(services.Image))
+		v1.GET("/image/:link", files.RetrievingHandler(services.Image))
+	}
+
+	// v2 serves the organizers of v1 with the inlined logos
+	inlineLogos := json2.InlineLogos(services.Image)
+	v2 := versions.Version("v2")
+	{
+		v2.GET("/organizer", json.GetAllOrganizersHandler(services.Organizer, inlineLogos))
+		v2.POST("/organizer", json.CreateOrganizerHandler(services.Organizer, inlineLogos))
+		v2.GET("/organizer/:id", json.GetByIDOrganizerHandler(services.Organizer, inlineLogos))
+	}
+
+	v3Handler := json3.NewHandler(services)
+	v3 := versions.Version("v3")
+	v3.Use(auth.Middleware(services.Auth))
+	{
+		v3.GET("/events", v3Handler.GetEvents)
+		v3.GET("/events/:id", v3Handler.GetEvent)
+		v3.GET("/organizers", v3Handler.GetOrganizers)
+		v3.GET("/organizers/:id", v3Handler.GetOrganizer)
+		v3.GET("/organizer_levels", v3Handler.GetOrganizerLevels)
+		v3.GET("/organizer_levels/:id", v3Handler.GetOrganizerLevel)
+		v3.GET("/competitors", v3Handler.GetCompetitors)
+		v3.GET("/competitors/:id", v3Handler.GetCompetitor)
+		v3.GET("/subjects", v3Handler.GetSubjects)
+		v3.GET("/subjects/:id", v3Handler.GetSubject)
+		v3.GET("/images/:link", v3Handler.GetImage)
+	}
+
+	versions.Register(r)
+
+	api := r.Group("/api")
+	api.Use(auth.Middleware(services.Auth))
+	{
+		limits := gql.Limits{MaxDepth: cfg.GraphQL.MaxDepth, MaxComplexity: cfg.GraphQL.MaxComplexity}
+		api.POST("/graphql", graphql.QueryHandler(schema, services, limits))
+		api.GET("/graphql/schema", graphql.SchemaHandler(schema))
+	}
+
+	spec := newSpec()
+	r.GET(specPath, openapi.SpecHandler(spec))
+	r.GET(docsPath, openapi.UIHandler(specPath, docsPath))
+	for _, name := range openapi.UIAssets {
+		r.GET(docsPath+"/"+name, openapi.AssetHandler(name))
+	}
+
+	return r
+}
+
+// newSpec - builds the OpenAPI document from route descriptions of all delivery packages
+func newSpec() *openapi.Document {
+	spec := openapi.New("Map of events API", apiVersion)
+
+	spec.Add("/api/v1", json.Routes()...)
+	spec.Add("/api/v1", files.Routes()...)
+	spec.Add("/api/v2", json2.Routes()...)
+	spec.Add("/api/v3", json3.Routes()...)
+	spec.Add("/api", graphql.Routes()...)
+	spec.Add("/",
+		openapi.Route{Method: http.MethodGet, Path: specPath, Summary: "Returns this OpenAPI document", Tags: []string{"docs"}, Response: map[string]interface{}{}},
+		openapi.Route{Method: http.MethodGet, Path: docsPath, Summary: "Swagger UI for this document", Tags: []string{"docs"}, Response: "", ResponseContentType: "text/html"},
+	)
+	for _, name := range openapi.UIAssets {
+		spec.Add("/", openapi.Route{Method: http.MethodGet, Path: docsPath + "/" + name, Summary: "A file of Swagger UI",
+			Tags: []string{"docs"}, Response: "", ResponseContentType: mime.TypeByExtension(path.Ext(name))})
+	}
+
+	return spec
+}
+
+// versionPolicies - the policies of the API versions from the configuration
+func versionPolicies(cfg config.APIConfig) map[string]versioning.Policy {
+	policies := make(map[string]versioning.Policy, len(cfg.Versions))
+	for name, v := range cfg.Versions {
+		policies[name] = versioning.Policy{
+			Disabled:   v.Disabled,
+			Deprecated: v.Deprecated,
+			Sunset:     v.Sunset,
+			Successor:  v.Successor,
+		}
+	}
+	return policies
+}
@@ -0,0 +1,139 @@
+package app
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/config"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/openapi"
+)
+
+func TestAllRoutesAreDocumented(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := newRouter(services.Services{}, &config.Config{})
+	spec := newSpec()
+
+	// the registered routes are put into a document too, so both are compared in the same path format
+	registered := openapi.New("registered routes", apiVersion)
+	for _, route := range r.Routes() {
+		if !spec.Has(route.Method, route.Path) {
+			t.Errorf("route %s %s is registered, but missing in the OpenAPI document", route.Method, route.Path)
+		}
+		registered.Add("/", openapi.Route{Method: route.Method, Path: route.Path})
+	}
+
+	documented := registered.Operations()
+	for _, operation := range spec.Operations() {
+		if !contains(documented, operation) {
+			t.Errorf("operation %s is in the OpenAPI document, but it's not registered", operation)
+		}
+	}
+}
+
+func TestVersionsServeOnlyTheirRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := newRouter(services.Services{}, &config.Config{})
+
+	// v2 declares only the organizers, the other routes of v1 and its admin routes are not published under it
+	want := []string{"GET /api/v2/organizer", "POST /api/v2/organizer", "GET /api/v2/organizer/:id"}
+	got := make([]string, 0)
+	for _, route := range r.Routes() {
+		if strings.HasPrefix(route.Path, "/api/v2/") {
+			got = append(got, route.Method+" "+route.Path)
+		}
+	}
+
+	if len(got) != len(want) {
+		t.Errorf("v2 routes = %v, want %v", got, want)
+	}
+	for _, route := range want {
+		if !contains(got, route) {
+			t.Errorf("route %s is not registered", route)
+		}
+	}
+}
+
+func TestRangeRoutesByIDAreGone(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := newRouter(services.Services{}, &config.Config{})
+
+	// the ranges have no ids since they're a part of the event, the old routes must not read event ids
+	for _, path := range []string{"/api/v1/founding_range/", "/api/v1/co_founding_range/"} {
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path+uuid.NewString(), nil))
+		if recorder.Code != http.StatusGone {
+			t.Errorf("GET %s{id} = %d, want %d", path, recorder.Code, http.StatusGone)
+		}
+	}
+}
+
+func TestDeprecatedVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	cfg := &config.Config{}
+	cfg.API.Versions = map[string]config.APIVersionConfig{
+		"v1": {
+			Deprecated: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Sunset:     time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+			Successor:  "/api/v2",
+		},
+	}
+	cfg.API.UsageLogInterval = time.Hour
+	r := newRouter(services.Services{}, cfg)
+
+	// the route does not use the services, so it's served without them
+	path := "/api/v1/founding_range/" + uuid.NewString()
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	request.Header.Set("User-Agent", "legacy-client")
+	r.ServeHTTP(recorder, request)
+
+	headers := map[string]string{
+		"Deprecation": "@1767225600",
+		"Sunset":      "Thu, 31 Dec 2026 00:00:00 GMT",
+		"Link":        `</api/v2>; rel="successor-version"`,
+	}
+	for name, want := range headers {
+		if got := recorder.Header().Get(name); got != want {
+			t.Errorf("header %s = %q, want %q", name, got, want)
+		}
+	}
+
+	want := "deprecated route GET /api/v1/founding_range/:id is used by address 192.0.2.1(legacy-client)"
+	if !strings.Contains(logged.String(), want) {
+		t.Errorf("log = %q, want %q", logged.String(), want)
+	}
+
+	// the next requests of the client are counted, but not logged until the interval passes
+	logged.Reset()
+	r.ServeHTTP(httptest.NewRecorder(), request)
+	if strings.Contains(logged.String(), "deprecated route") {
+		t.Errorf("the second request is logged within the interval: %q", logged.String())
+	}
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 )
 
@@ -16,8 +17,42 @@ const (
 	defaultRefreshTTL = time.Hour * 24 * 14
 
 	envLocal = "local"
+
+	SearchEnginePostgres = "postgres"
+	SearchEngineMemory   = "memory"
+
+	defaultReminderInterval    = time.Hour
+	defaultSavedSearchInterval = 5 * time.Minute
+	defaultSMTPPort            = "25"
+
+	defaultWebhookInterval    = 10 * time.Second
+	defaultWebhookTimeout     = 10 * time.Second
+	defaultWebhookMaxAttempts = 8
+	defaultWebhookBackoffBase = 30 * time.Second
+	defaultWebhookBackoffMax  = time.Hour
+
+	defaultOutboxInterval    = time.Second
+	defaultOutboxRetention   = 7 * 24 * time.Hour
+	defaultOutboxNATSSubject = "map-of-events"
+	defaultOutboxTimeout     = 5 * time.Second
+
+	defaultStreamBufferSize = 1000
+
+	defaultGraphQLMaxDepth      = 10
+	defaultGraphQLMaxComplexity = 2000
+
+	defaultGRPCPort = "9000"
+
+	defaultAPIUsageLogInterval = time.Hour
+
+	defaultReferenceCurrency = "RUB"
+
+	// dateLayout - layout of the dates in the configuration
+	dateLayout = "2006-01-02"
 )
 
+var defaultReminderDaysBefore = []int{7, 1}
+
 type (
 	HTTPConfig struct {
 		Port                string        `mapstructure:"port"`
@@ -38,13 +73,152 @@ type (
 		HTTP        HTTPConfig
 		Postgres    PostgresConfig
 		Auth        AuthConfig
+		Search      SearchConfig
+		Reminder    ReminderConfig
+		SavedSearch SavedSearchConfig
+		SMTP        SMTPConfig
+		Webhook     WebhookConfig
+		Outbox      OutboxConfig
+		Stream      StreamConfig
+		GraphQL     GraphQLConfig
+		GRPC        GRPCConfig
+		API         APIConfig
+		Currency    CurrencyConfig
 		Environment string
 	}
 
+	// CurrencyConfig - the funding amounts are converted to the reference currency by the rates
+	// for filtering, sorting and the aggregates, the amounts in the currencies without a rate are not converted
+	CurrencyConfig struct {
+		// Reference - ISO 4217 code of the currency
+		Reference string `mapstructure:"reference"`
+		// Rates - units of the reference currency for one unit of the currency, by ISO 4217 codes
+		Rates map[string]float64 `mapstructure:"rates"`
+	}
+
+	// APIConfig - the versions of the HTTP API(v1, v2, v3)
+	APIConfig struct {
+		// Versions - by the names of the versions, the versions not listed are enabled and not deprecated
+		Versions map[string]APIVersionConfig `mapstructure:"versions"`
+		// UsageLogInterval - a client's usage of a deprecated route is logged at the first request
+		// and then at most once per the interval
+		UsageLogInterval time.Duration `mapstructure:"usageLogInterval"`
+	}
+
+	// APIVersionConfig - the dates are given as 2006-01-02
+	APIVersionConfig struct {
+		// Disabled - the version responds to everything with 410
+		Disabled bool `mapstructure:"disabled"`
+		// Deprecated - the date the version is deprecated since, it's zero if the version is not deprecated
+		Deprecated time.Time `mapstructure:"deprecated"`
+		// Sunset - the date the version will be turned off
+		Sunset time.Time `mapstructure:"sunset"`
+		// Successor - path of the version replacing this one, e.g. /api/v2
+		Successor string `mapstructure:"successor"`
+	}
+
+	// GraphQLConfig - limits of the GraphQL queries, the queries exceeding them are rejected before the execution
+	GraphQLConfig struct {
+		MaxDepth int `mapstructure:"maxDepth"`
+		// MaxComplexity - the maximum number of the resolved fields, the fields of a list are counted 10 times
+		MaxComplexity int `mapstructure:"maxComplexity"`
+	}
+
+	// GRPCConfig - the gRPC API served on its own port
+	GRPCConfig struct {
+		Enabled bool   `mapstructure:"enabled"`
+		Port    string `mapstructure:"port"`
+		// Reflection - the services are described to the clients like grpcurl
+		Reflection bool `mapstructure:"reflection"`
+	}
+
+	// StreamConfig - the live stream of the changes
+	StreamConfig struct {
+		// BufferSize - the number of the latest changes kept for the clients resuming the stream
+		BufferSize int `mapstructure:"bufferSize"`
+		// MaxDuration - the stream is ended after it, so the server's write timeout does not break it,
+		// the clients reconnect and resume, a second less than http.writeTimeout is used if it's zero
+		MaxDuration time.Duration `mapstructure:"maxDuration"`
+	}
+
+	// OutboxConfig - relay of the domain events from the outbox, the events are always published
+	// to the in-process bus, NATS and HTTP are the optional external publishers
+	OutboxConfig struct {
+		// Interval - how often the unpublished events are looked for
+		Interval time.Duration `mapstructure:"interval"`
+		// Retention - how long the published events are kept, they're kept forever if it's zero
+		Retention time.Duration    `mapstructure:"retention"`
+		NATS      OutboxNATSConfig `mapstructure:"nats"`
+		HTTP      OutboxHTTPConfig `mapstructure:"http"`
+	}
+
+	OutboxNATSConfig struct {
+		Enabled bool `mapstructure:"enabled"`
+		// URL - nats://[user:password@]host:port
+		URL string `mapstructure:"url"`
+		// Subject - prefix of the subjects, an event is published to "<subject>.<type>"
+		Subject string        `mapstructure:"subject"`
+		Timeout time.Duration `mapstructure:"timeout"`
+	}
+
+	OutboxHTTPConfig struct {
+		Enabled bool `mapstructure:"enabled"`
+		// URL - the events are posted to it
+		URL     string        `mapstructure:"url"`
+		Timeout time.Duration `mapstructure:"timeout"`
+	}
+
+	// WebhookConfig - delivery of the webhooks, a failed delivery is retried after BackoffBase * 2^(attempt - 1),
+	// but not later than BackoffMax, after MaxAttempts the delivery is moved to the dead letters
+	WebhookConfig struct {
+		Enabled bool `mapstructure:"enabled"`
+		// Interval - how often the due deliveries are looked for
+		Interval    time.Duration `mapstructure:"interval"`
+		Timeout     time.Duration `mapstructure:"timeout"`
+		MaxAttempts int           `mapstructure:"maxAttempts"`
+		BackoffBase time.Duration `mapstructure:"backoffBase"`
+		BackoffMax  time.Duration `mapstructure:"backoffMax"`
+	}
+
+	// ReminderConfig - deadline reminders sent by email to the users, who starred the events
+	ReminderConfig struct {
+		Enabled bool `mapstructure:"enabled"`
+		// Interval - how often the due reminders are looked for
+		Interval time.Duration `mapstructure:"interval"`
+		// DaysBefore - days before the deadline to remind at, used when the user has no preferences
+		DaysBefore []int `mapstructure:"daysBefore"`
+	}
+
+	// SavedSearchConfig - delivery of the alerts about events matching saved searches
+	SavedSearchConfig struct {
+		Enabled bool `mapstructure:"enabled"`
+		// Interval - how often the alerts due by the frequency of the searches are delivered
+		Interval time.Duration `mapstructure:"interval"`
+	}
+
+	SMTPConfig struct {
+		Host     string `mapstructure:"host"`
+		Port     string `mapstructure:"port"`
+		Username string `mapstructure:"username"`
+		Password string
+		// From - address of the sender
+		From string `mapstructure:"from"`
+	}
+
+	SearchConfig struct {
+		// Engine - "postgres" to search using the database, "memory" to use in-process index
+		Engine string `mapstructure:"engine"`
+		// Fill - the "memory" index is filled with the stored events on the start,
+		// without it the index contains only the events created or updated after the start
+		Fill bool `mapstructure:"fill"`
+	}
+
 	AuthConfig struct {
 		AccessTTL  time.Duration `mapstructure:"accessTokenTTL"`
 		RefreshTTL time.Duration `mapstructure:"refreshTokenTTL"`
 		SigningKey string        `mapstructure:"key"`
+		// Admins - ids of the users allowed to use the administration routes
+		Admins []string `mapstructure:"admins"`
 	}
 )
 
@@ -67,13 +241,46 @@ func Init(configDir string) (*Config, error) {
 }
 
 func populateDefaults() {
-	viper.SetDefault("auth.refresh_token_ttl", defaultRefreshTTL)
-	viper.SetDefault("auth.access_token_ttl", defaultAccessTTL)
+	viper.SetDefault("auth.refreshTokenTTL", defaultRefreshTTL)
+	viper.SetDefault("auth.accessTokenTTL", defaultAccessTTL)
 
 	viper.SetDefault("http.port", defaultHTTPPort)
 	viper.SetDefault("http.timeouts.write", defaultHTTPRWTimeout)
 	viper.SetDefault("http.timeouts.read", defaultHTTPRWTimeout)
 	viper.SetDefault("http.max_header_megabytes", defaultHTTPMaxHeaderMegaBytes)
+
+	viper.SetDefault("search.engine", SearchEnginePostgres)
+	viper.SetDefault("search.fill", true)
+
+	viper.SetDefault("reminder.interval", defaultReminderInterval)
+	viper.SetDefault("reminder.daysBefore", defaultReminderDaysBefore)
+
+	viper.SetDefault("savedSearch.interval", defaultSavedSearchInterval)
+
+	viper.SetDefault("smtp.port", defaultSMTPPort)
+
+	viper.SetDefault("webhook.interval", defaultWebhookInterval)
+	viper.SetDefault("webhook.timeout", defaultWebhookTimeout)
+	viper.SetDefault("webhook.maxAttempts", defaultWebhookMaxAttempts)
+	viper.SetDefault("webhook.backoffBase", defaultWebhookBackoffBase)
+	viper.SetDefault("webhook.backoffMax", defaultWebhookBackoffMax)
+
+	viper.SetDefault("outbox.interval", defaultOutboxInterval)
+	viper.SetDefault("outbox.retention", defaultOutboxRetention)
+	viper.SetDefault("outbox.nats.subject", defaultOutboxNATSSubject)
+	viper.SetDefault("outbox.nats.timeout", defaultOutboxTimeout)
+	viper.SetDefault("outbox.http.timeout", defaultOutboxTimeout)
+
+	viper.SetDefault("stream.bufferSize", defaultStreamBufferSize)
+
+	viper.SetDefault("graphql.maxDepth", defaultGraphQLMaxDepth)
+	viper.SetDefault("graphql.maxComplexity", defaultGraphQLMaxComplexity)
+
+	viper.SetDefault("grpc.port", defaultGRPCPort)
+
+	viper.SetDefault("api.usageLogInterval", defaultAPIUsageLogInterval)
+
+	viper.SetDefault("currency.reference", defaultReferenceCurrency)
 }
 
 func parseConfigFile(dir string, env string) error {
@@ -98,6 +305,58 @@ func unmarshal(c *Config) error {
 		return err
 	}
 
+	if err := viper.UnmarshalKey("search", &c.Search); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("auth", &c.Auth); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("reminder", &c.Reminder); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("savedSearch", &c.SavedSearch); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("smtp", &c.SMTP); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("webhook", &c.Webhook); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("outbox", &c.Outbox); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("stream", &c.Stream); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("graphql", &c.GraphQL); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("grpc", &c.GRPC); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("currency", &c.Currency); err != nil {
+		return err
+	}
+
+	dates := viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToTimeHookFunc(dateLayout),
+	))
+	if err := viper.UnmarshalKey("api", &c.API, dates); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -106,6 +365,8 @@ func setFromEnv(c *Config) {
 
 	c.Auth.SigningKey = os.Getenv("SECRET")
 
+	c.SMTP.Password = os.Getenv("SMTP_PASSWORD")
+
 	c.Postgres.Name = os.Getenv("POSTGRES_DB_NAME")
 	c.Postgres.User = os.Getenv("POSTGRES_DB_USER")
 	c.Postgres.Password = os.Getenv("POSTGRES_DB_PASSWORD")
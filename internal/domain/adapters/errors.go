@@ -0,0 +1,12 @@
+package adapters
+
+import "errors"
+
+// ErrStaleVersion - returned by the storages, when the object was changed since it was read
+var ErrStaleVersion = errors.New("stored object has a different version")
+
+// ErrNotFound - returned by the storages, when the requested object does not exist
+var ErrNotFound = errors.New("object was not found")
+
+// ErrAlreadyExists - returned by the storages, when an object with the same unique fields is already stored
+var ErrAlreadyExists = errors.New("object already exists")
@@ -0,0 +1,15 @@
+package adapters
+
+import "context"
+
+// Mail - a plain text email message
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// MailSender - interface for delivering emails
+type MailSender interface {
+	Send(ctx context.Context, mail Mail) error
+}
@@ -0,0 +1,15 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+)
+
+// DomainEventPublisher - interface for publishing the domain events relayed from the outbox,
+// the same event can be published more than once, so the subscribers should be idempotent
+type DomainEventPublisher interface {
+	// Name - a stable name of the publisher, the outbox keeps the position of every publisher by it
+	Name() string
+	Publish(ctx context.Context, event models.DomainEvent) error
+}
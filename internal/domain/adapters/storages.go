@@ -2,6 +2,7 @@ package adapters
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -22,6 +23,8 @@ const (
 type StorageWithTransaction interface {
 	// BeginTransaction - creates a transaction and returns an object of transaction as interface{}
 	BeginTransaction(ctx context.Context) (interface{}, error)
+	// CommitTransaction - commits all changes made in the transaction
+	CommitTransaction(ctx context.Context, transaction interface{}) error
 	// CloseTransaction is a closing method of transactions
 	CloseTransaction(ctx context.Context, transaction interface{}) error
 }
@@ -34,12 +37,16 @@ type CompetitorStorage interface {
 	Get(ctx context.Context, id uuid.UUID) (models.Competitor, errors.Error)
 	// GetAll - get all existing competitors
 	GetAll(ctx context.Context) ([]models.Competitor, errors.Error)
+	// GetByIDs - get the competitors with given ids in one query, missing ones are skipped
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Competitor, errors.Error)
 	// Create - creates a new competitor in storage
 	Create(ctx context.Context, competitor models.Competitor) errors.Error
 	// Update - updates a competitor in storage
 	Update(ctx context.Context, competitor models.Competitor) errors.Error
 	// Delete - deletes competitor in storage with given ID
 	Delete(ctx context.Context, id uuid.UUID) errors.Error
+	// Suggest - returns at most limit competitors with names similar to the text, the most similar first
+	Suggest(ctx context.Context, text string, limit int) ([]models.Suggestion, errors.Error)
 
 	StorageWithTransaction
 }
@@ -48,32 +55,137 @@ type CompetitorStorage interface {
 type SubjectStorage interface {
 	// GetByID - get subject with given ID
 	GetByID(ctx context.Context, id uuid.UUID) (models.Subject, error)
-	// GetByEvent - get subject by event id
+	// GetByName - get subject which name or one of synonyms equals to the name(case-insensitive),
+	// returns ErrNotFound if there is no such subject
+	GetByName(ctx context.Context, name string) (models.Subject, error)
+	// GetByEvent - get ids of subjects linked to the event
 	GetByEvent(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	// GetByEvents - get subjects of all given events in one call, grouped by event id
+	GetByEvents(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]models.Subject, error)
 	// GetAll - get all existing subjects
 	GetAll(ctx context.Context) ([]models.Subject, error)
+	// GetByIDs - get the subjects with given ids in one query, missing ones are skipped
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Subject, error)
 	// Add - adds new subject to the storage
 	Add(ctx context.Context, subject models.Subject) error
-	// Delete - deletes subject with given id from the storage
+	// Delete - deletes subject with given id from the storage, links to the events are deleted with it
 	Delete(ctx context.Context, id uuid.UUID) error
 	// Update - updates a subject in storage(will return an error, if it does not exist)
 	Update(ctx context.Context, subject models.Subject) error
+	// SetForEvent - replaces subjects linked to the event with given ones
+	SetForEvent(ctx context.Context, eventId uuid.UUID, subjects []uuid.UUID) error
+	// Suggest - returns at most limit distinct subject names similar to the text, the most similar first
+	Suggest(ctx context.Context, text string, limit int) ([]models.Suggestion, error)
 
 	StorageWithTransaction
 }
 
-// RangeStorage - a generic interface for storing models.RangeModel in the storages
-type RangeStorage interface {
-	// GetByID - get range by it's id
-	GetByID(ctx context.Context, id uuid.UUID) (models.RangeModel, error)
+// ProfileStorage - interface for storing models.Profile
+type ProfileStorage interface {
+	// GetByID - get profile with given id, returns ErrNotFound if it does not exist
+	GetByID(ctx context.Context, id uuid.UUID) (models.Profile, error)
+	// GetAll - get all stored profiles
+	GetAll(ctx context.Context) ([]models.Profile, error)
+	// Add - adds new profile to the storage
+	Add(ctx context.Context, profile models.Profile) error
+	// Update - updates a profile in storage, returns ErrNotFound if it does not exist
+	Update(ctx context.Context, profile models.Profile) error
+	// Delete - deletes profile with given id from the storage
+	Delete(ctx context.Context, id uuid.UUID) error
+}
 
-	GetMaximumRange(ctx context.Context) (models.RangeModel, error)
-	Create(ctx context.Context, foundingRange models.RangeModel) (models.RangeModel, error)
+// ApplicationStorage - interface for storing models.Application with its history, notes and files
+type ApplicationStorage interface {
+	// GetByID - get application with given id, returns ErrNotFound if it does not exist
+	GetByID(ctx context.Context, id uuid.UUID) (models.Application, error)
+	// GetByEvent - get all applications to the event
+	GetByEvent(ctx context.Context, eventId uuid.UUID) ([]models.Application, error)
+	// GetByTeam - get all applications of the team
+	GetByTeam(ctx context.Context, teamId uuid.UUID) ([]models.Application, error)
+	// Add - adds new application with its history to the storage
+	Add(ctx context.Context, application models.Application) error
+	// ChangeStage - sets the stage of the application and adds the change to its history
+	ChangeStage(ctx context.Context, id uuid.UUID, change models.StageChange) error
+	// AddNote - adds a note to the application
+	AddNote(ctx context.Context, id uuid.UUID, note models.ApplicationNote) error
+	// AddFile - attaches a file to the application
+	AddFile(ctx context.Context, id uuid.UUID, file models.ApplicationFile) error
+	// Delete - deletes application with its history, notes and files
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	StorageWithTransaction
 }
 
+// FavouriteStorage - interface for storing models.Favourite
+type FavouriteStorage interface {
+	// Add - stars the event for the user, does nothing if it's already starred
+	Add(ctx context.Context, favourite models.Favourite) error
+	// Remove - un-stars the event for the user
+	Remove(ctx context.Context, user, event uuid.UUID) error
+	// GetByUser - get all favourites of the user, the latest first
+	GetByUser(ctx context.Context, user uuid.UUID) ([]models.Favourite, error)
+	// Filter - returns those of the events, that are favourite for the user
+	Filter(ctx context.Context, user uuid.UUID, events []uuid.UUID) ([]uuid.UUID, error)
+	// GetByEvents - get all favourites of the given events
+	GetByEvents(ctx context.Context, events []uuid.UUID) ([]models.Favourite, error)
+}
+
+// NotificationPreferencesStorage - interface for storing models.NotificationPreferences
+type NotificationPreferencesStorage interface {
+	// Get - returns ErrNotFound if the user has not saved the preferences
+	Get(ctx context.Context, user uuid.UUID) (models.NotificationPreferences, error)
+	// GetByUsers - get saved preferences of the users, keyed by user id
+	GetByUsers(ctx context.Context, users []uuid.UUID) (map[uuid.UUID]models.NotificationPreferences, error)
+	// Save - creates or replaces preferences of the user
+	Save(ctx context.Context, preferences models.NotificationPreferences) error
+}
+
+// ReminderDeliveryStorage - interface for storing models.ReminderDelivery
+type ReminderDeliveryStorage interface {
+	// Add - records the delivery, returns false if it is already recorded
+	Add(ctx context.Context, delivery models.ReminderDelivery) (bool, error)
+	// Remove - deletes the record, so the reminder can be sent again
+	Remove(ctx context.Context, user, event uuid.UUID, daysBefore int) error
+}
+
+// SavedSearchStorage - interface for storing models.SavedSearch
+type SavedSearchStorage interface {
+	GetAll(ctx context.Context) ([]models.SavedSearch, error)
+	GetByUser(ctx context.Context, user uuid.UUID) ([]models.SavedSearch, error)
+	// GetByID - returns ErrNotFound if the search does not exist
+	GetByID(ctx context.Context, id uuid.UUID) (models.SavedSearch, error)
+	Add(ctx context.Context, search models.SavedSearch) error
+	Update(ctx context.Context, search models.SavedSearch) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// SetDelivered - sets the time of the last delivery of the search's alerts
+	SetDelivered(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// SearchAlertStorage - interface for storing models.SearchAlert
+type SearchAlertStorage interface {
+	// Add - stores the alerts, an alert about the event already stored for the search is skipped
+	Add(ctx context.Context, alerts []models.SearchAlert) error
+	// GetPending - get the alerts of the search waiting for the delivery, the oldest first
+	GetPending(ctx context.Context, search uuid.UUID) ([]models.SearchAlert, error)
+	// MarkDelivered - sets the delivery time of the alerts
+	MarkDelivered(ctx context.Context, ids []uuid.UUID, at time.Time) error
+	// GetDelivered - get delivered in-app alerts of the user's searches, the latest first
+	GetDelivered(ctx context.Context, user uuid.UUID, unreadOnly bool) ([]models.SearchAlert, error)
+	// MarkRead - sets the read time of the user's alerts, all delivered alerts if ids is empty
+	MarkRead(ctx context.Context, user uuid.UUID, ids []uuid.UUID, at time.Time) error
+}
+
+// RangeStorage - reads the founding or the co-founding ranges of the events, they're written
+// with the events by EventStorage
+type RangeStorage interface {
+	// GetByEvent - the range of the event with the id
+	GetByEvent(ctx context.Context, event uuid.UUID) (models.RangeModel, error)
+
+	// GetMaximumRange - the lowest and the highest bounds of all ranges, the bounds in the currencies
+	// of the factors are multiplied by them, the others are skipped, the factors are nil for the ranges without a currency
+	GetMaximumRange(ctx context.Context, factors map[string]float64) (models.RangeModel, error)
+}
+
 // OrganizerStorage - interface for storing models.Organizer and their levels(models.OrganizerLevel)
 type OrganizerStorage interface {
 	// GetAllIDs - returns IDs of all organizers
@@ -82,16 +194,25 @@ type OrganizerStorage interface {
 	GetByID(ctx context.Context, id uuid.UUID) (models.Organizer, error)
 	// GetAll - returns all organizers
 	GetAll(ctx context.Context) ([]models.Organizer, error)
+	// GetByIDs - returns the organizers with given IDs in one query, missing ones are skipped
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Organizer, error)
 	// Add - adds new organizer to the storage
 	Add(ctx context.Context, organizer models.Organizer) error
-	// Remove - removes an organizer with given id from storage
-	Remove(ctx context.Context, id uuid.UUID) error
+	// Remove - removes an organizer with given id and version from storage,
+	// returns ErrStaleVersion if the stored organizer has a different version
+	Remove(ctx context.Context, id uuid.UUID, version int) error
+	// Update - updates an organizer if the stored one has the same version and increases the version,
+	// returns ErrStaleVersion otherwise
 	Update(ctx context.Context, organizer models.Organizer) error
+	// Suggest - returns at most limit organizers with names similar to the text, the most similar first
+	Suggest(ctx context.Context, text string, limit int) ([]models.Suggestion, error)
 
 	// GetLevelsIDs - returns IDs of all organizer levels
 	GetLevelsIDs(ctx context.Context) ([]uuid.UUID, error)
 	// GetLevels - returns all organizer levels from storage
 	GetLevels(ctx context.Context) ([]models.OrganizerLevel, error)
+	// GetLevelsByIDs - returns the organizer levels with given IDs in one query, missing ones are skipped
+	GetLevelsByIDs(ctx context.Context, ids []uuid.UUID) ([]models.OrganizerLevel, error)
 	// AddLevel - adds a new organizer level to the storage
 	AddLevel(ctx context.Context, level models.OrganizerLevel) error
 	// RemoveLevel - removes level from the storage with given ID
@@ -120,11 +241,15 @@ type EventStorage interface {
 	GetIDList(ctx context.Context) ([]uuid.UUID, error)
 	// GetByID - returns models.Event with given ID
 	GetByID(ctx context.Context, id uuid.UUID) (models.Event, error)
+	// GetByIDs - returns all events with given IDs(with their competitors) in a constant number of queries
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Event, error)
 	// Add - adds a new event to the storage
 	Add(ctx context.Context, event models.Event) error
-	// Remove - removes event with given ID from the storage
-	Remove(ctx context.Context, id uuid.UUID) error
-	// Update - updates event in the storage
+	// Remove - removes event with given ID and version from the storage,
+	// returns ErrStaleVersion if the stored event has a different version
+	Remove(ctx context.Context, id uuid.UUID, version int) error
+	// Update - updates event in the storage if the stored one has the same version and increases the version,
+	// returns ErrStaleVersion otherwise
 	Update(ctx context.Context, event models.Event) error
 
 	// AddCompetitor - adds a competitor(competitorId) for event(id)
@@ -133,10 +258,22 @@ type EventStorage interface {
 	RemoveCompetitor(ctx context.Context, id, competitorId uuid.UUID) error
 	// GetCompetitors - get competitors for event with given id
 	GetCompetitors(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	// GetCompetitorsForEvents - get competitors for all given events, grouped by event id
+	GetCompetitorsForEvents(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]uuid.UUID, error)
 
 	StorageWithTransaction
 }
 
+// EventSearchStorage - interface for full-text search over events
+type EventSearchStorage interface {
+	// Search - returns at most limit events matching the query, ordered by rank
+	Search(ctx context.Context, query string, limit int) ([]models.EventSearchHit, error)
+	// Index - adds or replaces the event with its subjects in the index
+	Index(ctx context.Context, event models.Event, subjects []string) error
+	// Remove - removes the event from the index
+	Remove(ctx context.Context, id uuid.UUID) error
+}
+
 type UserStorage interface {
 	GetByID(ctx context.Context, id uuid.UUID) (models.User, error)
 	GetByName(ctx context.Context, name string) (models.User, error)
@@ -150,6 +287,61 @@ type SessionStorage interface {
 	GetByToken(ctx context.Context, token string) (models.TokenSession, error)
 	GetByUser(ctx context.Context, id uuid.UUID) (models.TokenSession, error)
 
+	// Create - stores the session, the previous session of the user is replaced
 	Create(ctx context.Context, session models.TokenSession) error
 	Delete(ctx context.Context, token string) error
 }
+
+// WebhookStorage - interface for storing models.Webhook
+type WebhookStorage interface {
+	GetAll(ctx context.Context) ([]models.Webhook, error)
+	// GetByType - get active webhooks subscribed to the type of changes
+	GetByType(ctx context.Context, changeType string) ([]models.Webhook, error)
+	// GetByID - returns ErrNotFound if the webhook does not exist
+	GetByID(ctx context.Context, id uuid.UUID) (models.Webhook, error)
+	Add(ctx context.Context, webhook models.Webhook) error
+	// Update - returns ErrNotFound if the webhook does not exist
+	Update(ctx context.Context, webhook models.Webhook) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// WebhookDeliveryStorage - interface for storing models.WebhookDelivery with their attempts
+type WebhookDeliveryStorage interface {
+	// Add - adds the deliveries, a delivery with an existing id is skipped
+	Add(ctx context.Context, deliveries []models.WebhookDelivery) error
+	// GetDue - get at most limit pending deliveries, which next attempt is not later than now, the oldest first
+	GetDue(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error)
+	// GetByID - returns ErrNotFound if the delivery does not exist
+	GetByID(ctx context.Context, id uuid.UUID) (models.WebhookDelivery, error)
+	// GetByWebhook - get the latest deliveries of the webhook, all statuses if status is empty
+	GetByWebhook(ctx context.Context, webhook uuid.UUID, status string, limit int) ([]models.WebhookDelivery, error)
+	// GetByStatus - get the latest deliveries with the status
+	GetByStatus(ctx context.Context, status string, limit int) ([]models.WebhookDelivery, error)
+	// Record - saves status, attempts and next attempt of the delivery with the attempt's log record
+	Record(ctx context.Context, delivery models.WebhookDelivery, attempt models.WebhookAttempt) error
+	// Requeue - makes the delivery pending with the next attempt at the time, the attempts are reset
+	Requeue(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// OutboxStorage - interface for the outbox of models.DomainEvent,
+// an event is added with the connection of ctx, so it's stored in the transaction of the change
+type OutboxStorage interface {
+	Add(ctx context.Context, event models.DomainEvent) error
+	// GetUnpublished - get at most limit events not published to the publisher yet in the order they were added,
+	// the events already published to all publishers are not returned
+	GetUnpublished(ctx context.Context, publisher string, limit int) ([]models.DomainEvent, error)
+	// MarkPublished - records that the event is published to the publisher
+	MarkPublished(ctx context.Context, publisher string, id uuid.UUID) error
+	// MarkPublishedToAll - sets the publication time of the events published to all given publishers,
+	// returns the number of the marked events
+	MarkPublishedToAll(ctx context.Context, publishers []string, at time.Time) (int, error)
+	// RemovePublished - removes the events published before the time, returns the number of removed events
+	RemovePublished(ctx context.Context, before time.Time) (int, error)
+}
+
+// AnalyticsStorage - aggregates of the stored events
+type AnalyticsStorage interface {
+	// Aggregate - groups the events matching the filter by the dimension(one of models.AnalyticsBy...),
+	// the founding ranges are multiplied by the factors of their currencies
+	Aggregate(ctx context.Context, dimension string, filter models.AnalyticsFilter, factors map[string]float64) ([]models.AnalyticsGroup, error)
+}
@@ -0,0 +1,12 @@
+package adapters
+
+import (
+	"context"
+	"net/http"
+)
+
+// WebhookSender - interface for posting webhook payloads
+type WebhookSender interface {
+	// Post - sends the body, returns status of the response, an error is returned if there is no response
+	Post(ctx context.Context, url string, header http.Header, body []byte) (int, error)
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,20 +18,200 @@ type Organizer struct {
 	Name  string
 	Logo  string
 	Level uuid.UUID
+	// Version - a revision of the organizer, it's increased on every update
+	Version int
 }
 
+// RangeModel - a value object of the event, it's stored and changed with the event
 type RangeModel struct {
-	ID   uuid.UUID
-	Low  int
-	High int
+	Low  int64
+	High int64
+	// Currency - ISO 4217 code, the bounds of a founding range are amounts in its minor units,
+	// it's empty for a co-founding range, whose bounds are percents
+	Currency string
+}
+
+func (r RangeModel) LowMoney() Money {
+	return Money{Amount: r.Low, Currency: r.Currency}
+}
+
+func (r RangeModel) HighMoney() Money {
+	return Money{Amount: r.High, Currency: r.Currency}
+}
+
+// Money - an amount in the minor units of the currency, e.g. kopecks of RUB
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// Major - the amount in the major units of the currency, the minor units are truncated
+func (m Money) Major() int64 {
+	return m.Amount / MinorUnits(m.Currency)
+}
+
+// MoneyFromMajor - the money of the amount in the major units of the currency
+func MoneyFromMajor(amount int64, currency string) Money {
+	return Money{Amount: amount * MinorUnits(currency), Currency: currency}
+}
+
+// CurrencyExponents - numbers of the digits of the minor units of the supported ISO 4217 currencies
+var CurrencyExponents = map[string]int{
+	"RUB": 2,
+	"BYN": 2,
+	"KZT": 2,
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"CHF": 2,
+	"CNY": 2,
+	"INR": 2,
+	"TRY": 2,
+	"AED": 2,
+	"JPY": 0,
+	"KRW": 0,
+}
+
+// Currencies - the codes of the supported currencies in the alphabetical order
+func Currencies() []string {
+	result := make([]string, 0, len(CurrencyExponents))
+	for c := range CurrencyExponents {
+		result = append(result, c)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// MinorUnits - the number of the minor units in a major one, it's 1 for the unknown currencies
+func MinorUnits(currency string) int64 {
+	result := int64(1)
+	for i := 0; i < CurrencyExponents[currency]; i++ {
+		result *= 10
+	}
+	return result
 }
 
 type FoundingRange = RangeModel
 type CoFoundingRange = RangeModel
 
+// Competitor - a category of applicants allowed to take part in an event with its eligibility criteria,
+// zero values of the criteria mean there is no restriction
 type Competitor struct {
-	ID   uuid.UUID
-	Name string
+	ID       uuid.UUID
+	Name     string
+	Category string
+	MinAge   int
+	MaxAge   int
+	// MinDegree - the lowest academic degree an applicant should have
+	MinDegree         string
+	Regions           []string
+	OrganizationTypes []string
+}
+
+const (
+	CompetitorCategoryIndividual   = "individual"
+	CompetitorCategoryTeam         = "team"
+	CompetitorCategoryOrganization = "organization"
+)
+
+// Academic degrees from the lowest to the highest
+const (
+	DegreeBachelor   = "bachelor"
+	DegreeSpecialist = "specialist"
+	DegreeMaster     = "master"
+	DegreeCandidate  = "candidate"
+	DegreeDoctor     = "doctor"
+)
+
+// ApplicantProfile - an applicant, whose eligibility for events is checked
+type ApplicantProfile struct {
+	Category         string
+	Age              int
+	Degree           string
+	Region           string
+	OrganizationType string
+}
+
+// Profile - a stored applicant or team profile, events are recommended by it
+type Profile struct {
+	ID        uuid.UUID
+	Name      string
+	Applicant ApplicantProfile
+	// TRL - technology readiness level of the applicant's project
+	TRL int
+	// Subjects - subjects of interest from the catalogue
+	Subjects []uuid.UUID
+	// NeededFunding - amount of funding the project needs in the major units of the reference currency,
+	// 0 if it's not specified
+	NeededFunding int
+	// CoFundingCapacity - percent of co-funding the applicant can provide
+	CoFundingCapacity int
+}
+
+// Recommendation - an event the profile's applicant can apply to with a score of fit from 0 to 1
+type Recommendation struct {
+	EventID     uuid.UUID
+	Title       string
+	Score       float64
+	Explanation []ScoreComponent
+}
+
+// ScoreComponent - a part of the recommendation's score, the score is a weighted mean of the components
+type ScoreComponent struct {
+	Criterion string
+	Weight    float64
+	Score     float64
+	Reason    string
+}
+
+// Application - an application of a team(Profile) to an event, it's moved through the stages
+type Application struct {
+	ID        uuid.UUID
+	Event     uuid.UUID
+	Team      uuid.UUID
+	Stage     string
+	CreatedAt time.Time
+	// History - all stages of the application in order, the first one is the initial stage
+	History []StageChange
+	Notes   []ApplicationNote
+	Files   []ApplicationFile
+}
+
+const (
+	ApplicationStageIdea        = "idea"
+	ApplicationStagePreparing   = "preparing"
+	ApplicationStageSubmitted   = "submitted"
+	ApplicationStageUnderReview = "under_review"
+	ApplicationStageWon         = "won"
+	ApplicationStageRejected    = "rejected"
+)
+
+// StageChange - the moment the application was moved to the stage
+type StageChange struct {
+	Stage     string
+	ChangedAt time.Time
+}
+
+type ApplicationNote struct {
+	ID        uuid.UUID
+	Text      string
+	CreatedAt time.Time
+}
+
+// ApplicationFile - a file attached to the application, the file itself is stored as an image by Link
+type ApplicationFile struct {
+	Link       string
+	Name       string
+	AttachedAt time.Time
+}
+
+// Eligibility - result of checking an applicant against the competitors of an event
+type Eligibility struct {
+	EventID  uuid.UUID
+	Title    string
+	Eligible bool
+	// Reasons - why the applicant does not qualify, empty for eligible applicants
+	Reasons []string
 }
 
 type Event struct {
@@ -38,8 +219,8 @@ type Event struct {
 	Title               string
 	Organizer           uuid.UUID
 	FoundingType        string
-	FoundingRange       uuid.UUID
-	CoFoundingRange     uuid.UUID
+	FoundingRange       FoundingRange
+	CoFoundingRange     CoFoundingRange
 	SubmissionDeadline  time.Time
 	ConsiderationPeriod string
 	RealisationPeriod   string
@@ -49,14 +230,47 @@ type Event struct {
 	InternalContacts    string
 	TRL                 int
 	Competitors         []uuid.UUID
+	// Version - a revision of the event, it's increased on every update
+	Version int
 }
 
-type Subject struct {
-	ID      uuid.UUID
-	Name    string
+// Suggestion - an existing entity, whose name is similar to the typed text
+type Suggestion struct {
+	ID    uuid.UUID
+	Name  string
+	Score float64
+}
+
+// EventSearchHit - an event found by full-text search
+type EventSearchHit struct {
 	EventID uuid.UUID
+	Title   string
+	Rank    float64
+	// Snippet - a fragment of event's text with matched words wrapped in <b></b>
+	Snippet string
 }
 
+// Subject - an entry of the shared hierarchical catalogue of subjects, events are linked to them
+type Subject struct {
+	ID   uuid.UUID
+	Name string
+	// Parent - id of the parent subject, uuid.Nil for the root subjects
+	Parent   uuid.UUID
+	Synonyms []string
+	Codes    []SubjectCode
+}
+
+// SubjectCode - a code of the subject in a classification(ГРНТИ, OECD FOS)
+type SubjectCode struct {
+	Scheme string
+	Code   string
+}
+
+const (
+	SubjectCodeSchemeGRNTI   = "grnti"
+	SubjectCodeSchemeOECDFOS = "oecd_fos"
+)
+
 type StoredImage struct {
 	Link  string
 	Value []byte
@@ -68,7 +282,212 @@ type User struct {
 	Password string
 }
 
+// Favourite - an event starred by the user
+type Favourite struct {
+	User      uuid.UUID
+	Event     uuid.UUID
+	CreatedAt time.Time
+}
+
 type TokenSession struct {
+	// Token - the hash of the token given to the client
 	Token string
 	User  uuid.UUID
 }
+
+// Languages of the notifications
+const (
+	LanguageRussian = "ru"
+	LanguageEnglish = "en"
+)
+
+// NotificationPreferences - how the user wants to be reminded about deadlines of the favourite events
+type NotificationPreferences struct {
+	User uuid.UUID
+	// Email - address the reminders are sent to, nothing is sent if it's empty
+	Email    string
+	Language string
+	// DaysBefore - days before the submission deadline to send a reminder at
+	DaysBefore []int
+	Enabled    bool
+}
+
+// ReminderDelivery - a record of a reminder sent to the user, the user is reminded
+// about the event only once for every value of DaysBefore
+type ReminderDelivery struct {
+	User       uuid.UUID
+	Event      uuid.UUID
+	DaysBefore int
+	SentAt     time.Time
+}
+
+// EventFilter - criteria of events, empty(zero) criteria are not checked
+type EventFilter struct {
+	Organizers  []uuid.UUID
+	Competitors []uuid.UUID
+	// Subjects - an event should have one of the subjects or their descendants
+	Subjects []uuid.UUID
+	MinTRL   int
+	MaxTRL   int
+	// MinFunding, MaxFunding - the founding range of an event should intersect with them,
+	// they're in the major units of the reference currency, the range is converted to it
+	MinFunding int
+	MaxFunding int
+}
+
+// Delivery channels of the saved search alerts
+const (
+	AlertDeliveryEmail = "email"
+	AlertDeliveryInApp = "in_app"
+)
+
+// Frequencies of the saved search alerts delivery
+const (
+	AlertFrequencyImmediate = "immediate"
+	AlertFrequencyDaily     = "daily"
+	AlertFrequencyWeekly    = "weekly"
+)
+
+// SavedSearch - a filter saved by the user, the user is alerted about new and changed events matching it
+type SavedSearch struct {
+	ID        uuid.UUID
+	User      uuid.UUID
+	Name      string
+	Filter    EventFilter
+	Delivery  string
+	Frequency string
+	CreatedAt time.Time
+	// LastDeliveredAt - zero if the alerts have never been delivered
+	LastDeliveredAt time.Time
+}
+
+// Reasons of the saved search alerts
+const (
+	AlertReasonCreated = "created"
+	AlertReasonUpdated = "updated"
+)
+
+// SearchAlert - an event matching the saved search, the user is alerted about the event once
+type SearchAlert struct {
+	ID        uuid.UUID
+	Search    uuid.UUID
+	Event     uuid.UUID
+	Title     string
+	Reason    string
+	CreatedAt time.Time
+	// DeliveredAt - zero while the alert is waiting for the delivery
+	DeliveredAt time.Time
+	// ReadAt - zero until the user has read the in-app alert
+	ReadAt time.Time
+}
+
+// Types of the changes of the domain objects
+const (
+	ChangeEventCreated     = "event.created"
+	ChangeEventUpdated     = "event.updated"
+	ChangeEventDeleted     = "event.deleted"
+	ChangeOrganizerCreated = "organizer.created"
+	ChangeOrganizerUpdated = "organizer.updated"
+	ChangeOrganizerDeleted = "organizer.deleted"
+)
+
+// Change - a change of a domain object, Object is the object(Event, Organizer) after the change,
+// for deletions it's the object before it was deleted
+type Change struct {
+	// ID - id of the domain event, the same change can be told more than once with the same ID
+	ID         uuid.UUID
+	Type       string
+	ObjectID   uuid.UUID
+	Object     interface{}
+	OccurredAt time.Time
+}
+
+// DomainEvent - a change of a domain object as it's stored in the outbox in the transaction of the change,
+// Payload is JSON of the object(Event, Organizer) after the change, for deletions before it
+type DomainEvent struct {
+	ID          uuid.UUID
+	Type        string
+	AggregateID uuid.UUID
+	Payload     []byte
+	OccurredAt  time.Time
+	// PublishedAt - zero until the relay has published the event to all publishers
+	PublishedAt time.Time
+}
+
+// Webhook - a subscription of an external system to the changes of given types
+type Webhook struct {
+	ID  uuid.UUID
+	URL string
+	// Secret - a key of HMAC signature of the payloads
+	Secret    string
+	Types     []string
+	Active    bool
+	CreatedAt time.Time
+}
+
+// Statuses of the webhook deliveries
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	// WebhookDeliveryDead - the delivery has failed all attempts, it's kept in the dead letters
+	WebhookDeliveryDead = "dead"
+)
+
+// WebhookDelivery - a payload about one change sent to the webhook
+type WebhookDelivery struct {
+	ID            uuid.UUID
+	Webhook       uuid.UUID
+	Type          string
+	Payload       []byte
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	// DeliveredAt - zero until the receiver has accepted the payload
+	DeliveredAt time.Time
+	History     []WebhookAttempt
+}
+
+// WebhookAttempt - a log record of one try to deliver the payload
+type WebhookAttempt struct {
+	At time.Time
+	// StatusCode - status of the receiver's response, 0 if the request has failed
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+}
+
+// Dimensions the events are grouped by in the analytics
+const (
+	AnalyticsByOrganizer     = "organizer"
+	AnalyticsByLevel         = "level"
+	AnalyticsBySubject       = "subject"
+	AnalyticsByCompetitor    = "competitor"
+	AnalyticsByTRL           = "trl"
+	AnalyticsByDeadlineMonth = "deadline_month"
+)
+
+// AnalyticsFilter - the events counted in the analytics, zero bounds of the deadline are not checked
+type AnalyticsFilter struct {
+	// DeadlineFrom, DeadlineTo - the deadline should be in [DeadlineFrom, DeadlineTo)
+	DeadlineFrom time.Time
+	DeadlineTo   time.Time
+}
+
+// AnalyticsGroup - aggregates of the events of one group, an event with several subjects or competitors
+// is counted in the group of each of them, the events without them are in the group with the empty key
+type AnalyticsGroup struct {
+	// Key - id of the organizer, level, subject or competitor, TRL or month of the deadline(2006-01)
+	Key string
+	// Name - name of the organizer, level, subject or competitor
+	Name   string
+	Events int
+	// The sums of the bounds of the founding and co-founding ranges of the events, the founding ranges
+	// are converted to the minor units of the reference currency, the ranges without a rate are not summed
+	FoundingLow    int64
+	FoundingHigh   int64
+	CoFoundingLow  int64
+	CoFoundingHigh int64
+	// Currency - the reference currency of the founding sums
+	Currency string
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+)
+
+// AnalyticsDimensions - the dimensions the events can be grouped by
+var AnalyticsDimensions = []string{
+	models.AnalyticsByOrganizer,
+	models.AnalyticsByLevel,
+	models.AnalyticsBySubject,
+	models.AnalyticsByCompetitor,
+	models.AnalyticsByTRL,
+	models.AnalyticsByDeadlineMonth,
+}
+
+// ValidateAnalyticsQuery - the dimension should be known and the bounds of the deadline should be ordered
+func ValidateAnalyticsQuery(dimension string, filter models.AnalyticsFilter) error {
+	checks := []validators.FieldCheck{
+		validators.String("dimension", dimension, validators.OneOf(AnalyticsDimensions...)),
+	}
+	if !filter.DeadlineFrom.IsZero() && !filter.DeadlineTo.IsZero() && !filter.DeadlineFrom.Before(filter.DeadlineTo) {
+		checks = append(checks, func() validators.Violations {
+			return validators.Violations{{Field: "to", Message: "should be after from"}}
+		})
+	}
+	return validators.Validate(checks...)
+}
+
+// AnalyticsService - statistics of the events for the grants office
+type AnalyticsService interface {
+	// Aggregate - counts the events matching the filter and sums their ranges by the groups of the dimension
+	Aggregate(ctx context.Context, dimension string, filter models.AnalyticsFilter) ([]models.AnalyticsGroup, error)
+}
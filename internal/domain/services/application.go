@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+)
+
+// ErrApplicationExists - returned on Create, when the team has already applied to the event
+var ErrApplicationExists = errors.New("the team has already applied to the event")
+
+// ApplicationStages - all stages of the applications in their usual order
+var ApplicationStages = []string{
+	models.ApplicationStageIdea,
+	models.ApplicationStagePreparing,
+	models.ApplicationStageSubmitted,
+	models.ApplicationStageUnderReview,
+	models.ApplicationStageWon,
+	models.ApplicationStageRejected,
+}
+
+// ValidateApplicationStage - the stage should be one of ApplicationStages, returns validators.Violations
+func ValidateApplicationStage(stage string) error {
+	return validators.Validate(
+		validators.String("stage", stage, validators.Required(), validators.OneOf(ApplicationStages...)),
+	)
+}
+
+// ValidateApplicationNote - checks the note's text, returns validators.Violations
+func ValidateApplicationNote(text string) error {
+	return validators.Validate(
+		validators.String("text", text, validators.Required(), validators.MaxLength(4096)),
+	)
+}
+
+// ValidateApplicationFile - checks the attached file, returns validators.Violations
+func ValidateApplicationFile(name, link string) error {
+	return validators.Validate(
+		validators.String("name", name, validators.Required(), validators.MaxLength(255)),
+		validators.String("link", link, validators.Required(), validators.MaxLength(512)),
+	)
+}
+
+type ApplicationService interface {
+	GetByID(ctx context.Context, id uuid.UUID) (models.Application, error)
+	GetAllForEvent(ctx context.Context, eventId uuid.UUID) ([]models.Application, error)
+	GetAllForTeam(ctx context.Context, teamId uuid.UUID) ([]models.Application, error)
+	// Create - creates an application of the team to the event in the idea stage,
+	// returns ErrApplicationExists if the team has already applied
+	Create(ctx context.Context, eventId, teamId uuid.UUID) (models.Application, error)
+	// ChangeStage - moves the application to the stage, the moment of the change is stored in the history
+	ChangeStage(ctx context.Context, id uuid.UUID, stage string) (models.Application, error)
+	AddNote(ctx context.Context, id uuid.UUID, text string) (models.ApplicationNote, error)
+	// AttachFile - attaches an uploaded file(see ImageService) to the application
+	AttachFile(ctx context.Context, id uuid.UUID, name, link string) (models.ApplicationFile, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -3,14 +3,36 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/validators"
 )
 
 var (
 	AuthErrFailedToLogin  = errors.New("failed to log in to account")
 	AuthErrTokenIsInvalid = errors.New("token is invalid")
 	AuthErrInternalError  = errors.New("internal error")
+	AuthErrUserExists     = errors.New("user with this name already exists")
 )
 
+// passwordMaxBytes - the password is hashed by bcrypt, that accepts at most 72 bytes
+const passwordMaxBytes = 72
+
+// ValidateCredentials - checks the name and the password of a new user, returns validators.Violations
+func ValidateCredentials(name, password string) error {
+	return validators.Validate(
+		validators.String("name", name, validators.Required(), validators.MaxLength(255)),
+		validators.String("password", password, validators.MinLength(8), func(value string) string {
+			if len(value) > passwordMaxBytes {
+				return fmt.Sprintf("should be at most %d bytes long", passwordMaxBytes)
+			}
+			return ""
+		}),
+	)
+}
+
 type AuthService interface {
 	// Login - login as a user with given name and password
 	// if the login process is failed return "", AuthErrFailedToLogin
@@ -27,5 +49,13 @@ type AuthService interface {
 	GetAccess(ctx context.Context, rt string) (string, error)
 
 	// CreateUser - creates a user with given name and password and returns a refresh token for this user
+	// if the name is taken, it returns "", AuthErrUserExists, invalid credentials are validators.Violations
 	CreateUser(ctx context.Context, name, password string) (string, error)
+
+	// Authenticate - returns id of the user, whose session has the token
+	// if there is no such session, it returns uuid.Nil, AuthErrTokenIsInvalid
+	Authenticate(ctx context.Context, token string) (uuid.UUID, error)
+
+	// IsAdmin - reports if the user is allowed to use the administration routes
+	IsAdmin(user uuid.UUID) bool
 }
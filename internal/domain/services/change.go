@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+)
+
+// ChangeTypes - all types of the changes, the listeners can be told about
+var ChangeTypes = []string{
+	models.ChangeEventCreated,
+	models.ChangeEventUpdated,
+	models.ChangeEventDeleted,
+	models.ChangeOrganizerCreated,
+	models.ChangeOrganizerUpdated,
+	models.ChangeOrganizerDeleted,
+}
+
+// ChangeListener - is told about the changes of the domain objects after they're stored,
+// a listener should not fail the change, so it handles its errors itself.
+// The changes come from the outbox at least once, a change told again has the same ID
+type ChangeListener interface {
+	Changed(ctx context.Context, change models.Change)
+}
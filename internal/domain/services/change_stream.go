@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+)
+
+// StreamedChange - a change in the live stream, Seq is its position in the stream,
+// Facts are filled for created and updated events
+type StreamedChange struct {
+	Seq uint64
+	models.Change
+	Facts EventFacts
+}
+
+// ChangeSubscription - changes of the stream satisfying the subscriber's filter
+type ChangeSubscription struct {
+	// Replay - the buffered changes after the last one seen by the subscriber
+	Replay []StreamedChange
+	// Last - the position of the latest change at the moment of the subscription
+	Last uint64
+	// Missed - some changes after the last seen one are not in the buffer anymore,
+	// so the subscriber should reload the objects
+	Missed bool
+	// Changes - the following changes, it's closed when the subscription has ended
+	// (it's too long or the subscriber is too slow), the subscriber may resume after the last received change
+	Changes <-chan StreamedChange
+	// Cancel - ends the subscription, it should be called when the subscriber is gone
+	Cancel func()
+}
+
+// ChangeStreamService - the live stream of the changes of events and organizers with a bounded replay buffer
+type ChangeStreamService interface {
+	ChangeListener
+
+	// Subscribe - subscribes to the changes satisfying the filter, the filter is applied to organizer changes by Organizers only,
+	// and to deleted events without Subjects and Funding, because they're deleted with the event.
+	// The buffered changes after lastSeq are replayed, nothing is replayed if lastSeq is 0
+	Subscribe(ctx context.Context, filter models.EventFilter, lastSeq uint64) ChangeSubscription
+}
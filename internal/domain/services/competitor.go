@@ -6,13 +6,68 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
 	"github.com/indigowar/map-of-events/pkg/errors"
 )
 
+// CompetitorCategories - all known categories of applicants
+var CompetitorCategories = []string{
+	models.CompetitorCategoryIndividual,
+	models.CompetitorCategoryTeam,
+	models.CompetitorCategoryOrganization,
+}
+
+// Degrees - all known academic degrees from the lowest to the highest
+var Degrees = []string{
+	models.DegreeBachelor,
+	models.DegreeSpecialist,
+	models.DegreeMaster,
+	models.DegreeCandidate,
+	models.DegreeDoctor,
+}
+
+// CompetitorInfo - fields of the competitor given by the client
+type CompetitorInfo struct {
+	Name              string
+	Category          string
+	MinAge            int
+	MaxAge            int
+	MinDegree         string
+	Regions           []string
+	OrganizationTypes []string
+}
+
+// Validate - checks the competitor's fields, returns validators.Violations
+func (i CompetitorInfo) Validate() error {
+	checks := []validators.FieldCheck{
+		validators.String("name", i.Name, validators.Required(), validators.MaxLength(255)),
+		validators.String("category", i.Category, validators.Optional(validators.OneOf(CompetitorCategories...))),
+		validators.Int("minAge", i.MinAge, validators.Between(0, 150)),
+		validators.Int("maxAge", i.MaxAge, validators.Between(0, 150)),
+		validators.String("minDegree", i.MinDegree, validators.Optional(validators.OneOf(Degrees...))),
+		validators.Each("regions", i.Regions, validators.Required(), validators.MaxLength(255)),
+		validators.Each("organizationTypes", i.OrganizationTypes, validators.Required(), validators.MaxLength(64)),
+	}
+	if i.MaxAge != 0 && i.MinAge > i.MaxAge {
+		checks = append(checks, validators.Int("maxAge", i.MaxAge, validators.Between(i.MinAge, 150)))
+	}
+	return validators.Validate(checks...)
+}
+
+// ValidateCompetitor - checks the competitor's name, returns validators.Violations
+func ValidateCompetitor(name string) error {
+	return CompetitorInfo{Name: name}.Validate()
+}
+
 type CompetitorService interface {
 	AllIDs(ctx context.Context) ([]uuid.UUID, errors.Error)
 	GetByID(ctx context.Context, id uuid.UUID) (models.Competitor, errors.Error)
 	GetAll(ctx context.Context) ([]models.Competitor, errors.Error)
-	Create(ctx context.Context, name string) (models.Competitor, errors.Error)
+	// GetByIDs - returns the existing competitors of the ids
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Competitor, errors.Error)
+	Create(ctx context.Context, info CompetitorInfo) (models.Competitor, errors.Error)
+	Update(ctx context.Context, id uuid.UUID, info CompetitorInfo) (models.Competitor, errors.Error)
 	Delete(ctx context.Context, id uuid.UUID) errors.Error
+	// Suggest - returns existing competitors with names similar to the text
+	Suggest(ctx context.Context, text string, limit int) ([]models.Suggestion, errors.Error)
 }
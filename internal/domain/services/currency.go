@@ -0,0 +1,27 @@
+package services
+
+import (
+	"github.com/indigowar/map-of-events/internal/domain/models"
+)
+
+// CurrencyService - converts the amounts to the reference currency by the configured rates
+type CurrencyService interface {
+	// Reference - the currency the amounts are converted to, it's used for the amounts given without a currency
+	Reference() string
+	// Convert - converts the money to the reference currency, false if there is no rate of its currency
+	Convert(m models.Money) (models.Money, bool)
+	// Factors - multipliers of the amounts in the minor units of the currencies to the minor units
+	// of the reference currency, they're given to the aggregate queries
+	Factors() map[string]float64
+}
+
+// ReferenceRange - the founding range converted to the reference currency,
+// Currency of the result is empty if there is no rate of the range's currency
+func ReferenceRange(svc CurrencyService, r models.RangeModel) models.RangeModel {
+	low, ok := svc.Convert(r.LowMoney())
+	if !ok {
+		return models.RangeModel{}
+	}
+	high, _ := svc.Convert(r.HighMoney())
+	return models.RangeModel{Low: low.Amount, High: high.Amount, Currency: low.Currency}
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+)
+
+// ValidateApplicantProfile - checks the profile's fields, returns validators.Violations
+func ValidateApplicantProfile(p models.ApplicantProfile) error {
+	return validators.Validate(
+		validators.String("category", p.Category, validators.Required(), validators.OneOf(CompetitorCategories...)),
+		validators.Int("age", p.Age, validators.Between(0, 150)),
+		validators.String("degree", p.Degree, validators.Optional(validators.OneOf(Degrees...))),
+		validators.String("region", p.Region, validators.MaxLength(255)),
+		validators.String("organizationType", p.OrganizationType, validators.MaxLength(64)),
+	)
+}
+
+// CheckCompetitor - returns why the applicant does not match the competitor's criteria,
+// the result is empty if the applicant matches all of them
+func CheckCompetitor(c models.Competitor, p models.ApplicantProfile) []string {
+	reasons := make([]string, 0)
+
+	if c.Category != "" && c.Category != p.Category {
+		reasons = append(reasons, fmt.Sprintf("category should be %s", c.Category))
+	}
+	if c.MinAge != 0 && (p.Age == 0 || p.Age < c.MinAge) {
+		reasons = append(reasons, fmt.Sprintf("age should be at least %d", c.MinAge))
+	}
+	if c.MaxAge != 0 && (p.Age == 0 || p.Age > c.MaxAge) {
+		reasons = append(reasons, fmt.Sprintf("age should be at most %d", c.MaxAge))
+	}
+	if c.MinDegree != "" && degreeRank(p.Degree) < degreeRank(c.MinDegree) {
+		reasons = append(reasons, fmt.Sprintf("degree should be at least %s", c.MinDegree))
+	}
+	if len(c.Regions) != 0 && !containsFold(c.Regions, p.Region) {
+		reasons = append(reasons, fmt.Sprintf("region should be one of: %s", strings.Join(c.Regions, ", ")))
+	}
+	if len(c.OrganizationTypes) != 0 && !containsFold(c.OrganizationTypes, p.OrganizationType) {
+		reasons = append(reasons, fmt.Sprintf("organization type should be one of: %s", strings.Join(c.OrganizationTypes, ", ")))
+	}
+
+	return reasons
+}
+
+// degreeRank - position of the degree in Degrees starting from 1, 0 for no degree
+func degreeRank(degree string) int {
+	for i, v := range Degrees {
+		if v == degree {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), strings.TrimSpace(value)) {
+			return true
+		}
+	}
+	return false
+}
+
+type EligibilityService interface {
+	// Check - checks the applicant against all events, the applicant qualifies for an event
+	// if it matches at least one of its competitors(or the event has no competitors)
+	Check(ctx context.Context, profile models.ApplicantProfile) ([]models.Eligibility, error)
+}
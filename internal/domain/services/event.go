@@ -2,11 +2,14 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
 )
 
 type EventMinimal struct {
@@ -18,13 +21,16 @@ type EventMinimal struct {
 }
 
 type EventCreateInfo struct {
-	Title               string
-	Organizer           uuid.UUID
-	FoundingType        string
-	FoundingRangeLow    int
-	FoundingRangeHigh   int
-	CoFoundingRangeLow  int
-	CoFoundingRangeHigh int
+	Title        string
+	Organizer    uuid.UUID
+	FoundingType string
+	// FoundingRangeLow, FoundingRangeHigh - in the major units of FoundingCurrency
+	FoundingRangeLow  int64
+	FoundingRangeHigh int64
+	// FoundingCurrency - ISO 4217 code, the reference currency is used if it's empty
+	FoundingCurrency    string
+	CoFoundingRangeLow  int64
+	CoFoundingRangeHigh int64
 	SubmissionDeadline  time.Time
 	ConsiderationPeriod string
 	RealisationPeriod   string
@@ -37,13 +43,113 @@ type EventCreateInfo struct {
 	Subjects            []string
 }
 
+// Validate - checks the info against the limits of the storage, returns validators.Violations
+func (i EventCreateInfo) Validate() error {
+	return validators.Validate(
+		validators.String("title", i.Title, validators.Required(), validators.MaxLength(255)),
+		validators.RequiredID("organizer", i.Organizer),
+		validators.String("foundingType", i.FoundingType, validators.MaxLength(1024)),
+		validators.Range("foundingRange", models.RangeModel{Low: i.FoundingRangeLow, High: i.FoundingRangeHigh},
+			validators.ValidateRange, validators.ValidateAmountRange),
+		validators.String("foundingCurrency", i.FoundingCurrency, validators.Optional(validators.OneOf(models.Currencies()...))),
+		validators.Range("coFoundingRange", models.RangeModel{Low: i.CoFoundingRangeLow, High: i.CoFoundingRangeHigh},
+			validators.ValidatePercentRange),
+		validators.String("considerationPeriod", i.ConsiderationPeriod, validators.MaxLength(255)),
+		validators.String("realisationPeriod", i.RealisationPeriod, validators.MaxLength(255)),
+		validators.String("site", i.Site, validators.URL(), validators.MaxLength(1024)),
+		validators.String("document", i.Document, validators.URL(), validators.MaxLength(1024)),
+		validators.String("internalContacts", i.InternalContacts, validators.MaxLength(255)),
+		validators.Int("trl", i.TRL, validators.Between(1, 9)),
+		validators.Each("subjects", i.Subjects, validators.Required(), validators.MaxLength(255)),
+	)
+}
+
+// Orders of the events by the funding, they're given in ?sort=
+const (
+	EventSortFunding           = "funding"
+	EventSortFundingDescending = "-funding"
+)
+
+// ValidateEventSort - the sort should be empty or one of EventSort...
+func ValidateEventSort(sort string) error {
+	return validators.Validate(
+		validators.String("sort", sort, validators.Optional(validators.OneOf(EventSortFunding, EventSortFundingDescending))),
+	)
+}
+
+// Types of the operations of a batch
+const (
+	EventOperationCreate = "create"
+	EventOperationUpdate = "update"
+	EventOperationDelete = "delete"
+)
+
+// EventOperationTypes - all types of the operations of a batch
+var EventOperationTypes = []string{EventOperationCreate, EventOperationUpdate, EventOperationDelete}
+
+// MaxEventBatchSize - the maximum number of the operations of a batch
+const MaxEventBatchSize = 500
+
+// ErrBatchRolledBack - the result of the operations of an all-or-nothing batch, which are applied
+// before another operation has failed, and are rolled back with it
+var ErrBatchRolledBack = errors.New("rolled back, because another operation of the batch has failed")
+
+// ErrBatchSkipped - the result of the operations of an all-or-nothing batch after the failed one, they're not attempted
+var ErrBatchSkipped = errors.New("not attempted, because a previous operation of the batch has failed")
+
+// EventOperation - an operation of a batch, ID and Version are given for updates and deletions, Info for creations and updates
+type EventOperation struct {
+	Type    string
+	ID      uuid.UUID
+	Version int
+	Info    EventCreateInfo
+}
+
+// EventOperationResult - the result of an operation of a batch, Event is the created, updated or deleted event,
+// Err is nil if the operation is applied
+type EventOperationResult struct {
+	Event models.Event
+	Err   error
+}
+
+// ValidateEventOperations - checks the operations of a batch, but not the events' fields, which are checked by every operation,
+// the fields are named as operations[i].field, returns validators.Violations
+func ValidateEventOperations(operations []EventOperation) error {
+	checks := []validators.FieldCheck{
+		validators.Int("operations", len(operations), validators.Between(1, MaxEventBatchSize)),
+	}
+	for i, o := range operations {
+		field := fmt.Sprintf("operations[%d]", i)
+		checks = append(checks, validators.String(field+".op", o.Type, validators.OneOf(EventOperationTypes...)))
+		if o.Type == EventOperationUpdate || o.Type == EventOperationDelete {
+			checks = append(checks, validators.RequiredID(field+".id", o.ID))
+		}
+	}
+	return validators.Validate(checks...)
+}
+
 type EventService interface {
 	AllIDs(ctx context.Context) ([]uuid.UUID, error)
 	GetAll(ctx context.Context) ([]models.Event, error)
 	GetByID(ctx context.Context, id uuid.UUID) (models.Event, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Event, error)
+	// Find - returns the events satisfying the filter, the filter is checked by MatchEvent
+	Find(ctx context.Context, filter models.EventFilter) ([]models.Event, error)
+	// SortByFunding - orders the events by the upper bound of their founding ranges converted to the reference
+	// currency and then by the lower one, the events without a rate of their currency are the last
+	SortByFunding(ctx context.Context, events []models.Event, descending bool) ([]models.Event, error)
 	Create(ctx context.Context, info EventCreateInfo) (models.Event, error)
-	Delete(ctx context.Context, id uuid.UUID) error
-	Update(ctx context.Context, id uuid.UUID, info EventCreateInfo) (models.Event, error)
+	// Delete - deletes the event if its version matches, otherwise returns ErrVersionMismatch
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+	// Update - updates the event if its version matches, otherwise returns ErrVersionMismatch
+	Update(ctx context.Context, id uuid.UUID, version int, info EventCreateInfo) (models.Event, error)
+	// Batch - applies the operations and returns their results in the same order, the operations themselves are
+	// checked by ValidateEventOperations first. In the all-or-nothing mode they're applied in one transaction,
+	// the first failed operation rolls back all of them, otherwise every operation is applied on its own
+	Batch(ctx context.Context, operations []EventOperation, allOrNothing bool) ([]EventOperationResult, error)
+
+	// Search - full-text search over events, returns at most limit events ordered by relevance
+	Search(ctx context.Context, query string, limit int) ([]models.EventSearchHit, error)
 
 	GetAllAsMinimal(ctx context.Context) ([]EventMinimal, error)
 	GetByIDAsMinimal(ctx context.Context, id uuid.UUID) (EventMinimal, error)
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+)
+
+type FavouriteService interface {
+	// Add - stars the event for the user, the event should exist
+	Add(ctx context.Context, user, event uuid.UUID) error
+	Remove(ctx context.Context, user, event uuid.UUID) error
+	// GetAll - returns favourite events of the user, the latest starred first
+	GetAll(ctx context.Context, user uuid.UUID) ([]models.Event, error)
+	// AreFavourite - reports for every event if it's favourite for the user
+	AreFavourite(ctx context.Context, user uuid.UUID, events []uuid.UUID) (map[uuid.UUID]bool, error)
+}
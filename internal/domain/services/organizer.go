@@ -6,18 +6,44 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
 )
 
+// ValidateOrganizer - checks the organizer's fields, returns validators.Violations
+func ValidateOrganizer(name, logo string, level uuid.UUID) error {
+	return validators.Validate(
+		validators.String("name", name, validators.Required(), validators.MaxLength(255)),
+		validators.String("logo", logo, validators.MaxLength(255)),
+		validators.RequiredID("level", level),
+	)
+}
+
+// ValidateOrganizerLevel - checks the organizer level's fields, returns validators.Violations
+func ValidateOrganizerLevel(name, code string) error {
+	return validators.Validate(
+		validators.String("name", name, validators.Required(), validators.MaxLength(255)),
+		validators.String("code", code, validators.Required(), validators.MaxLength(3)),
+	)
+}
+
 type OrganizerService interface {
 	GetAllIDs(ctx context.Context) ([]uuid.UUID, error)
 	GetAll(ctx context.Context) ([]models.Organizer, error)
 	GetByID(ctx context.Context, id uuid.UUID) (models.Organizer, error)
+	// GetByIDs - returns the existing organizers of the ids
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Organizer, error)
 	Create(ctx context.Context, name, logo string, level uuid.UUID) (models.Organizer, error)
-	Delete(ctx context.Context, id uuid.UUID) error
-	Update(ctx context.Context, id uuid.UUID, name, logo string, level uuid.UUID) (models.Organizer, error)
+	// Delete - deletes the organizer if its version matches, otherwise returns ErrVersionMismatch
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+	// Update - updates the organizer if its version matches, otherwise returns ErrVersionMismatch
+	Update(ctx context.Context, id uuid.UUID, version int, name, logo string, level uuid.UUID) (models.Organizer, error)
+	// Suggest - returns existing organizers with names similar to the text
+	Suggest(ctx context.Context, text string, limit int) ([]models.Suggestion, error)
 
 	GetAllLevelsId(ctx context.Context) ([]uuid.UUID, error)
 	GetAllLevels(ctx context.Context) ([]models.OrganizerLevel, error)
+	// GetLevelsByIDs - returns the existing organizer levels of the ids
+	GetLevelsByIDs(ctx context.Context, ids []uuid.UUID) ([]models.OrganizerLevel, error)
 	CreateLevel(ctx context.Context, name string, code string) (models.OrganizerLevel, error)
 	UpdateLevel(ctx context.Context, level models.OrganizerLevel) (models.OrganizerLevel, error)
 }
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+)
+
+// OutboxService - relays the domain events from the outbox to the publishers
+type OutboxService interface {
+	// Relay - publishes the unpublished events to every publisher in the order they were added,
+	// a publisher stops at its first failed event, so the event and the following ones are published to it
+	// in the next run, while the other publishers go on. Returns the number of events published to all publishers
+	Relay(ctx context.Context, now time.Time) (int, error)
+}
+
+// NewDomainEvent - builds the domain event about the change of the object(Event, Organizer)
+func NewDomainEvent(changeType string, id uuid.UUID, object interface{}, at time.Time) (models.DomainEvent, error) {
+	payload, err := json.Marshal(object)
+	if err != nil {
+		return models.DomainEvent{}, err
+	}
+	return models.DomainEvent{ID: uuid.New(), Type: changeType, AggregateID: id, Payload: payload, OccurredAt: at}, nil
+}
+
+// DecodeChange - restores the change from the domain event, the object's type is chosen by the event's type
+func DecodeChange(event models.DomainEvent) (models.Change, error) {
+	change := models.Change{ID: event.ID, Type: event.Type, ObjectID: event.AggregateID, OccurredAt: event.OccurredAt}
+
+	switch event.Type {
+	case models.ChangeEventCreated, models.ChangeEventUpdated, models.ChangeEventDeleted:
+		var e models.Event
+		if err := json.Unmarshal(event.Payload, &e); err != nil {
+			return models.Change{}, err
+		}
+		change.Object = e
+	case models.ChangeOrganizerCreated, models.ChangeOrganizerUpdated, models.ChangeOrganizerDeleted:
+		var o models.Organizer
+		if err := json.Unmarshal(event.Payload, &o); err != nil {
+			return models.Change{}, err
+		}
+		change.Object = o
+	default:
+		return models.Change{}, fmt.Errorf("unknown type of the domain event: %s", event.Type)
+	}
+	return change, nil
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"math"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+)
+
+// Criteria of the recommendation's score
+const (
+	CriterionFunding    = "funding"
+	CriterionCoFunding  = "coFunding"
+	CriterionTRL        = "trl"
+	CriterionSubjects   = "subjects"
+	CriterionCompetitor = "competitor"
+)
+
+// ProfileInfo - fields of the profile given by the client
+type ProfileInfo struct {
+	Name              string
+	Applicant         models.ApplicantProfile
+	TRL               int
+	Subjects          []uuid.UUID
+	NeededFunding     int
+	CoFundingCapacity int
+}
+
+// Validate - checks the profile's fields, returns validators.Violations
+func (i ProfileInfo) Validate() error {
+	checks := []validators.FieldCheck{
+		validators.String("name", i.Name, validators.Required(), validators.MaxLength(255)),
+		validators.Int("trl", i.TRL, validators.Between(1, 9)),
+		validators.Int("neededFunding", i.NeededFunding, validators.Between(0, math.MaxInt32)),
+		validators.Int("coFundingCapacity", i.CoFundingCapacity, validators.Between(0, 100)),
+	}
+	if err := ValidateApplicantProfile(i.Applicant); err != nil {
+		checks = append(checks, prefixed("applicant", err.(validators.Violations)))
+	}
+	return validators.Validate(checks...)
+}
+
+// prefixed - reports violations of a nested object under its field
+func prefixed(field string, violations validators.Violations) validators.FieldCheck {
+	return func() validators.Violations {
+		result := make(validators.Violations, len(violations))
+		for i, v := range violations {
+			result[i] = validators.Violation{Field: field + "." + v.Field, Message: v.Message}
+		}
+		return result
+	}
+}
+
+type ProfileService interface {
+	GetAll(ctx context.Context) ([]models.Profile, error)
+	GetByID(ctx context.Context, id uuid.UUID) (models.Profile, error)
+	// Create - stores a profile, subjects of interest should exist in the catalogue
+	Create(ctx context.Context, info ProfileInfo) (models.Profile, error)
+	Update(ctx context.Context, id uuid.UUID, info ProfileInfo) (models.Profile, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type RecommendationService interface {
+	// Recommend - returns at most limit events the profile's applicant is eligible for,
+	// ordered by the score of fit, every score is explained by its components
+	Recommend(ctx context.Context, profile models.Profile, limit int) ([]models.Recommendation, error)
+}
@@ -8,10 +8,11 @@ import (
 	"github.com/indigowar/map-of-events/internal/domain/models"
 )
 
+// RangeService - reads the ranges of the events, the ranges are changed only by EventService
 type RangeService interface {
-	GetByID(ctx context.Context, id uuid.UUID) (models.RangeModel, error)
+	// GetByEvent - the range of the event with the id
+	GetByEvent(ctx context.Context, event uuid.UUID) (models.RangeModel, error)
+	// GetMaximumRange - the lowest and the highest bounds of all ranges, the founding ranges
+	// are converted to the reference currency
 	GetMaximumRange(ctx context.Context) (models.RangeModel, error)
-	Create(ctx context.Context, low, high int) (models.RangeModel, error)
-	Delete(ctx context.Context, id uuid.UUID) error
-	Update(ctx context.Context, foundingRange models.RangeModel) (models.RangeModel, error)
 }
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+)
+
+// Languages - languages the reminders can be written in
+var Languages = []string{models.LanguageRussian, models.LanguageEnglish}
+
+// maxReminderDaysBefore - the earliest reminder is sent 90 days before the deadline
+const maxReminderDaysBefore = 90
+
+// ValidateNotificationPreferences - checks the preferences given by the user, returns validators.Violations
+func ValidateNotificationPreferences(p models.NotificationPreferences) error {
+	checks := []validators.FieldCheck{
+		validators.String("email", p.Email, validators.Email(), validators.MaxLength(255)),
+		validators.String("language", p.Language, validators.OneOf(Languages...)),
+		validators.EachInt("daysBefore", p.DaysBefore, validators.Between(1, maxReminderDaysBefore)),
+	}
+	if p.Enabled {
+		checks = append(checks, validators.String("email", p.Email, validators.Required()))
+	}
+	return validators.Validate(checks...)
+}
+
+type ReminderService interface {
+	// GetPreferences - returns saved preferences of the user or the default ones
+	GetPreferences(ctx context.Context, user uuid.UUID) (models.NotificationPreferences, error)
+	SavePreferences(ctx context.Context, preferences models.NotificationPreferences) error
+
+	// SendDue - sends the reminders about deadlines of favourite events, that are due at the moment,
+	// every reminder is sent once, returns the number of the sent reminders
+	SendDue(ctx context.Context, now time.Time) (int, error)
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+)
+
+// AlertDeliveries - channels the saved search alerts can be delivered by
+var AlertDeliveries = []string{models.AlertDeliveryEmail, models.AlertDeliveryInApp}
+
+// AlertFrequencies - how often the saved search alerts can be delivered
+var AlertFrequencies = []string{models.AlertFrequencyImmediate, models.AlertFrequencyDaily, models.AlertFrequencyWeekly}
+
+// AlertPeriod - the minimal time between two deliveries of the alerts with the frequency
+func AlertPeriod(frequency string) time.Duration {
+	switch frequency {
+	case models.AlertFrequencyDaily:
+		return 24 * time.Hour
+	case models.AlertFrequencyWeekly:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// ValidateEventFilter - checks the filter's criteria, returns validators.Violations
+func ValidateEventFilter(f models.EventFilter) error {
+	checks := []validators.FieldCheck{
+		validators.Int("minTrl", f.MinTRL, validators.Between(0, 9)),
+		validators.Int("maxTrl", f.MaxTRL, validators.Between(0, 9)),
+		validators.Int("minFunding", f.MinFunding, validators.Between(0, validators.MaxMajorAmount)),
+		validators.Int("maxFunding", f.MaxFunding, validators.Between(0, validators.MaxMajorAmount)),
+	}
+	if f.MaxTRL != 0 && f.MinTRL > f.MaxTRL {
+		checks = append(checks, validators.Int("maxTrl", f.MaxTRL, validators.Between(f.MinTRL, 9)))
+	}
+	if f.MaxFunding != 0 && f.MinFunding > f.MaxFunding {
+		checks = append(checks, validators.Int("maxFunding", f.MaxFunding, validators.Between(f.MinFunding, validators.MaxMajorAmount)))
+	}
+	return validators.Validate(checks...)
+}
+
+// EventFacts - the event with its related objects, that are checked by the filter
+type EventFacts struct {
+	Event    models.Event
+	Subjects []models.Subject
+	// ReferenceFunding - the founding range of the event converted to the reference currency, it's checked
+	// by the filter, Currency is empty if there is no rate of the range's currency
+	ReferenceFunding models.RangeModel
+}
+
+// IsEmptyFilter - reports if the filter has no criteria, so all events satisfy it
+func IsEmptyFilter(f models.EventFilter) bool {
+	return len(f.Organizers) == 0 && len(f.Competitors) == 0 && len(f.Subjects) == 0 &&
+		f.MinTRL == 0 && f.MaxTRL == 0 && f.MinFunding == 0 && f.MaxFunding == 0
+}
+
+// MatchEvent - reports if the event satisfies all criteria of the filter,
+// parents are parents of the catalogue's subjects, they're used to match descendants of the filter's subjects
+func MatchEvent(f models.EventFilter, facts EventFacts, parents map[uuid.UUID]uuid.UUID) bool {
+	e := facts.Event
+
+	if len(f.Organizers) != 0 && !validators.IDExists(f.Organizers, e.Organizer) {
+		return false
+	}
+	if f.MinTRL != 0 && e.TRL < f.MinTRL {
+		return false
+	}
+	if f.MaxTRL != 0 && e.TRL > f.MaxTRL {
+		return false
+	}
+	if f.MinFunding != 0 || f.MaxFunding != 0 {
+		// the range can not be compared without the rate of its currency
+		if facts.ReferenceFunding.Currency == "" {
+			return false
+		}
+		low, high := facts.ReferenceFunding.LowMoney().Major(), facts.ReferenceFunding.HighMoney().Major()
+		if f.MinFunding != 0 && high < int64(f.MinFunding) {
+			return false
+		}
+		if f.MaxFunding != 0 && low > int64(f.MaxFunding) {
+			return false
+		}
+	}
+
+	if len(f.Competitors) != 0 {
+		found := false
+		for _, c := range e.Competitors {
+			if validators.IDExists(f.Competitors, c) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(f.Subjects) != 0 {
+		found := false
+		for _, s := range facts.Subjects {
+			for current := s.ID; current != uuid.Nil && !found; current = parents[current] {
+				found = validators.IDExists(f.Subjects, current)
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// SavedSearchInfo - fields of the saved search given by the client
+type SavedSearchInfo struct {
+	Name      string
+	Filter    models.EventFilter
+	Delivery  string
+	Frequency string
+}
+
+// Validate - checks the saved search's fields, returns validators.Violations
+func (i SavedSearchInfo) Validate() error {
+	checks := []validators.FieldCheck{
+		validators.String("name", i.Name, validators.Required(), validators.MaxLength(255)),
+		validators.String("delivery", i.Delivery, validators.OneOf(AlertDeliveries...)),
+		validators.String("frequency", i.Frequency, validators.OneOf(AlertFrequencies...)),
+	}
+	if err := ValidateEventFilter(i.Filter); err != nil {
+		checks = append(checks, prefixed("filter", err.(validators.Violations)))
+	}
+	return validators.Validate(checks...)
+}
+
+type SavedSearchService interface {
+	// ChangeListener - created and updated events are evaluated against all saved searches
+	ChangeListener
+
+	GetAll(ctx context.Context, user uuid.UUID) ([]models.SavedSearch, error)
+	// GetByID - returns adapters.ErrNotFound if the search does not exist or belongs to another user
+	GetByID(ctx context.Context, user, id uuid.UUID) (models.SavedSearch, error)
+	// Create - saves the search, subjects of the filter should exist in the catalogue
+	Create(ctx context.Context, user uuid.UUID, info SavedSearchInfo) (models.SavedSearch, error)
+	Update(ctx context.Context, user, id uuid.UUID, info SavedSearchInfo) (models.SavedSearch, error)
+	Delete(ctx context.Context, user, id uuid.UUID) error
+
+	// DeliverDue - delivers pending alerts of the searches, which frequency period has passed,
+	// returns the number of the delivered alerts
+	DeliverDue(ctx context.Context, now time.Time) (int, error)
+
+	// GetAlerts - returns delivered in-app alerts of the user, the latest first
+	GetAlerts(ctx context.Context, user uuid.UUID, unreadOnly bool) ([]models.SearchAlert, error)
+	// MarkAlertsRead - marks the user's alerts as read, all of them if ids is empty
+	MarkAlertsRead(ctx context.Context, user uuid.UUID, ids []uuid.UUID) error
+}
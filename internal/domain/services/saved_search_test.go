@@ -0,0 +1,68 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+)
+
+func TestMatchEvent(t *testing.T) {
+	organizer, competitor := uuid.New(), uuid.New()
+	science, algebra, biology := uuid.New(), uuid.New(), uuid.New()
+	parents := map[uuid.UUID]uuid.UUID{algebra: science}
+
+	facts := EventFacts{
+		Event:    models.Event{Organizer: organizer, TRL: 4, Competitors: []uuid.UUID{competitor}},
+		Subjects: []models.Subject{{ID: algebra, Parent: science}},
+		// 1 000 - 5 000 RUB
+		ReferenceFunding: models.RangeModel{Low: 100000, High: 500000, Currency: "RUB"},
+	}
+
+	tests := []struct {
+		name   string
+		filter models.EventFilter
+		facts  EventFacts
+		want   bool
+	}{
+		{name: "empty filter", want: true},
+		{name: "organizer", filter: models.EventFilter{Organizers: []uuid.UUID{uuid.New(), organizer}}, want: true},
+		{name: "another organizer", filter: models.EventFilter{Organizers: []uuid.UUID{uuid.New()}}},
+		{name: "TRL within the bounds", filter: models.EventFilter{MinTRL: 4, MaxTRL: 4}, want: true},
+		{name: "TRL below the minimum", filter: models.EventFilter{MinTRL: 5}},
+		{name: "TRL above the maximum", filter: models.EventFilter{MaxTRL: 3}},
+		{name: "funding intersects", filter: models.EventFilter{MinFunding: 4000, MaxFunding: 10000}, want: true},
+		{name: "funding above the range", filter: models.EventFilter{MinFunding: 5001}},
+		{name: "funding below the range", filter: models.EventFilter{MaxFunding: 999}},
+		{
+			name:   "funding without a rate",
+			filter: models.EventFilter{MinFunding: 1},
+			facts:  EventFacts{Event: facts.Event, Subjects: facts.Subjects},
+		},
+		{name: "competitor", filter: models.EventFilter{Competitors: []uuid.UUID{competitor}}, want: true},
+		{name: "another competitor", filter: models.EventFilter{Competitors: []uuid.UUID{uuid.New()}}},
+		{name: "subject", filter: models.EventFilter{Subjects: []uuid.UUID{algebra}}, want: true},
+		{name: "parent subject", filter: models.EventFilter{Subjects: []uuid.UUID{science}}, want: true},
+		{name: "another subject", filter: models.EventFilter{Subjects: []uuid.UUID{biology}}},
+		{
+			name:   "all criteria",
+			filter: models.EventFilter{Organizers: []uuid.UUID{organizer}, Subjects: []uuid.UUID{science}, MinTRL: 3, MinFunding: 5000},
+			want:   true,
+		},
+		{
+			name:   "one of the criteria fails",
+			filter: models.EventFilter{Organizers: []uuid.UUID{organizer}, Subjects: []uuid.UUID{biology}, MinTRL: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		f := facts
+		if tt.facts.Event.Organizer != uuid.Nil {
+			f = tt.facts
+		}
+		if got := MatchEvent(tt.filter, f, parents); got != tt.want {
+			t.Errorf("%s: MatchEvent = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
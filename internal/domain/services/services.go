@@ -8,4 +8,17 @@ type Services struct {
 	Competitor      CompetitorService
 	Subject         SubjectService
 	Image           ImageService
+	Eligibility     EligibilityService
+	Profile         ProfileService
+	Recommendation  RecommendationService
+	Application     ApplicationService
+	Auth            AuthService
+	Favourite       FavouriteService
+	Reminder        ReminderService
+	SavedSearch     SavedSearchService
+	Webhook         WebhookService
+	Outbox          OutboxService
+	ChangeStream    ChangeStreamService
+	Analytics       AnalyticsService
+	Currency        CurrencyService
 }
@@ -2,17 +2,62 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 
 	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
 )
 
+// ErrSubjectHasChildren - returned on Delete of a subject, that is a parent of other subjects
+var ErrSubjectHasChildren = errors.New("subject has child subjects")
+
+// SubjectCodeSchemes - classifications, which codes can be assigned to the subjects
+var SubjectCodeSchemes = []string{models.SubjectCodeSchemeGRNTI, models.SubjectCodeSchemeOECDFOS}
+
+// SubjectInfo - fields of the subject given by the client
+type SubjectInfo struct {
+	Name     string
+	Parent   uuid.UUID
+	Synonyms []string
+	Codes    []models.SubjectCode
+}
+
+// Validate - checks the info against the limits of the storage, returns validators.Violations
+func (i SubjectInfo) Validate() error {
+	checks := []validators.FieldCheck{
+		validators.String("name", i.Name, validators.Required(), validators.MaxLength(255)),
+		validators.Each("synonyms", i.Synonyms, validators.Required(), validators.MaxLength(255)),
+	}
+	for index, code := range i.Codes {
+		checks = append(checks,
+			validators.String(fmt.Sprintf("codes[%d].scheme", index), code.Scheme, validators.OneOf(SubjectCodeSchemes...)),
+			validators.String(fmt.Sprintf("codes[%d].code", index), code.Code, validators.Required(), validators.MaxLength(32)),
+		)
+	}
+	return validators.Validate(checks...)
+}
+
 type SubjectService interface {
 	GetAllExisting(ctx context.Context) ([]models.Subject, error)
 	GetAllForEvent(ctx context.Context, eventId uuid.UUID) ([]models.Subject, error)
+	GetAllForEvents(ctx context.Context, eventIds []uuid.UUID) (map[uuid.UUID][]models.Subject, error)
 	GetByID(ctx context.Context, id uuid.UUID) (models.Subject, error)
-	Create(ctx context.Context, eventId uuid.UUID, subject string) (models.Subject, error)
+	// GetByIDs - returns the existing subjects of the ids
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Subject, error)
+	// Create - adds a subject to the catalogue, the parent should exist
+	Create(ctx context.Context, info SubjectInfo) (models.Subject, error)
+	// Delete - deletes a subject from the catalogue and from all events, returns ErrSubjectHasChildren
+	// if other subjects are placed under it
 	Delete(ctx context.Context, id uuid.UUID) error
-	Update(ctx context.Context, subject models.Subject) error
+	// Update - updates a subject, the subject can not be moved under itself or its descendants
+	Update(ctx context.Context, id uuid.UUID, info SubjectInfo) (models.Subject, error)
+	// Resolve - finds subjects by their names or synonyms, missing subjects are added to the catalogue as root ones
+	Resolve(ctx context.Context, names []string) ([]models.Subject, error)
+	// SetForEvent - replaces subjects of the event with given ones
+	SetForEvent(ctx context.Context, eventId uuid.UUID, subjects []uuid.UUID) error
+	// Suggest - returns existing subjects with names similar to the text
+	Suggest(ctx context.Context, text string, limit int) ([]models.Suggestion, error)
 }
@@ -0,0 +1,14 @@
+package services
+
+import "errors"
+
+// AnyVersion - can be passed to Update/Delete instead of a version to skip the check of it
+const AnyVersion = -1
+
+// ErrVersionMismatch - returned on Update/Delete of an object, that was changed since the client read it
+var ErrVersionMismatch = errors.New("version of the object does not match")
+
+// VersionMatches - reports if the stored version satisfies the expected one
+func VersionMatches(expected, stored int) bool {
+	return expected == AnyVersion || expected == stored
+}
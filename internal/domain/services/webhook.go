@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+)
+
+// ErrDeliveryIsNotDead - returned on Retry of a delivery, that is not in the dead letters
+var ErrDeliveryIsNotDead = errors.New("the delivery is not dead")
+
+// WebhookInfo - fields of the webhook given by the client
+type WebhookInfo struct {
+	URL string
+	// Secret - a key of HMAC signature, it's generated if it's empty
+	Secret string
+	Types  []string
+	Active bool
+}
+
+// Validate - checks the webhook's fields, returns validators.Violations
+func (i WebhookInfo) Validate() error {
+	checks := []validators.FieldCheck{
+		validators.String("url", i.URL, validators.Required(), validators.URL(), validators.MaxLength(1024)),
+		validators.String("secret", i.Secret, validators.MaxLength(255)),
+		validators.Each("types", i.Types, validators.OneOf(ChangeTypes...)),
+	}
+	if len(i.Types) == 0 {
+		checks = append(checks, validators.String("types", "", validators.Required()))
+	}
+	return validators.Validate(checks...)
+}
+
+type WebhookService interface {
+	// ChangeListener - a delivery is queued for every active webhook subscribed to the change
+	ChangeListener
+
+	GetAll(ctx context.Context) ([]models.Webhook, error)
+	// GetByID - returns adapters.ErrNotFound if the webhook does not exist
+	GetByID(ctx context.Context, id uuid.UUID) (models.Webhook, error)
+	Create(ctx context.Context, info WebhookInfo) (models.Webhook, error)
+	// Update - changes the webhook, the secret is kept if it's empty in the info
+	Update(ctx context.Context, id uuid.UUID, info WebhookInfo) (models.Webhook, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// GetDeliveries - returns the latest deliveries of the webhook with their attempts,
+	// all statuses if status is empty
+	GetDeliveries(ctx context.Context, webhook uuid.UUID, status string, limit int) ([]models.WebhookDelivery, error)
+	// GetDeadLetters - returns the latest deliveries, that have failed all attempts
+	GetDeadLetters(ctx context.Context, limit int) ([]models.WebhookDelivery, error)
+	// Retry - queues the dead delivery again, returns ErrDeliveryIsNotDead for other deliveries
+	Retry(ctx context.Context, delivery uuid.UUID) error
+
+	// DeliverDue - sends the due deliveries, returns the number of the sent ones
+	DeliverDue(ctx context.Context, now time.Time) (int, error)
+}
@@ -0,0 +1,221 @@
+package validators
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+)
+
+/*
+
+This file contains a declarative validation of fields.
+
+Every field is described with its name, value and a list of rules,
+Validate checks all of them and returns all found violations at once:
+
+	err := validators.Validate(
+		validators.String("title", title, validators.Required(), validators.MaxLength(255)),
+		validators.Int("trl", trl, validators.Between(1, 9)),
+	)
+
+*/
+
+// Violation - a single failed rule of a field
+type Violation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Violations - all failed rules, it's returned as an error from Validate
+type Violations []Violation
+
+func (v Violations) Error() string {
+	messages := make([]string, len(v))
+	for i, violation := range v {
+		messages[i] = violation.Field + ": " + violation.Message
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// FieldCheck - a field with its rules, returns violations of the field
+type FieldCheck func() Violations
+
+// StringRule - checks a string value, returns an empty message if value is valid
+type StringRule func(value string) string
+
+// IntRule - checks an int value, returns an empty message if value is valid
+type IntRule func(value int) string
+
+// Validate - runs all checks and returns Violations if any of them failed, otherwise nil
+func Validate(checks ...FieldCheck) error {
+	result := make(Violations, 0)
+	for _, check := range checks {
+		result = append(result, check()...)
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func String(field string, value string, rules ...StringRule) FieldCheck {
+	return func() Violations {
+		result := make(Violations, 0)
+		for _, rule := range rules {
+			if msg := rule(value); msg != "" {
+				result = append(result, Violation{Field: field, Message: msg})
+			}
+		}
+		return result
+	}
+}
+
+func Int(field string, value int, rules ...IntRule) FieldCheck {
+	return func() Violations {
+		result := make(Violations, 0)
+		for _, rule := range rules {
+			if msg := rule(value); msg != "" {
+				result = append(result, Violation{Field: field, Message: msg})
+			}
+		}
+		return result
+	}
+}
+
+// RequiredID - id should not be uuid.Nil
+func RequiredID(field string, value uuid.UUID) FieldCheck {
+	return func() Violations {
+		if value == uuid.Nil {
+			return Violations{{Field: field, Message: "is required"}}
+		}
+		return nil
+	}
+}
+
+// Each - checks every string of the list with given rules, fields are named as field[i]
+func Each(field string, values []string, rules ...StringRule) FieldCheck {
+	return func() Violations {
+		result := make(Violations, 0)
+		for i, v := range values {
+			result = append(result, String(fmt.Sprintf("%s[%d]", field, i), v, rules...)()...)
+		}
+		return result
+	}
+}
+
+// EachInt - checks every int of the list with given rules, fields are named as field[i]
+func EachInt(field string, values []int, rules ...IntRule) FieldCheck {
+	return func() Violations {
+		result := make(Violations, 0)
+		for i, v := range values {
+			result = append(result, Int(fmt.Sprintf("%s[%d]", field, i), v, rules...)()...)
+		}
+		return result
+	}
+}
+
+// Required - string should not be empty or contain only spaces
+func Required() StringRule {
+	return func(value string) string {
+		if strings.TrimSpace(value) == "" {
+			return "is required"
+		}
+		return ""
+	}
+}
+
+// MaxLength - string should not be longer than n characters
+func MaxLength(n int) StringRule {
+	return func(value string) string {
+		if utf8.RuneCountInString(value) > n {
+			return fmt.Sprintf("should be at most %d characters long", n)
+		}
+		return ""
+	}
+}
+
+// MinLength - string should not be shorter than n characters
+func MinLength(n int) StringRule {
+	return func(value string) string {
+		if utf8.RuneCountInString(value) < n {
+			return fmt.Sprintf("should be at least %d characters long", n)
+		}
+		return ""
+	}
+}
+
+// URL - string should be an absolute http(s) url, the empty string is accepted
+func URL() StringRule {
+	return func(value string) string {
+		if value == "" {
+			return ""
+		}
+		u, err := url.ParseRequestURI(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "should be a valid http(s) url"
+		}
+		return ""
+	}
+}
+
+// Email - string should be a bare email address, the empty string is accepted
+func Email() StringRule {
+	return func(value string) string {
+		if value == "" {
+			return ""
+		}
+		address, err := mail.ParseAddress(value)
+		if err != nil || address.Address != value {
+			return "should be a valid email address"
+		}
+		return ""
+	}
+}
+
+// OneOf - string should be equal to one of the values
+func OneOf(values ...string) StringRule {
+	return func(value string) string {
+		if StringExists(values, value) {
+			return ""
+		}
+		return "should be one of: " + strings.Join(values, ", ")
+	}
+}
+
+// Optional - checks the string with the rule only if it's not empty
+func Optional(rule StringRule) StringRule {
+	return func(value string) string {
+		if value == "" {
+			return ""
+		}
+		return rule(value)
+	}
+}
+
+// Between - value should be in [min, max]
+func Between(min, max int) IntRule {
+	return func(value int) string {
+		if value < min || value > max {
+			return fmt.Sprintf("should be between %d and %d", min, max)
+		}
+		return ""
+	}
+}
+
+// Range - checks a range with given validators(ValidateRange, ValidatePercentRange)
+func Range(field string, r models.RangeModel, validators ...func(models.RangeModel) error) FieldCheck {
+	return func() Violations {
+		for _, validator := range validators {
+			if err := validator(r); err != nil {
+				return Violations{{Field: field, Message: err.Error()}}
+			}
+		}
+		return nil
+	}
+}
@@ -10,8 +10,12 @@ var (
 	ErrLowBiggerThenHighValue      = errors.New("range's low value is bigger then high value")
 	ErrRangeHasNegativeValues      = errors.New("range has negative value(s)")
 	ErrRangeHasUnacceptablePercent = errors.New("range has a value of unaccepted percent")
+	ErrRangeIsTooBig               = errors.New("range has a value bigger than 10^15")
 )
 
+// MaxMajorAmount - the limit of an amount in the major units, so it can be stored in the minor units as int64
+const MaxMajorAmount = 1_000_000_000_000_000
+
 func ValidateRange(r models.RangeModel) error {
 	high := r.High
 	low := r.Low
@@ -41,3 +45,11 @@ func ValidatePercentRange(r models.RangeModel) error {
 
 	return nil
 }
+
+// ValidateAmountRange - the bounds are amounts in the major units of a currency
+func ValidateAmountRange(r models.RangeModel) error {
+	if r.Low > MaxMajorAmount || r.High > MaxMajorAmount {
+		return ErrRangeIsTooBig
+	}
+	return nil
+}
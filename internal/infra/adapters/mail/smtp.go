@@ -0,0 +1,104 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/indigowar/map-of-events/internal/config"
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+)
+
+type smtpSender struct {
+	cfg config.SMTPConfig
+}
+
+func (s smtpSender) Send(ctx context.Context, mail adapters.Mail) error {
+	if err := s.send(ctx, mail); err != nil {
+		log.Println(err)
+		return errors.New("failed to send the mail")
+	}
+	return nil
+}
+
+func (s smtpSender) send(ctx context.Context, mail adapters.Mail) error {
+	addr := net.JoinHostPort(s.cfg.Host, s.cfg.Port)
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+			return err
+		}
+	}
+
+	// a local sink usually accepts the mail without authentication
+	if s.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(s.cfg.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(mail.To); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(message(s.cfg.From, mail)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// message - builds an RFC 5322 message, the subject is encoded because it's not ascii in russian
+func message(from string, mail adapters.Mail) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", mail.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", mail.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.Write(bytes.ReplaceAll([]byte(mail.Body), []byte("\n"), []byte("\r\n")))
+	return b.Bytes()
+}
+
+// NewSMTPSender - creates a sender delivering the mails through the configured SMTP server
+func NewSMTPSender(cfg config.SMTPConfig) adapters.MailSender {
+	return &smtpSender{
+		cfg: cfg,
+	}
+}
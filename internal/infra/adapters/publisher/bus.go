@@ -0,0 +1,46 @@
+package publisher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+// Bus - an in-process publisher, it tells the subscribed listeners about the changes from the domain events
+type Bus struct {
+	mu        sync.RWMutex
+	listeners []services.ChangeListener
+}
+
+// Subscribe - adds the listener, it's told about the events published after the subscription
+func (b *Bus) Subscribe(listener services.ChangeListener) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.listeners = append(b.listeners, listener)
+}
+
+func (b *Bus) Name() string {
+	return "bus"
+}
+
+func (b *Bus) Publish(ctx context.Context, event models.DomainEvent) error {
+	change, err := services.DecodeChange(event)
+	if err != nil {
+		return err
+	}
+
+	b.mu.RLock()
+	listeners := b.listeners
+	b.mu.RUnlock()
+
+	for _, l := range listeners {
+		l.Changed(ctx, change)
+	}
+	return nil
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
@@ -0,0 +1,29 @@
+package publisher
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+)
+
+// envelope - the domain event as it's sent to the external systems
+type envelope struct {
+	ID          uuid.UUID       `json:"id"`
+	Type        string          `json:"type"`
+	AggregateID uuid.UUID       `json:"aggregateId"`
+	OccurredAt  time.Time       `json:"occurredAt"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+func marshalEnvelope(event models.DomainEvent) ([]byte, error) {
+	return json.Marshal(envelope{
+		ID:          event.ID,
+		Type:        event.Type,
+		AggregateID: event.AggregateID,
+		OccurredAt:  event.OccurredAt,
+		Payload:     event.Payload,
+	})
+}
@@ -0,0 +1,46 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+)
+
+type httpPublisher struct {
+	sender adapters.WebhookSender
+	url    string
+}
+
+func (p httpPublisher) Name() string {
+	return "http"
+}
+
+// Publish - posts the envelope of the event, any status except 2xx is a failure
+func (p httpPublisher) Publish(ctx context.Context, event models.DomainEvent) error {
+	body, err := marshalEnvelope(event)
+	if err != nil {
+		return err
+	}
+
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	header.Set("X-Event-Id", event.ID.String())
+	header.Set("X-Event-Type", event.Type)
+
+	status, err := p.sender.Post(ctx, p.url, header, body)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("the receiver has responded with %d", status)
+	}
+	return nil
+}
+
+// NewHTTPPublisher - creates a publisher posting the events to the url
+func NewHTTPPublisher(sender adapters.WebhookSender, url string) adapters.DomainEventPublisher {
+	return &httpPublisher{sender: sender, url: url}
+}
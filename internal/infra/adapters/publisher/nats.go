@@ -0,0 +1,170 @@
+package publisher
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+)
+
+// natsPublisher - publishes the envelopes of the events to a NATS-compatible server using the text protocol,
+// the subject of an event is "<prefix>.<type>", e.g. "map-of-events.event.created".
+// Every publication is followed by PING, so the event is published only when the server has answered PONG
+type natsPublisher struct {
+	address string
+	user    *url.Userinfo
+	prefix  string
+	timeout time.Duration
+	mu      sync.Mutex
+	conn    net.Conn
+	reader  *bufio.Reader
+}
+
+// connectOptions - options of CONNECT command of the protocol
+type connectOptions struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+}
+
+func (p *natsPublisher) Name() string {
+	return "nats"
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, event models.DomainEvent) error {
+	body, err := marshalEnvelope(event)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.publish(ctx, p.prefix+"."+event.Type, body); err != nil {
+		// the connection is in unknown state, so the next publication makes a new one
+		p.close()
+		return err
+	}
+	return nil
+}
+
+func (p *natsPublisher) publish(ctx context.Context, subject string, body []byte) error {
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			return err
+		}
+	}
+
+	deadline := time.Now().Add(p.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := p.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	command := fmt.Sprintf("PUB %s %d\r\n%s\r\nPING\r\n", subject, len(body), body)
+	if _, err := p.conn.Write([]byte(command)); err != nil {
+		return err
+	}
+
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := p.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New("the server has failed the publication: " + line)
+		}
+		// INFO updates and +OK are not needed
+	}
+}
+
+func (p *natsPublisher) connect(ctx context.Context) error {
+	dialer := net.Dialer{Timeout: p.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.address)
+	if err != nil {
+		return err
+	}
+	p.conn = conn
+	p.reader = bufio.NewReader(conn)
+
+	if err := conn.SetDeadline(time.Now().Add(p.timeout)); err != nil {
+		return err
+	}
+
+	info, err := p.readLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(info, "INFO") {
+		return errors.New("unexpected greeting of the server: " + info)
+	}
+
+	options := connectOptions{Name: "map-of-events"}
+	if p.user != nil {
+		options.User = p.user.Username()
+		options.Pass, _ = p.user.Password()
+	}
+	encoded, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Write([]byte("CONNECT " + string(encoded) + "\r\n"))
+	return err
+}
+
+func (p *natsPublisher) readLine() (string, error) {
+	line, err := p.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (p *natsPublisher) close() {
+	if p.conn != nil {
+		_ = p.conn.Close()
+	}
+	p.conn = nil
+	p.reader = nil
+}
+
+// NewNATSPublisher - creates a publisher to the server by the url(nats://[user:password@]host:port),
+// the connection is made on the first publication
+func NewNATSPublisher(serverURL, prefix string, timeout time.Duration) (adapters.DomainEventPublisher, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		return nil, errors.New("the url of NATS server has no host: " + serverURL)
+	}
+
+	return &natsPublisher{
+		address: u.Host,
+		user:    u.User,
+		prefix:  prefix,
+		timeout: timeout,
+	}, nil
+}
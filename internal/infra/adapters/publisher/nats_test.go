@@ -0,0 +1,207 @@
+package publisher
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+)
+
+// command - a command received by the fake server, body is the payload of PUB
+type command struct {
+	line string
+	body string
+}
+
+// fakeNATSServer - accepts connections, greets them with INFO and answers every PING of the client
+// with the reply of the connection
+type fakeNATSServer struct {
+	listener net.Listener
+	// replies - replies of the connections in the order they're accepted, the last one is used for the following ones
+	replies  []string
+	commands chan command
+}
+
+func newFakeNATSServer(t *testing.T, replies ...string) *fakeNATSServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	s := &fakeNATSServer{
+		listener: listener,
+		replies:  replies,
+		commands: make(chan command, 100),
+	}
+	go s.serve()
+	t.Cleanup(func() { _ = listener.Close() })
+	return s
+}
+
+func (s *fakeNATSServer) serve() {
+	for i := 0; ; i++ {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		reply := s.replies[len(s.replies)-1]
+		if i < len(s.replies) {
+			reply = s.replies[i]
+		}
+		go s.handle(conn, reply)
+	}
+}
+
+func (s *fakeNATSServer) handle(conn net.Conn, reply string) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	if _, err := conn.Write([]byte(`INFO {"server_id":"fake","max_payload":1048576}` + "\r\n")); err != nil {
+		return
+	}
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		c := command{line: strings.TrimRight(line, "\r\n")}
+
+		if strings.HasPrefix(c.line, "PUB ") {
+			fields := strings.Fields(c.line)
+			size, _ := strconv.Atoi(fields[2])
+			body := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, body); err != nil {
+				return
+			}
+			c.body = string(body[:size])
+		}
+		s.commands <- c
+
+		if c.line == "PING" {
+			if _, err := conn.Write([]byte(reply)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *fakeNATSServer) url() string {
+	return "nats://user:secret@" + s.listener.Addr().String()
+}
+
+// next - returns the next command received by the server
+func (s *fakeNATSServer) next(t *testing.T) command {
+	t.Helper()
+	select {
+	case c := <-s.commands:
+		return c
+	case <-time.After(time.Second):
+		t.Fatalf("the server has received no command")
+		return command{}
+	}
+}
+
+// expect - checks that the next commands of the server start with the prefixes
+func (s *fakeNATSServer) expect(t *testing.T, prefixes ...string) []command {
+	t.Helper()
+	result := make([]command, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		c := s.next(t)
+		if !strings.HasPrefix(c.line, prefix) {
+			t.Fatalf("the server has received %q, want %s", c.line, prefix)
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+func TestNATSPublisher(t *testing.T) {
+	// the server pings the client before it answers the client's PING
+	server := newFakeNATSServer(t, "PING\r\nPONG\r\n")
+
+	p, err := NewNATSPublisher(server.url(), "map-of-events", time.Second)
+	if err != nil {
+		t.Fatalf("NewNATSPublisher: %v", err)
+	}
+
+	event := models.DomainEvent{ID: uuid.New(), Type: models.ChangeEventCreated, AggregateID: uuid.New(), Payload: []byte(`{}`)}
+	for i := 0; i < 2; i++ {
+		if err := p.Publish(context.Background(), event); err != nil {
+			t.Fatalf("Publish #%d: %v", i, err)
+		}
+	}
+
+	// both publications use one connection, the client answers PONG to the server's PING
+	commands := server.expect(t, "CONNECT ", "PUB ", "PING", "PONG", "PUB ", "PING", "PONG")
+
+	var options connectOptions
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(commands[0].line, "CONNECT ")), &options); err != nil {
+		t.Fatalf("CONNECT has invalid options: %v", err)
+	}
+	if options.User != "user" || options.Pass != "secret" || options.Verbose {
+		t.Errorf("CONNECT options = %+v, want user and password of the url without verbose mode", options)
+	}
+
+	for _, pub := range []command{commands[1], commands[4]} {
+		if want := "PUB map-of-events." + models.ChangeEventCreated + " "; !strings.HasPrefix(pub.line, want) {
+			t.Errorf("PUB = %q, want subject map-of-events.%s", pub.line, models.ChangeEventCreated)
+		}
+		var envelope map[string]interface{}
+		if err := json.Unmarshal([]byte(pub.body), &envelope); err != nil || envelope["id"] != event.ID.String() {
+			t.Errorf("PUB body = %s, want the envelope of %s", pub.body, event.ID)
+		}
+	}
+}
+
+func TestNATSPublisherReconnectsAfterError(t *testing.T) {
+	// the first connection fails the publication, the second one accepts it
+	server := newFakeNATSServer(t, "-ERR 'Permissions Violation'\r\n", "PONG\r\n")
+
+	p, err := NewNATSPublisher(server.url(), "map-of-events", time.Second)
+	if err != nil {
+		t.Fatalf("NewNATSPublisher: %v", err)
+	}
+
+	event := models.DomainEvent{ID: uuid.New(), Type: models.ChangeOrganizerDeleted, AggregateID: uuid.New(), Payload: []byte(`{}`)}
+
+	err = p.Publish(context.Background(), event)
+	if err == nil || !strings.Contains(err.Error(), "Permissions Violation") {
+		t.Errorf("Publish with -ERR = %v, want the error of the server", err)
+	}
+	server.expect(t, "CONNECT ", "PUB ", "PING")
+
+	if err := p.Publish(context.Background(), event); err != nil {
+		t.Errorf("Publish after the error: %v", err)
+	}
+	// the failed connection is closed, so the publication is made on a new one
+	server.expect(t, "CONNECT ", "PUB ", "PING")
+}
+
+func TestNATSPublisherUnavailableServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	address := listener.Addr().String()
+	_ = listener.Close()
+
+	p, err := NewNATSPublisher("nats://"+address, "map-of-events", time.Second)
+	if err != nil {
+		t.Fatalf("NewNATSPublisher: %v", err)
+	}
+
+	event := models.DomainEvent{ID: uuid.New(), Type: models.ChangeEventDeleted, Payload: []byte(`{}`)}
+	if err := p.Publish(context.Background(), event); err == nil {
+		t.Errorf("Publish to a closed port has returned no error")
+	}
+}
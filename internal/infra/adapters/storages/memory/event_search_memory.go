@@ -0,0 +1,236 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+)
+
+/*
+
+In-process full-text index of events, it's used when the application runs without
+the postgres search(in-memory/test mode). The index doesn't read the database by itself,
+it's filled by Index calls of the services and optionally by Fill from any event storage.
+
+It follows the postgres configuration: title is weighted over founding type and subjects,
+and they are over the result text, the query matches an event only if all of its words match.
+
+*/
+
+// weights of the fields, the same as default weights of ts_rank({D, C, B, A})
+const (
+	weightTitle    = 1.0
+	weightSubjects = 0.4
+	weightResult   = 0.2
+
+	snippetRadius = 10
+)
+
+type indexedEvent struct {
+	title string
+	text  string
+	// terms - stemmed words of the event with their maximal weight
+	terms map[string]float64
+}
+
+type eventSearchStorage struct {
+	mutex  sync.RWMutex
+	events map[uuid.UUID]indexedEvent
+}
+
+func (s *eventSearchStorage) Search(_ context.Context, query string, limit int) ([]models.EventSearchHit, error) {
+	queryTerms := stems(query)
+	if len(queryTerms) == 0 {
+		return make([]models.EventSearchHit, 0), nil
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	hits := make([]models.EventSearchHit, 0)
+
+	for id, e := range s.events {
+		rank, ok := 0.0, true
+		for _, t := range queryTerms {
+			weight, found := e.terms[t]
+			if !found {
+				ok = false
+				break
+			}
+			rank += weight
+		}
+		if !ok {
+			continue
+		}
+
+		hits = append(hits, models.EventSearchHit{
+			EventID: id,
+			Title:   e.title,
+			Rank:    rank / float64(len(queryTerms)),
+			Snippet: snippet(e.text, queryTerms),
+		})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Rank == hits[j].Rank {
+			return hits[i].Title < hits[j].Title
+		}
+		return hits[i].Rank > hits[j].Rank
+	})
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+func (s *eventSearchStorage) Index(_ context.Context, event models.Event, subjects []string) error {
+	terms := make(map[string]float64)
+	add := func(text string, weight float64) {
+		for _, t := range stems(text) {
+			if terms[t] < weight {
+				terms[t] = weight
+			}
+		}
+	}
+
+	add(event.Result, weightResult)
+	add(event.FoundingType, weightSubjects)
+	add(strings.Join(subjects, " "), weightSubjects)
+	add(event.Title, weightTitle)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.events[event.ID] = indexedEvent{
+		title: event.Title,
+		text:  strings.Join([]string{event.Title, event.FoundingType, event.Result}, " "),
+		terms: terms,
+	}
+	return nil
+}
+
+func (s *eventSearchStorage) Remove(_ context.Context, id uuid.UUID) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.events, id)
+	return nil
+}
+
+func NewEventSearchStorage() adapters.EventSearchStorage {
+	return &eventSearchStorage{
+		events: make(map[uuid.UUID]indexedEvent),
+	}
+}
+
+// Fill - indexes all events of the storage with names and synonyms of their subjects
+func Fill(ctx context.Context, index adapters.EventSearchStorage, events adapters.EventStorage, subjects adapters.SubjectStorage) error {
+	ids, err := events.GetIDList(ctx)
+	if err != nil {
+		return err
+	}
+
+	storedEvents, err := events.GetByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	storedSubjects, err := subjects.GetByEvents(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range storedEvents {
+		names := make([]string, 0, len(storedSubjects[e.ID]))
+		for _, s := range storedSubjects[e.ID] {
+			names = append(names, s.Name)
+			names = append(names, s.Synonyms...)
+		}
+		if err := index.Index(ctx, e, names); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// words - splits the text into words
+func words(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// stems - returns normalized stems of all words of the text
+func stems(text string) []string {
+	result := make([]string, 0)
+	for _, w := range words(text) {
+		if s := stem(w); s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// endings - common russian and english inflectional endings, longest first
+var endings = []string{
+	"иями", "ями", "ами", "ого", "его", "ому", "ему", "ыми", "ими", "ской", "ская", "ские", "ский",
+	"ией", "ия", "ии", "ий", "ой", "ый", "ая", "яя", "ое", "ее", "ые", "ие", "ом", "ем", "ам", "ям", "ах", "ях",
+	"ов", "ев", "ей", "а", "я", "о", "е", "ы", "и", "у", "ю", "ь",
+	"ies", "ing", "ed", "es", "s",
+}
+
+// stem - a light-weight stemmer, it lowercases the word and cuts a common ending,
+// keeping at least 3 letters of the word
+func stem(word string) string {
+	w := strings.ReplaceAll(strings.ToLower(word), "ё", "е")
+	runes := []rune(w)
+	for _, e := range endings {
+		ending := []rune(e)
+		if len(runes)-len(ending) >= 3 && strings.HasSuffix(w, e) {
+			return string(runes[:len(runes)-len(ending)])
+		}
+	}
+	return w
+}
+
+// snippet - returns a fragment of the text around the first match with matched words wrapped in <b></b>
+func snippet(text string, queryTerms []string) string {
+	tokens := strings.Fields(text)
+
+	first := -1
+	marked := make([]string, len(tokens))
+	for i, token := range tokens {
+		marked[i] = token
+		for _, w := range words(token) {
+			if validators.StringExists(queryTerms, stem(w)) {
+				marked[i] = strings.Replace(token, w, "<b>"+w+"</b>", 1)
+				if first < 0 {
+					first = i
+				}
+				break
+			}
+		}
+	}
+
+	if first < 0 {
+		first = 0
+	}
+
+	from, to := first-snippetRadius, first+snippetRadius
+	if from < 0 {
+		from = 0
+	}
+	if to > len(marked) {
+		to = len(marked)
+	}
+	return strings.Join(marked[from:to], " ")
+}
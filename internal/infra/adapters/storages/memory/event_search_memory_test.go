@@ -0,0 +1,192 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+)
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		word, stem string
+	}{
+		{"олимпиада", "олимпиад"},
+		{"Олимпиады", "олимпиад"},
+		{"математике", "математик"},
+		{"Ёлка", "елк"},
+		{"исследованиями", "исследован"},
+		{"кот", "кот"},
+		{"ель", "ель"},
+		{"Grants", "grant"},
+		{"testing", "test"},
+		{"studies", "stud"},
+		{"2024", "2024"},
+	}
+
+	for _, tt := range tests {
+		if got := stem(tt.word); got != tt.stem {
+			t.Errorf("stem(%q) = %q, want %q", tt.word, got, tt.stem)
+		}
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	long := make([]string, 30)
+	for i := range long {
+		long[i] = fmt.Sprintf("w%d", i)
+	}
+
+	tests := []struct {
+		name, text, query, snippet string
+	}{
+		{
+			name:    "inflected word",
+			text:    "Олимпиада по математике для школьников",
+			query:   "математика",
+			snippet: "Олимпиада по <b>математике</b> для школьников",
+		},
+		{
+			name:    "punctuation is kept outside",
+			text:    "Конкурс: гранты, стипендии",
+			query:   "грант",
+			snippet: "Конкурс: <b>гранты</b>, стипендии",
+		},
+		{
+			name:    "all matches",
+			text:    "grants and more grants",
+			query:   "grant",
+			snippet: "<b>grants</b> and more <b>grants</b>",
+		},
+		{
+			name:    "no match",
+			text:    "Олимпиада по физике",
+			query:   "химия",
+			snippet: "Олимпиада по физике",
+		},
+		{
+			name:    "window around the first match",
+			text:    strings.Join(long, " "),
+			query:   "w15",
+			snippet: strings.Join(long[5:15], " ") + " <b>w15</b> " + strings.Join(long[16:25], " "),
+		},
+	}
+
+	for _, tt := range tests {
+		if got := snippet(tt.text, stems(tt.query)); got != tt.snippet {
+			t.Errorf("%s: snippet = %q, want %q", tt.name, got, tt.snippet)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+	index := NewEventSearchStorage()
+
+	math := models.Event{ID: uuid.New(), Title: "Олимпиада по математике", FoundingType: "грант"}
+	physics := models.Event{ID: uuid.New(), Title: "Конкурс исследований", Result: "гранты на олимпиады по физике"}
+	school := models.Event{ID: uuid.New(), Title: "Школьная конференция"}
+
+	_ = index.Index(ctx, math, nil)
+	_ = index.Index(ctx, physics, []string{"физика"})
+	_ = index.Index(ctx, school, []string{"математика", "алгебра"})
+
+	tests := []struct {
+		query string
+		limit int
+		ids   []uuid.UUID
+	}{
+		{"олимпиады", 0, []uuid.UUID{math.ID, physics.ID}},
+		{"математика", 0, []uuid.UUID{math.ID, school.ID}},
+		{"алгебра", 0, []uuid.UUID{school.ID}},
+		{"олимпиада физика", 0, []uuid.UUID{physics.ID}},
+		// a word of the title and a word of the subjects
+		{"конференция алгебра", 0, []uuid.UUID{school.ID}},
+		{"олимпиада химия", 0, []uuid.UUID{}},
+		{"грант", 0, []uuid.UUID{math.ID, physics.ID}},
+		{"олимпиада", 1, []uuid.UUID{math.ID}},
+		{"  ,.", 0, []uuid.UUID{}},
+	}
+
+	for _, tt := range tests {
+		hits, err := index.Search(ctx, tt.query, tt.limit)
+		if err != nil {
+			t.Errorf("Search(%q): %v", tt.query, err)
+			continue
+		}
+		ids := make([]uuid.UUID, 0, len(hits))
+		for _, h := range hits {
+			ids = append(ids, h.EventID)
+		}
+		if !reflect.DeepEqual(ids, tt.ids) {
+			t.Errorf("Search(%q) = %v, want %v", tt.query, ids, tt.ids)
+		}
+	}
+
+	_ = index.Remove(ctx, math.ID)
+	hits, _ := index.Search(ctx, "олимпиада", 0)
+	if len(hits) != 1 || hits[0].EventID != physics.ID {
+		t.Errorf("Search after Remove = %v, want only %v", hits, physics.ID)
+	}
+}
+
+type fakeEventStorage struct {
+	adapters.EventStorage
+	events []models.Event
+	err    error
+}
+
+func (s fakeEventStorage) GetIDList(_ context.Context) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(s.events))
+	for _, e := range s.events {
+		ids = append(ids, e.ID)
+	}
+	return ids, s.err
+}
+
+func (s fakeEventStorage) GetByIDs(_ context.Context, _ []uuid.UUID) ([]models.Event, error) {
+	return s.events, nil
+}
+
+type fakeSubjectStorage struct {
+	adapters.SubjectStorage
+	subjects map[uuid.UUID][]models.Subject
+}
+
+func (s fakeSubjectStorage) GetByEvents(_ context.Context, _ []uuid.UUID) (map[uuid.UUID][]models.Subject, error) {
+	return s.subjects, nil
+}
+
+func TestFill(t *testing.T) {
+	ctx := context.Background()
+
+	event := models.Event{ID: uuid.New(), Title: "Конкурс проектов"}
+	events := fakeEventStorage{events: []models.Event{event}}
+	subjects := fakeSubjectStorage{subjects: map[uuid.UUID][]models.Subject{
+		event.ID: {{ID: uuid.New(), Name: "Информатика", Synonyms: []string{"programming"}}},
+	}}
+
+	index := NewEventSearchStorage()
+	if err := Fill(ctx, index, events, subjects); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+
+	for _, query := range []string{"проект", "информатика", "programming"} {
+		hits, _ := index.Search(ctx, query, 0)
+		if len(hits) != 1 || hits[0].EventID != event.ID {
+			t.Errorf("Search(%q) after Fill = %v, want %v", query, hits, event.ID)
+		}
+	}
+
+	failure := errors.New("storage is unavailable")
+	if err := Fill(ctx, NewEventSearchStorage(), fakeEventStorage{err: failure}, subjects); !errors.Is(err, failure) {
+		t.Errorf("Fill with failing storage = %v, want %v", err, failure)
+	}
+}
@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/pkg/postgres"
+)
+
+// analyticsStorage - the aggregates are computed by the database, every dimension gives
+// the expressions of the group's key and name and the joins they need
+type analyticsStorage struct {
+	pool *pgxpool.Pool
+}
+
+type analyticsDimension struct {
+	key   string
+	name  string
+	joins string
+	order string
+}
+
+var analyticsDimensions = map[string]analyticsDimension{
+	models.AnalyticsByOrganizer: {
+		key:   "o.organizer_id::text",
+		name:  "o.organizer_name",
+		joins: "JOIN organizer o ON o.organizer_id = e.event_organizer",
+		order: "2, 1",
+	},
+	models.AnalyticsByLevel: {
+		key:  "coalesce(l.organizer_level_id::text, '')",
+		name: "coalesce(l.organizer_level_name, '')",
+		joins: `JOIN organizer o ON o.organizer_id = e.event_organizer
+         LEFT JOIN organizer_level l ON l.organizer_level_id = o.organizer_level`,
+		order: "2, 1",
+	},
+	models.AnalyticsBySubject: {
+		key:  "coalesce(s.subject_id::text, '')",
+		name: "coalesce(s.subject_name, '')",
+		joins: `LEFT JOIN event_subject es ON es.event_id = e.event_id
+         LEFT JOIN subject s ON s.subject_id = es.subject_id`,
+		order: "2, 1",
+	},
+	models.AnalyticsByCompetitor: {
+		key:  "coalesce(c.competitor_id::text, '')",
+		name: "coalesce(c.competitor_name, '')",
+		// the same competitor can be linked to the event twice
+		joins: `LEFT JOIN (SELECT DISTINCT cr_event, cr_competitor FROM competitor_requirements) cr ON cr.cr_event = e.event_id
+         LEFT JOIN competitor c ON c.competitor_id = cr.cr_competitor`,
+		order: "2, 1",
+	},
+	models.AnalyticsByTRL: {
+		key:  "e.event_trl::text",
+		name: "''",
+		// as a number, not as the key
+		order: "min(e.event_trl)",
+	},
+	models.AnalyticsByDeadlineMonth: {
+		// the events without a deadline are in the group with the empty key
+		key:   "coalesce(to_char(e.event_submission_deadline AT TIME ZONE 'UTC', 'YYYY-MM'), '')",
+		name:  "''",
+		order: "1",
+	},
+}
+
+const analyticsQuery = `
+SELECT %[1]s AS group_key,
+       %[2]s AS group_name,
+       count(*),
+       -- the converted amounts are summed as numeric and saturated, so the large amounts can't overflow bigint
+       least(coalesce(sum(round(e.event_founding_low::numeric * f.factor::numeric)), 0), 9223372036854775807)::bigint,
+       least(coalesce(sum(round(e.event_founding_high::numeric * f.factor::numeric)), 0), 9223372036854775807)::bigint,
+       coalesce(sum(e.event_co_founding_low), 0),
+       coalesce(sum(e.event_co_founding_high), 0)
+FROM event e
+         -- the founding ranges in the currencies without a rate are counted, but not summed
+         LEFT JOIN unnest($3::text[], $4::float8[]) AS f(currency, factor) ON f.currency = e.event_founding_currency
+         %[3]s
+WHERE ($1::timestamptz IS NULL OR e.event_submission_deadline >= $1)
+  AND ($2::timestamptz IS NULL OR e.event_submission_deadline < $2)
+GROUP BY 1, 2
+ORDER BY %[4]s`
+
+func (s analyticsStorage) Aggregate(ctx context.Context, dimension string, filter models.AnalyticsFilter, factors map[string]float64) ([]models.AnalyticsGroup, error) {
+	d, ok := analyticsDimensions[dimension]
+	if !ok {
+		return nil, fmt.Errorf("unknown dimension %q", dimension)
+	}
+
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	query := fmt.Sprintf(analyticsQuery, d.key, d.name, d.joins, d.order)
+
+	currencies, values := factorColumns(factors)
+
+	rows, err := dataSource.Query(ctx, query, optionalTime(filter.DeadlineFrom), optionalTime(filter.DeadlineTo), currencies, values)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read data from database")
+	}
+	defer rows.Close()
+
+	result := make([]models.AnalyticsGroup, 0)
+	for rows.Next() {
+		var g models.AnalyticsGroup
+		if err := rows.Scan(&g.Key, &g.Name, &g.Events, &g.FoundingLow, &g.FoundingHigh, &g.CoFoundingLow, &g.CoFoundingHigh); err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to read data from database")
+		}
+		result = append(result, g)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read data from database")
+	}
+	return result, nil
+}
+
+// optionalTime - NULL for the zero time
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// timeOrZero - the zero time for NULL
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+func NewPostgresAnalyticsStorage(p *pgxpool.Pool) adapters.AnalyticsStorage {
+	return &analyticsStorage{
+		pool: p,
+	}
+}
@@ -0,0 +1,241 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/pkg/postgres"
+)
+
+type postgresApplicationStorage struct {
+	pool *pgxpool.Pool
+}
+
+func (s postgresApplicationStorage) BeginTransaction(ctx context.Context) (interface{}, error) {
+	return s.pool.Begin(ctx)
+}
+
+func (s postgresApplicationStorage) CommitTransaction(ctx context.Context, transaction interface{}) error {
+	tx := transaction.(*pgxpool.Tx)
+	return tx.Commit(ctx)
+}
+
+func (s postgresApplicationStorage) CloseTransaction(ctx context.Context, transaction interface{}) error {
+	tx := transaction.(*pgxpool.Tx)
+	return tx.Rollback(ctx)
+}
+
+const applicationColumns = "application_id, application_event, application_team, application_stage, application_created_at"
+
+func (s postgresApplicationStorage) GetByID(ctx context.Context, id uuid.UUID) (models.Application, error) {
+	applications, err := s.getMany(ctx, "SELECT "+applicationColumns+" FROM application WHERE application_id = $1", id)
+	if err != nil {
+		return models.Application{}, err
+	}
+	if len(applications) == 0 {
+		return models.Application{}, adapters.ErrNotFound
+	}
+	return applications[0], nil
+}
+
+func (s postgresApplicationStorage) GetByEvent(ctx context.Context, eventId uuid.UUID) ([]models.Application, error) {
+	return s.getMany(ctx,
+		"SELECT "+applicationColumns+" FROM application WHERE application_event = $1 ORDER BY application_created_at",
+		eventId)
+}
+
+func (s postgresApplicationStorage) GetByTeam(ctx context.Context, teamId uuid.UUID) ([]models.Application, error) {
+	return s.getMany(ctx,
+		"SELECT "+applicationColumns+" FROM application WHERE application_team = $1 ORDER BY application_created_at",
+		teamId)
+}
+
+// getMany - reads applications by the query and fills their history, notes and files
+func (s postgresApplicationStorage) getMany(ctx context.Context, query string, args ...interface{}) ([]models.Application, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	rows, err := dataSource.Query(ctx, query, args...)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read data from database")
+	}
+
+	applications := make([]models.Application, 0)
+	index := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var a models.Application
+		if err := rows.Scan(&a.ID, &a.Event, &a.Team, &a.Stage, &a.CreatedAt); err != nil {
+			rows.Close()
+			log.Println(err)
+			return nil, errors.New("failed to read data from database")
+		}
+		a.History = make([]models.StageChange, 0)
+		a.Notes = make([]models.ApplicationNote, 0)
+		a.Files = make([]models.ApplicationFile, 0)
+		index[a.ID] = len(applications)
+		applications = append(applications, a)
+	}
+	rows.Close()
+
+	if len(applications) == 0 {
+		return applications, nil
+	}
+
+	ids := make([]uuid.UUID, len(applications))
+	for i, v := range applications {
+		ids[i] = v.ID
+	}
+
+	err = s.readDetails(ctx, dataSource,
+		"SELECT application_id, stage, changed_at FROM application_stage_change WHERE application_id = ANY($1) ORDER BY changed_at",
+		ids, func(row pgx.Rows) error {
+			var id uuid.UUID
+			var change models.StageChange
+			if err := row.Scan(&id, &change.Stage, &change.ChangedAt); err != nil {
+				return err
+			}
+			applications[index[id]].History = append(applications[index[id]].History, change)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.readDetails(ctx, dataSource,
+		"SELECT application_id, note_id, note_text, note_created_at FROM application_note WHERE application_id = ANY($1) ORDER BY note_created_at",
+		ids, func(row pgx.Rows) error {
+			var id uuid.UUID
+			var note models.ApplicationNote
+			if err := row.Scan(&id, &note.ID, &note.Text, &note.CreatedAt); err != nil {
+				return err
+			}
+			applications[index[id]].Notes = append(applications[index[id]].Notes, note)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.readDetails(ctx, dataSource,
+		"SELECT application_id, file_link, file_name, file_attached_at FROM application_file WHERE application_id = ANY($1) ORDER BY file_attached_at",
+		ids, func(row pgx.Rows) error {
+			var id uuid.UUID
+			var file models.ApplicationFile
+			if err := row.Scan(&id, &file.Link, &file.Name, &file.AttachedAt); err != nil {
+				return err
+			}
+			applications[index[id]].Files = append(applications[index[id]].Files, file)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return applications, nil
+}
+
+// readDetails - calls scan for every row of the query by ids of the applications
+func (s postgresApplicationStorage) readDetails(ctx context.Context, dataSource postgres.Connection, query string, ids []uuid.UUID,
+	scan func(row pgx.Rows) error) error {
+	rows, err := dataSource.Query(ctx, query, ids)
+	if err != nil {
+		log.Println(err)
+		return errors.New("failed to read data from database")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			log.Println(err)
+			return errors.New("failed to read data from database")
+		}
+	}
+	return nil
+}
+
+func (s postgresApplicationStorage) Add(ctx context.Context, application models.Application) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	// the application and its initial stage are inserted in one statement
+	command := `WITH a AS (
+		INSERT INTO application(` + applicationColumns + `) VALUES ($1, $2, $3, $4, $5)
+		RETURNING application_id
+	)
+	INSERT INTO application_stage_change(application_id, stage, changed_at)
+	SELECT application_id, $4, $5 FROM a`
+
+	_, err := dataSource.Exec(ctx, command,
+		application.ID, application.Event, application.Team, application.Stage, application.CreatedAt)
+	if err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	return nil
+}
+
+func (s postgresApplicationStorage) ChangeStage(ctx context.Context, id uuid.UUID, change models.StageChange) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := `WITH a AS (
+		UPDATE application SET application_stage = $2 WHERE application_id = $1
+		RETURNING application_id
+	)
+	INSERT INTO application_stage_change(application_id, stage, changed_at)
+	SELECT application_id, $2, $3 FROM a`
+
+	tag, err := dataSource.Exec(ctx, command, id, change.Stage, change.ChangedAt)
+	if err != nil {
+		log.Println(err)
+		return errors.New("failed to update the database")
+	}
+	if tag.RowsAffected() == 0 {
+		return adapters.ErrNotFound
+	}
+	return nil
+}
+
+func (s postgresApplicationStorage) AddNote(ctx context.Context, id uuid.UUID, note models.ApplicationNote) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := "INSERT INTO application_note(note_id, application_id, note_text, note_created_at) VALUES ($1, $2, $3, $4)"
+	if _, err := dataSource.Exec(ctx, command, note.ID, id, note.Text, note.CreatedAt); err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	return nil
+}
+
+func (s postgresApplicationStorage) AddFile(ctx context.Context, id uuid.UUID, file models.ApplicationFile) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := `INSERT INTO application_file(application_id, file_link, file_name, file_attached_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (application_id, file_link) DO UPDATE SET file_name = excluded.file_name`
+	if _, err := dataSource.Exec(ctx, command, id, file.Link, file.Name, file.AttachedAt); err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	return nil
+}
+
+func (s postgresApplicationStorage) Delete(ctx context.Context, id uuid.UUID) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	if _, err := dataSource.Exec(ctx, "DELETE FROM application WHERE application_id = $1", id); err != nil {
+		log.Println(err)
+		return errors.New("failed to delete from database")
+	}
+	return nil
+}
+
+func NewPostgresApplicationStorage(p *pgxpool.Pool) adapters.ApplicationStorage {
+	return &postgresApplicationStorage{
+		pool: p,
+	}
+}
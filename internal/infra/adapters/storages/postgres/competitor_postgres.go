@@ -49,14 +49,25 @@ func (s competitorStorage) AllIDs(ctx context.Context) ([]uuid.UUID, errors.Erro
 	return result, nil
 }
 
+const competitorColumns = `competitor_id, competitor_name, competitor_category, competitor_min_age, competitor_max_age,
+	competitor_min_degree, competitor_regions, competitor_organization_types`
+
+func scanCompetitor(row pgx.Row) (models.Competitor, error) {
+	var c models.Competitor
+	err := row.Scan(&c.ID, &c.Name, &c.Category, &c.MinAge, &c.MaxAge, &c.MinDegree, &c.Regions, &c.OrganizationTypes)
+	return c, err
+}
+
 func (s competitorStorage) Get(ctx context.Context, id uuid.UUID) (models.Competitor, errors.Error) {
 	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
-	var c models.Competitor
-	query := fmt.Sprintf("SELECT * FROM competitor WHERE competitor_id == '%s'", id.String())
-	if err := dataSource.QueryRow(ctx, query).Scan(&c.ID, &c.Name); err != nil {
-		return models.Competitor{}, handleTheError(err,
-			errors.CreateError(adapters.ErrReasonObjectNotFoundErr, "object was not found",
-				fmt.Sprintf("object %s was not found in competitors", id.String())))
+	query := "SELECT " + competitorColumns + " FROM competitor WHERE competitor_id = $1"
+	c, err := scanCompetitor(dataSource.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return models.Competitor{}, errors.CreateError(adapters.ErrReasonObjectNotFoundErr, "object was not found",
+				fmt.Sprintf("object %s was not found in competitors", id.String()))
+		}
+		return models.Competitor{}, createInternalStorageError(err, "failed to read database")
 	}
 	return c, nil
 }
@@ -66,31 +77,50 @@ func (s competitorStorage) GetAll(ctx context.Context) ([]models.Competitor, err
 
 	comps := make([]models.Competitor, 0)
 
-	rows, err := dataSource.Query(ctx, "SELECT * FROM competitor")
+	rows, err := dataSource.Query(ctx, "SELECT "+competitorColumns+" FROM competitor")
 	if err != nil {
-		return nil, handleTheError(err, createInternalStorageError(err, "failed to read database"))
+		return nil, createInternalStorageError(err, "failed to read database")
 	}
+	defer rows.Close()
 
 	for rows.Next() {
-		values, err := rows.Values()
+		c, err := scanCompetitor(rows)
 		if err != nil {
-			return nil, handleTheError(err, createInternalStorageError(err, "failed to read values"))
+			return nil, createInternalStorageError(err, "failed to read values")
 		}
-		id, e := parseIDFromValue(values[0])
-		if e != nil {
-			return nil, e
-		}
-		name := values[1].(string)
-		comps = append(comps, models.Competitor{ID: id, Name: name})
+		comps = append(comps, c)
+	}
+
+	return comps, nil
+}
+
+func (s competitorStorage) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Competitor, errors.Error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	rows, err := dataSource.Query(ctx, "SELECT "+competitorColumns+" FROM competitor WHERE competitor_id = ANY($1)", ids)
+	if err != nil {
+		return nil, createInternalStorageError(err, "failed to read database")
 	}
+	defer rows.Close()
 
+	comps := make([]models.Competitor, 0, len(ids))
+	for rows.Next() {
+		c, err := scanCompetitor(rows)
+		if err != nil {
+			return nil, createInternalStorageError(err, "failed to read values")
+		}
+		comps = append(comps, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, createInternalStorageError(err, "failed to read values")
+	}
 	return comps, nil
 }
 
 func (s competitorStorage) Create(ctx context.Context, competitor models.Competitor) errors.Error {
 	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
-	command := "INSERT INTO competitor (competitor_id, competitor_name) VALUES ($1, $2)"
-	if _, err := dataSource.Exec(ctx, command, competitor.ID, competitor.Name); err != nil {
+	command := "INSERT INTO competitor (" + competitorColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7, $8)"
+	if _, err := dataSource.Exec(ctx, command, competitorValues(competitor)...); err != nil {
 		return createInternalStorageError(err, "failed to create a competitor")
 	}
 	return nil
@@ -98,23 +128,56 @@ func (s competitorStorage) Create(ctx context.Context, competitor models.Competi
 
 func (s competitorStorage) Update(ctx context.Context, competitor models.Competitor) errors.Error {
 	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
-	command := "UPDATE competitor SET competitor_name = $2 WHERE competitor_id = $1"
-	if _, err := dataSource.Exec(ctx, command, competitor.ID, competitor.Name); err != nil {
+	command := `UPDATE competitor
+		SET competitor_name = $2, competitor_category = $3, competitor_min_age = $4, competitor_max_age = $5,
+		    competitor_min_degree = $6, competitor_regions = $7, competitor_organization_types = $8
+		WHERE competitor_id = $1`
+	if _, err := dataSource.Exec(ctx, command, competitorValues(competitor)...); err != nil {
 		return createInternalStorageError(err, "failed to update a competitor")
 	}
 	return nil
 }
 
+// competitorValues - values of the competitor in the order of competitorColumns
+func competitorValues(c models.Competitor) []interface{} {
+	regions := c.Regions
+	if regions == nil {
+		regions = make([]string, 0)
+	}
+	organizationTypes := c.OrganizationTypes
+	if organizationTypes == nil {
+		organizationTypes = make([]string, 0)
+	}
+	return []interface{}{c.ID, c.Name, c.Category, c.MinAge, c.MaxAge, c.MinDegree, regions, organizationTypes}
+}
+
 func (s competitorStorage) Delete(ctx context.Context, id uuid.UUID) errors.Error {
 	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
-	_, err := dataSource.Exec(ctx, "DELETE FROM competitor WHERE competitor_id = $1", id)
-	return createInternalStorageError(err, "failed to delete a competitor")
+	if _, err := dataSource.Exec(ctx, "DELETE FROM competitor WHERE competitor_id = $1", id); err != nil {
+		return createInternalStorageError(err, "failed to delete a competitor")
+	}
+	return nil
+}
+
+func (s competitorStorage) Suggest(ctx context.Context, text string, limit int) ([]models.Suggestion, errors.Error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	result, err := suggest(ctx, dataSource, "competitor", "competitor_id", "competitor_name", text, limit)
+	if err != nil {
+		return nil, createInternalStorageError(err, "failed to read database")
+	}
+	return result, nil
 }
 
 func (s competitorStorage) BeginTransaction(ctx context.Context) (interface{}, error) {
 	return s.pool.Begin(ctx)
 }
 
+func (s competitorStorage) CommitTransaction(ctx context.Context, transaction interface{}) error {
+	tx := transaction.(*pgxpool.Tx)
+	return tx.Commit(ctx)
+}
+
 func (s competitorStorage) CloseTransaction(ctx context.Context, transaction interface{}) error {
 	tx := transaction.(*pgxpool.Tx)
 	return tx.Rollback(ctx)
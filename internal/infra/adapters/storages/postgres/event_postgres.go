@@ -5,9 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/indigowar/map-of-events/internal/domain/adapters"
@@ -15,6 +15,13 @@ import (
 	"github.com/indigowar/map-of-events/pkg/postgres"
 )
 
+// eventColumns - columns of event table in order they're scanned into models.Event,
+// the submission deadline is NULL for the events, whose deadline date was never stored, it's read as the zero time
+const eventColumns = `event_id, title, event_organizer, event_founding_type,
+	event_founding_low, event_founding_high, event_founding_currency, event_co_founding_low, event_co_founding_high,
+	event_submission_deadline, event_consideration_period, event_realisation_period, event_result,
+	event_site, event_document, event_internal_contacts, event_trl, event_version`
+
 type postgresEventStorage struct {
 	pool *pgxpool.Pool
 }
@@ -23,6 +30,11 @@ func (s postgresEventStorage) BeginTransaction(ctx context.Context) (interface{}
 	return s.pool.Begin(ctx)
 }
 
+func (s postgresEventStorage) CommitTransaction(ctx context.Context, transaction interface{}) error {
+	tx := transaction.(*pgxpool.Tx)
+	return tx.Commit(ctx)
+}
+
 func (s postgresEventStorage) CloseTransaction(ctx context.Context, transaction interface{}) error {
 	tx := transaction.(*pgxpool.Tx)
 	return tx.Rollback(ctx)
@@ -63,20 +75,24 @@ func (s postgresEventStorage) GetIDList(ctx context.Context) ([]uuid.UUID, error
 func (s postgresEventStorage) GetByID(ctx context.Context, id uuid.UUID) (models.Event, error) {
 	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
 
-	query := fmt.Sprintf("SELECT * FROM event WHERE event_id = '%s'", id.String())
+	query := fmt.Sprintf("SELECT %s FROM event WHERE event_id = '%s'", eventColumns, id.String())
 
 	var event models.Event
+	var deadline *time.Time
 
 	err := dataSource.QueryRow(ctx, query).Scan(
-		&event.ID, &event.Title, &event.Organizer, &event.FoundingType, &event.FoundingRange, &event.CoFoundingRange, &event.SubmissionDeadline,
+		&event.ID, &event.Title, &event.Organizer, &event.FoundingType,
+		&event.FoundingRange.Low, &event.FoundingRange.High, &event.FoundingRange.Currency,
+		&event.CoFoundingRange.Low, &event.CoFoundingRange.High, &deadline,
 		&event.ConsiderationPeriod, &event.RealisationPeriod, &event.Result, &event.Site, &event.Document, &event.InternalContacts,
-		&event.TRL,
+		&event.TRL, &event.Version,
 	)
 
 	if err != nil {
 		log.Println(err)
 		return models.Event{}, errors.New("failed to read data from database")
 	}
+	event.SubmissionDeadline = timeOrZero(deadline)
 
 	event.Competitors, err = s.GetCompetitors(ctx, id)
 	if err != nil {
@@ -86,21 +102,86 @@ func (s postgresEventStorage) GetByID(ctx context.Context, id uuid.UUID) (models
 	return event, nil
 }
 
+func (s postgresEventStorage) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Event, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	rows, err := dataSource.Query(ctx, "SELECT "+eventColumns+" FROM event WHERE event_id = ANY($1)", ids)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read data from database")
+	}
+	defer rows.Close()
+
+	events := make([]models.Event, 0, len(ids))
+
+	for rows.Next() {
+		var event models.Event
+		var deadline *time.Time
+		err := rows.Scan(
+			&event.ID, &event.Title, &event.Organizer, &event.FoundingType,
+			&event.FoundingRange.Low, &event.FoundingRange.High, &event.FoundingRange.Currency,
+			&event.CoFoundingRange.Low, &event.CoFoundingRange.High, &deadline,
+			&event.ConsiderationPeriod, &event.RealisationPeriod, &event.Result, &event.Site, &event.Document, &event.InternalContacts,
+			&event.TRL, &event.Version,
+		)
+		if err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to read fetched data from database")
+		}
+		event.SubmissionDeadline = timeOrZero(deadline)
+		events = append(events, event)
+	}
+
+	events = orderByIDs(events, ids)
+
+	competitors, err := s.GetCompetitorsForEvents(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range events {
+		events[i].Competitors = competitors[events[i].ID]
+		if events[i].Competitors == nil {
+			events[i].Competitors = make([]uuid.UUID, 0)
+		}
+	}
+
+	return events, nil
+}
+
+// orderByIDs - puts the events in the order of their ids, the rows are returned by the database in any order
+func orderByIDs(events []models.Event, ids []uuid.UUID) []models.Event {
+	byID := make(map[uuid.UUID]models.Event, len(events))
+	for _, e := range events {
+		byID[e.ID] = e
+	}
+
+	result := make([]models.Event, 0, len(events))
+	for _, id := range ids {
+		if e, ok := byID[id]; ok {
+			result = append(result, e)
+			// an id given twice gives the event once
+			delete(byID, id)
+		}
+	}
+	return result
+}
+
 func (s postgresEventStorage) Add(ctx context.Context, event models.Event) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
 	command :=
-		`INSERT INTO event(
-                  event_id, title, event_organizer, event_founding_type, event_founding_range, event_co_founding_range,
-                  event_submission_deadline, event_consideration_period, event_realisation_period, event_result,
-                  event_site, event_document, event_internal_contacts, event_trl)
+		`INSERT INTO event(` + eventColumns + `)
 		VALUES 
 		(
-		 $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+		 $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
 		)`
 
-	_, err := s.pool.Exec(ctx, command,
-		event.ID, event.Title, event.Organizer, event.FoundingType, event.FoundingRange,
-		event.CoFoundingRange, event.SubmissionDeadline, event.ConsiderationPeriod, event.RealisationPeriod,
-		event.Result, event.Site, event.Document, event.InternalContacts, event.TRL)
+	_, err := dataSource.Exec(ctx, command,
+		event.ID, event.Title, event.Organizer, event.FoundingType,
+		event.FoundingRange.Low, event.FoundingRange.High, event.FoundingRange.Currency,
+		event.CoFoundingRange.Low, event.CoFoundingRange.High, optionalTime(event.SubmissionDeadline), event.ConsiderationPeriod, event.RealisationPeriod,
+		event.Result, event.Site, event.Document, event.InternalContacts, event.TRL, event.Version)
 
 	if err != nil {
 		log.Println(err)
@@ -109,71 +190,73 @@ func (s postgresEventStorage) Add(ctx context.Context, event models.Event) error
 	return nil
 }
 
-func (s postgresEventStorage) Remove(ctx context.Context, id uuid.UUID) error {
-	tx, err := s.pool.Begin(ctx)
-	if err != nil {
-		log.Println(err)
-		return errors.New("transaction failure")
-	}
-	defer func(tx pgx.Tx, ctx context.Context) {
-		_ = tx.Rollback(ctx)
-	}(tx, ctx)
+func (s postgresEventStorage) Remove(ctx context.Context, id uuid.UUID, version int) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
 
-	if _, err := tx.Exec(ctx, "DELETE FROM event WHERE event_id = $1", id); err != nil {
+	if _, err := dataSource.Exec(ctx, "DELETE FROM competitor_requirements WHERE cr_event = $1", id); err != nil {
 		log.Println(err)
 		return errors.New("failed to delete from db")
 	}
 
-	if _, err := tx.Exec(ctx, "DELETE FROM competitor_requirements WHERE cr_event = $1", id); err != nil {
+	tag, err := dataSource.Exec(ctx, "DELETE FROM event WHERE event_id = $1 AND event_version = $2", id, version)
+	if err != nil {
 		log.Println(err)
 		return errors.New("failed to delete from db")
 	}
 
+	if tag.RowsAffected() == 0 {
+		return adapters.ErrStaleVersion
+	}
+
 	return nil
 }
 
 func (s postgresEventStorage) Update(ctx context.Context, event models.Event) error {
 	// TODO: Add updating of competitor requirements
-	tx, err := s.pool.Begin(ctx)
-	if err != nil {
-		log.Println(err)
-		return errors.New("failed to start a transaction")
-	}
-	defer func(tx pgx.Tx, ctx context.Context) {
-		_ = tx.Rollback(ctx)
-	}(tx, ctx)
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
 
 	command := ` UPDATE event SET 
-                  title = $1,
-                  event_organizer = $2,
-                  event_founding_type = $3,
-                  event_founding_range = $4,
-                  event_co_founding_range = $5,
-                  event_submission_deadline = $6,
-                  event_consideration_period = $7,
-                  event_realisation_period = $8,
-                  event_result = $9,
-                  event_site = $10,
-                  event_document = $11,
-                  event_internal_contacts = $12,
-                  event_trl = $13
-                  WHERE event_id = $1`
-
-	_, err = tx.Exec(ctx, command,
-		event.ID, event.Title, event.Organizer, event.FoundingType, event.FoundingRange,
-		event.CoFoundingRange, event.SubmissionDeadline, event.ConsiderationPeriod, event.RealisationPeriod,
-		event.Result, event.Site, event.Document, event.InternalContacts, event.TRL)
+                  title = $2,
+                  event_organizer = $3,
+                  event_founding_type = $4,
+                  event_founding_low = $5,
+                  event_founding_high = $6,
+                  event_founding_currency = $7,
+                  event_co_founding_low = $8,
+                  event_co_founding_high = $9,
+                  event_submission_deadline = $10,
+                  event_consideration_period = $11,
+                  event_realisation_period = $12,
+                  event_result = $13,
+                  event_site = $14,
+                  event_document = $15,
+                  event_internal_contacts = $16,
+                  event_trl = $17,
+                  event_version = event_version + 1
+                  WHERE event_id = $1 AND event_version = $18`
+
+	tag, err := dataSource.Exec(ctx, command,
+		event.ID, event.Title, event.Organizer, event.FoundingType,
+		event.FoundingRange.Low, event.FoundingRange.High, event.FoundingRange.Currency,
+		event.CoFoundingRange.Low, event.CoFoundingRange.High, optionalTime(event.SubmissionDeadline), event.ConsiderationPeriod, event.RealisationPeriod,
+		event.Result, event.Site, event.Document, event.InternalContacts, event.TRL, event.Version)
 
 	if err != nil {
 		log.Println(err)
 		return errors.New("failed to write in database")
 	}
+
+	if tag.RowsAffected() == 0 {
+		return adapters.ErrStaleVersion
+	}
 	return nil
 }
 
 func (s postgresEventStorage) AddCompetitor(ctx context.Context, id, competitorId uuid.UUID) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
 	command := "INSERT INTO competitor_requirements(cr_id, cr_event, cr_competitor) VALUES ($1, $2, $3)"
-	_, err := s.pool.Exec(ctx, command, uuid.New(), id, competitorId)
+	_, err := dataSource.Exec(ctx, command, uuid.New(), id, competitorId)
 	if err != nil {
 		log.Println(err)
 		return errors.New("failed to write into database")
@@ -182,8 +265,10 @@ func (s postgresEventStorage) AddCompetitor(ctx context.Context, id, competitorI
 }
 
 func (s postgresEventStorage) RemoveCompetitor(ctx context.Context, id, competitorId uuid.UUID) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
 	command := "DELETE FROM competitor_requirements WHERE cr_event=$1 AND cr_competitor=$2"
-	_, err := s.pool.Exec(ctx, command, id, competitorId)
+	_, err := dataSource.Exec(ctx, command, id, competitorId)
 	if err != nil {
 		log.Println(err)
 		return errors.New("failed to delete from database")
@@ -192,9 +277,11 @@ func (s postgresEventStorage) RemoveCompetitor(ctx context.Context, id, competit
 }
 
 func (s postgresEventStorage) GetCompetitors(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
 	query := fmt.Sprintf("SELECT cr_competitor FROM competitor_requirements WHERE cr_event='%s'", id.String())
 
-	rows, err := s.pool.Query(ctx, query)
+	rows, err := dataSource.Query(ctx, query)
 	if err != nil {
 		log.Println(err)
 		return nil, errors.New("failed to read from database")
@@ -220,6 +307,31 @@ func (s postgresEventStorage) GetCompetitors(ctx context.Context, id uuid.UUID)
 	return ids, nil
 }
 
+func (s postgresEventStorage) GetCompetitorsForEvents(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]uuid.UUID, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	query := "SELECT cr_event, cr_competitor FROM competitor_requirements WHERE cr_event = ANY($1)"
+
+	rows, err := dataSource.Query(ctx, query, ids)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read from database")
+	}
+	defer rows.Close()
+
+	result := make(map[uuid.UUID][]uuid.UUID)
+
+	for rows.Next() {
+		var event, competitor uuid.UUID
+		if err := rows.Scan(&event, &competitor); err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to read fetched data")
+		}
+		result[event] = append(result[event], competitor)
+	}
+	return result, nil
+}
+
 func NewPostgresEventStorage(p *pgxpool.Pool) adapters.EventStorage {
 	return &postgresEventStorage{
 		pool: p,
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/pkg/postgres"
+)
+
+// eventSearchStorage - searches events using event_search tsvector column,
+// subjects(with their synonyms) are stored in other table, so they're added to the document in the query.
+type eventSearchStorage struct {
+	pool *pgxpool.Pool
+}
+
+const eventSearchQuery = `
+WITH q AS (
+    SELECT websearch_to_tsquery('russian', $1) || websearch_to_tsquery('english', $1) AS query
+),
+subjects AS (
+    SELECT es.event_id,
+           setweight(to_tsvector('russian', string_agg(
+                   concat_ws(' ', s.subject_name, array_to_string(s.subject_synonyms, ' ')), ' ')), 'B') AS document
+    FROM event_subject es
+             JOIN subject s ON s.subject_id = es.subject_id
+    GROUP BY es.event_id
+)
+SELECT e.event_id,
+       e.title,
+       ts_rank(e.event_search || coalesce(s.document, ''::tsvector), q.query) AS rank,
+       ts_headline('russian',
+                   concat_ws(' ', e.title, e.event_founding_type, e.event_result),
+                   q.query,
+                   'StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=30, MinWords=10') AS snippet
+FROM event e
+         CROSS JOIN q
+         LEFT JOIN subjects s ON s.event_id = e.event_id
+-- the words of a query can be found partly in the event and partly in its subjects
+WHERE (e.event_search || coalesce(s.document, ''::tsvector)) @@ q.query
+ORDER BY rank DESC
+LIMIT $2`
+
+func (s eventSearchStorage) Search(ctx context.Context, query string, limit int) ([]models.EventSearchHit, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	rows, err := dataSource.Query(ctx, eventSearchQuery, query, limit)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to search in database")
+	}
+	defer rows.Close()
+
+	hits := make([]models.EventSearchHit, 0)
+
+	for rows.Next() {
+		var hit models.EventSearchHit
+		var rank float32
+		if err := rows.Scan(&hit.EventID, &hit.Title, &rank, &hit.Snippet); err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to read fetched data")
+		}
+		hit.Rank = float64(rank)
+		hits = append(hits, hit)
+	}
+
+	return hits, nil
+}
+
+// Index - does nothing, event_search column is generated by the database
+func (s eventSearchStorage) Index(_ context.Context, _ models.Event, _ []string) error {
+	return nil
+}
+
+// Remove - does nothing, event_search column is removed with the event
+func (s eventSearchStorage) Remove(_ context.Context, _ uuid.UUID) error {
+	return nil
+}
+
+func NewPostgresEventSearchStorage(p *pgxpool.Pool) adapters.EventSearchStorage {
+	return &eventSearchStorage{
+		pool: p,
+	}
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/pkg/postgres"
+)
+
+type postgresFavouriteStorage struct {
+	pool *pgxpool.Pool
+}
+
+func (s postgresFavouriteStorage) Add(ctx context.Context, favourite models.Favourite) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := `INSERT INTO favourite(favourite_user, favourite_event, favourite_created_at) VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING`
+	if _, err := dataSource.Exec(ctx, command, favourite.User, favourite.Event, favourite.CreatedAt); err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	return nil
+}
+
+func (s postgresFavouriteStorage) Remove(ctx context.Context, user, event uuid.UUID) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := "DELETE FROM favourite WHERE favourite_user = $1 AND favourite_event = $2"
+	if _, err := dataSource.Exec(ctx, command, user, event); err != nil {
+		log.Println(err)
+		return errors.New("failed to delete from database")
+	}
+	return nil
+}
+
+func (s postgresFavouriteStorage) GetByUser(ctx context.Context, user uuid.UUID) ([]models.Favourite, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	query := `SELECT favourite_user, favourite_event, favourite_created_at FROM favourite
+		WHERE favourite_user = $1 ORDER BY favourite_created_at DESC`
+
+	rows, err := dataSource.Query(ctx, query, user)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read data from database")
+	}
+	defer rows.Close()
+
+	result := make([]models.Favourite, 0)
+	for rows.Next() {
+		var f models.Favourite
+		if err := rows.Scan(&f.User, &f.Event, &f.CreatedAt); err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to read data from database")
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+func (s postgresFavouriteStorage) Filter(ctx context.Context, user uuid.UUID, events []uuid.UUID) ([]uuid.UUID, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	query := "SELECT favourite_event FROM favourite WHERE favourite_user = $1 AND favourite_event = ANY($2)"
+
+	rows, err := dataSource.Query(ctx, query, user, events)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read data from database")
+	}
+	defer rows.Close()
+
+	result := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to read data from database")
+		}
+		result = append(result, id)
+	}
+	return result, nil
+}
+
+func (s postgresFavouriteStorage) GetByEvents(ctx context.Context, events []uuid.UUID) ([]models.Favourite, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	query := `SELECT favourite_user, favourite_event, favourite_created_at FROM favourite
+		WHERE favourite_event = ANY($1)`
+
+	rows, err := dataSource.Query(ctx, query, events)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read data from database")
+	}
+	defer rows.Close()
+
+	result := make([]models.Favourite, 0)
+	for rows.Next() {
+		var f models.Favourite
+		if err := rows.Scan(&f.User, &f.Event, &f.CreatedAt); err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to read data from database")
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+func NewPostgresFavouriteStorage(p *pgxpool.Pool) adapters.FavouriteStorage {
+	return &postgresFavouriteStorage{
+		pool: p,
+	}
+}
@@ -3,10 +3,10 @@ package postgres
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/indigowar/map-of-events/internal/domain/adapters"
@@ -14,69 +14,56 @@ import (
 	"github.com/indigowar/map-of-events/pkg/postgres"
 )
 
+// foundingRangeStorage - the founding ranges are the columns of the event table
 type foundingRangeStorage struct {
 	pool *pgxpool.Pool
 }
 
-func (s foundingRangeStorage) BeginTransaction(ctx context.Context) (interface{}, error) {
-	return s.pool.Begin(ctx)
-}
-
-func (s foundingRangeStorage) CloseTransaction(ctx context.Context, transaction interface{}) error {
-	tx := transaction.(*pgxpool.Tx)
-	return tx.Rollback(ctx)
-}
-
-func (s foundingRangeStorage) GetByID(ctx context.Context, id uuid.UUID) (models.RangeModel, error) {
+func (s foundingRangeStorage) GetByEvent(ctx context.Context, event uuid.UUID) (models.RangeModel, error) {
 	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
 
-	var Id uuid.UUID
-	var low, high int
-
-	query := fmt.Sprintf("SELECT * FROM founding_range WHERE founding_range_id = '%s'", id.String())
+	query := "SELECT event_founding_low, event_founding_high, event_founding_currency FROM event WHERE event_id = $1"
 
-	if err := dataSource.QueryRow(ctx, query).Scan(&Id, &low, &high); err != nil {
-		log.Println("Got query error or scan error: ", err)
-		return models.RangeModel{}, err
-	}
-
-	return models.RangeModel{ID: Id, Low: low, High: high}, nil
-}
-
-func (s foundingRangeStorage) GetMaximumRange(ctx context.Context) (models.RangeModel, error) {
-	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
-
-	var low, high int
-	if dataSource.QueryRow(ctx, "SELECT MIN(founding_range_low) FROM founding_range").Scan(&low) != nil ||
-		dataSource.QueryRow(ctx, "SELECT MAX(founding_range_high) FROM founding_range").Scan(&high) != nil {
+	var r models.RangeModel
+	if err := dataSource.QueryRow(ctx, query, event).Scan(&r.Low, &r.High, &r.Currency); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.RangeModel{}, adapters.ErrNotFound
+		}
+		log.Println(err)
 		return models.RangeModel{}, errors.New("failed to read database")
 	}
-	return models.RangeModel{Low: low, High: high}, nil
+	return r, nil
 }
 
-func (s foundingRangeStorage) Create(ctx context.Context, foundingRange models.RangeModel) (models.RangeModel, error) {
+// GetMaximumRange - the bounds are converted by the factors, the ranges in the currencies without a factor are skipped,
+// the converted bounds are computed as numeric and saturated at the maximum of bigint
+func (s foundingRangeStorage) GetMaximumRange(ctx context.Context, factors map[string]float64) (models.RangeModel, error) {
 	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
 
-	command := "INSERT INTO founding_range (founding_range_id, founding_range_low, founding_range_high) VALUES ($1, $2, $3)"
+	query := `SELECT least(coalesce(min(round(e.event_founding_low::numeric * f.factor::numeric)), 0), 9223372036854775807)::bigint,
+		least(coalesce(max(round(e.event_founding_high::numeric * f.factor::numeric)), 0), 9223372036854775807)::bigint
+		FROM event e
+		JOIN unnest($1::text[], $2::float8[]) AS f(currency, factor) ON f.currency = e.event_founding_currency`
+
+	currencies, values := factorColumns(factors)
 
-	_, err := dataSource.Exec(ctx, command, foundingRange.ID, foundingRange.Low, foundingRange.High)
-	if err != nil {
+	var r models.RangeModel
+	if err := dataSource.QueryRow(ctx, query, currencies, values).Scan(&r.Low, &r.High); err != nil {
 		log.Println(err)
-		return models.RangeModel{}, errors.New("failed to insert")
+		return models.RangeModel{}, errors.New("failed to read database")
 	}
-
-	return s.GetByID(ctx, foundingRange.ID)
+	return r, nil
 }
 
-func (s foundingRangeStorage) Delete(ctx context.Context, id uuid.UUID) error {
-	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
-
-	if _, err := dataSource.Exec(ctx, "DELETE FROM founding_range WHERE founding_range_id = $1", id); err != nil {
-		log.Println(err)
-		return errors.New("failed to delete from database")
+// factorColumns - the factors as two arrays for unnest, the currencies are in the same order as their factors
+func factorColumns(factors map[string]float64) ([]string, []float64) {
+	currencies := make([]string, 0, len(factors))
+	values := make([]float64, 0, len(factors))
+	for currency, factor := range factors {
+		currencies = append(currencies, currency)
+		values = append(values, factor)
 	}
-
-	return nil
+	return currencies, values
 }
 
 func NewFoundingRangePostgresStorage(p *pgxpool.Pool) adapters.RangeStorage {
@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/pkg/postgres"
+)
+
+const notificationPreferencesColumns = `np_user, np_email, np_language, np_days_before, np_enabled`
+
+type postgresNotificationPreferencesStorage struct {
+	pool *pgxpool.Pool
+}
+
+func scanNotificationPreferences(row pgx.Row) (models.NotificationPreferences, error) {
+	var p models.NotificationPreferences
+	err := row.Scan(&p.User, &p.Email, &p.Language, &p.DaysBefore, &p.Enabled)
+	return p, err
+}
+
+func (s postgresNotificationPreferencesStorage) Get(ctx context.Context, user uuid.UUID) (models.NotificationPreferences, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	query := "SELECT " + notificationPreferencesColumns + " FROM notification_preferences WHERE np_user = $1"
+
+	p, err := scanNotificationPreferences(dataSource.QueryRow(ctx, query, user))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.NotificationPreferences{}, adapters.ErrNotFound
+		}
+		log.Println(err)
+		return models.NotificationPreferences{}, errors.New("failed to read data from database")
+	}
+	return p, nil
+}
+
+func (s postgresNotificationPreferencesStorage) GetByUsers(ctx context.Context, users []uuid.UUID) (map[uuid.UUID]models.NotificationPreferences, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	query := "SELECT " + notificationPreferencesColumns + " FROM notification_preferences WHERE np_user = ANY($1)"
+
+	rows, err := dataSource.Query(ctx, query, users)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read data from database")
+	}
+	defer rows.Close()
+
+	result := make(map[uuid.UUID]models.NotificationPreferences)
+	for rows.Next() {
+		p, err := scanNotificationPreferences(rows)
+		if err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to read data from database")
+		}
+		result[p.User] = p
+	}
+	return result, nil
+}
+
+func (s postgresNotificationPreferencesStorage) Save(ctx context.Context, p models.NotificationPreferences) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := "INSERT INTO notification_preferences(" + notificationPreferencesColumns + `) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (np_user) DO UPDATE SET np_email = $2, np_language = $3, np_days_before = $4, np_enabled = $5`
+	if _, err := dataSource.Exec(ctx, command, p.User, p.Email, p.Language, p.DaysBefore, p.Enabled); err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	return nil
+}
+
+func NewPostgresNotificationPreferencesStorage(p *pgxpool.Pool) adapters.NotificationPreferencesStorage {
+	return &postgresNotificationPreferencesStorage{
+		pool: p,
+	}
+}
+
+type postgresReminderDeliveryStorage struct {
+	pool *pgxpool.Pool
+}
+
+func (s postgresReminderDeliveryStorage) Add(ctx context.Context, d models.ReminderDelivery) (bool, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := `INSERT INTO reminder_delivery(rd_user, rd_event, rd_days_before, rd_sent_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING`
+	tag, err := dataSource.Exec(ctx, command, d.User, d.Event, d.DaysBefore, d.SentAt)
+	if err != nil {
+		log.Println(err)
+		return false, errors.New("failed to write in the database")
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (s postgresReminderDeliveryStorage) Remove(ctx context.Context, user, event uuid.UUID, daysBefore int) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := "DELETE FROM reminder_delivery WHERE rd_user = $1 AND rd_event = $2 AND rd_days_before = $3"
+	if _, err := dataSource.Exec(ctx, command, user, event, daysBefore); err != nil {
+		log.Println(err)
+		return errors.New("failed to delete from database")
+	}
+	return nil
+}
+
+func NewPostgresReminderDeliveryStorage(p *pgxpool.Pool) adapters.ReminderDeliveryStorage {
+	return &postgresReminderDeliveryStorage{
+		pool: p,
+	}
+}
@@ -76,6 +76,11 @@ func (s PostgresOrganizerStorage) BeginTransaction(ctx context.Context) (interfa
 	return s.pool.Begin(ctx)
 }
 
+func (s PostgresOrganizerStorage) CommitTransaction(ctx context.Context, transaction interface{}) error {
+	tx := transaction.(*pgxpool.Tx)
+	return tx.Commit(ctx)
+}
+
 func (s PostgresOrganizerStorage) CloseTransaction(ctx context.Context, transaction interface{}) error {
 	tx := transaction.(*pgxpool.Tx)
 	return tx.Rollback(ctx)
@@ -94,7 +99,7 @@ func (s PostgresOrganizerStorage) GetByID(ctx context.Context, id uuid.UUID) (mo
 
 	command := fmt.Sprintf("SELECT * FROM organizer WHERE organizer_id = '%s'", id.String())
 
-	err := dataSource.QueryRow(ctx, command).Scan(&organizer.ID, &organizer.Name, &organizer.Logo, &organizer.Level)
+	err := dataSource.QueryRow(ctx, command).Scan(&organizer.ID, &organizer.Name, &organizer.Logo, &organizer.Level, &organizer.Version)
 
 	if err != nil {
 		log.Println("Failed to read to database")
@@ -131,45 +136,93 @@ func (s PostgresOrganizerStorage) GetAll(ctx context.Context) ([]models.Organize
 		logo := values[2].(string)
 		byteLevel := values[3].([16]byte)
 		level, _ := uuid.FromBytes(byteLevel[:])
+		version := int(values[4].(int32))
 
-		organizers = append(organizers, models.Organizer{ID: id, Name: name, Logo: logo, Level: level})
+		organizers = append(organizers, models.Organizer{ID: id, Name: name, Logo: logo, Level: level, Version: version})
 	}
 
 	return organizers, nil
 }
 
+func (s PostgresOrganizerStorage) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Organizer, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	query := `SELECT organizer_id, organizer_name, organizer_image, organizer_level, organizer_version
+		FROM organizer WHERE organizer_id = ANY($1)`
+	rows, err := dataSource.Query(ctx, query, ids)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to query database")
+	}
+	defer rows.Close()
+
+	organizers := make([]models.Organizer, 0, len(ids))
+	for rows.Next() {
+		var o models.Organizer
+		if err := rows.Scan(&o.ID, &o.Name, &o.Logo, &o.Level, &o.Version); err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to read fetched values")
+		}
+		organizers = append(organizers, o)
+	}
+	return organizers, rows.Err()
+}
+
 func (s PostgresOrganizerStorage) Add(ctx context.Context, organizer models.Organizer) error {
 	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
 
-	command := "INSERT INTO organizer (organizer_id, organizer_name, organizer_image, organizer_level) VALUES ($1, $2, $3, $4)"
+	command := "INSERT INTO organizer (organizer_id, organizer_name, organizer_image, organizer_level, organizer_version) VALUES ($1, $2, $3, $4, $5)"
 
-	if _, err := dataSource.Exec(ctx, command, organizer.ID, organizer.Name, organizer.Logo, organizer.Level); err != nil {
+	if _, err := dataSource.Exec(ctx, command, organizer.ID, organizer.Name, organizer.Logo, organizer.Level, organizer.Version); err != nil {
 		log.Println(err)
 		return errors.New("failed to create organizer")
 	}
 	return nil
 }
 
-func (s PostgresOrganizerStorage) Remove(ctx context.Context, id uuid.UUID) error {
+func (s PostgresOrganizerStorage) Remove(ctx context.Context, id uuid.UUID, version int) error {
 	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
 
-	_, err := dataSource.Exec(ctx, "DELETE FROM organizer WHERE organizer_id=$1", id)
-	return err
+	tag, err := dataSource.Exec(ctx, "DELETE FROM organizer WHERE organizer_id=$1 AND organizer_version=$2", id, version)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return adapters.ErrStaleVersion
+	}
+	return nil
 }
 
 func (s PostgresOrganizerStorage) Update(ctx context.Context, organizer models.Organizer) error {
 	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
 
-	command := "UPDATE organizer SET organizer_name = $2, organizer_image = $3, organizer_level = $4 WHERE organizer_id = $1"
+	command := `UPDATE organizer SET organizer_name = $2, organizer_image = $3, organizer_level = $4, organizer_version = organizer_version + 1 
+                 WHERE organizer_id = $1 AND organizer_version = $5`
 
-	if _, err := dataSource.Exec(ctx, command, organizer.ID, organizer.Name, organizer.Logo, organizer.Level); err != nil {
+	tag, err := dataSource.Exec(ctx, command, organizer.ID, organizer.Name, organizer.Logo, organizer.Level, organizer.Version)
+	if err != nil {
 		log.Println(err)
 		return errors.New("failed to update")
 	}
 
+	if tag.RowsAffected() == 0 {
+		return adapters.ErrStaleVersion
+	}
 	return nil
 }
 
+func (s PostgresOrganizerStorage) Suggest(ctx context.Context, text string, limit int) ([]models.Suggestion, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	result, err := suggest(ctx, dataSource, "organizer", "organizer_id", "organizer_name", text, limit)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read database")
+	}
+	return result, nil
+}
+
 func (s PostgresOrganizerStorage) GetLevels(ctx context.Context) ([]models.OrganizerLevel, error) {
 	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
 
@@ -207,6 +260,30 @@ func (s PostgresOrganizerStorage) GetLevels(ctx context.Context) ([]models.Organ
 	return levels, nil
 }
 
+func (s PostgresOrganizerStorage) GetLevelsByIDs(ctx context.Context, ids []uuid.UUID) ([]models.OrganizerLevel, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	query := `SELECT organizer_level_id, organizer_level_name, organizer_level_code
+		FROM organizer_level WHERE organizer_level_id = ANY($1)`
+	rows, err := dataSource.Query(ctx, query, ids)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read database")
+	}
+	defer rows.Close()
+
+	levels := make([]models.OrganizerLevel, 0, len(ids))
+	for rows.Next() {
+		var l models.OrganizerLevel
+		if err := rows.Scan(&l.ID, &l.Name, &l.Code); err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to read fetched values")
+		}
+		levels = append(levels, l)
+	}
+	return levels, rows.Err()
+}
+
 func (s PostgresOrganizerStorage) AddLevel(ctx context.Context, level models.OrganizerLevel) error {
 	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
 
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/pkg/postgres"
+)
+
+type postgresOutboxStorage struct {
+	pool *pgxpool.Pool
+}
+
+func (s postgresOutboxStorage) Add(ctx context.Context, event models.DomainEvent) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := `INSERT INTO outbox(outbox_id, outbox_type, outbox_aggregate_id, outbox_payload, outbox_occurred_at)
+		VALUES ($1, $2, $3, $4, $5)`
+	_, err := dataSource.Exec(ctx, command, event.ID, event.Type, event.AggregateID, event.Payload, event.OccurredAt)
+	if err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	return nil
+}
+
+func (s postgresOutboxStorage) GetUnpublished(ctx context.Context, publisher string, limit int) ([]models.DomainEvent, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	query := `SELECT outbox_id, outbox_type, outbox_aggregate_id, outbox_payload, outbox_occurred_at FROM outbox
+		WHERE outbox_published_at IS NULL
+		  AND NOT EXISTS(SELECT 1 FROM outbox_delivery WHERE od_event = outbox_id AND od_publisher = $1)
+		ORDER BY outbox_seq LIMIT $2`
+	rows, err := dataSource.Query(ctx, query, publisher, limit)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read data from database")
+	}
+	defer rows.Close()
+
+	result := make([]models.DomainEvent, 0)
+	for rows.Next() {
+		var e models.DomainEvent
+		if err := rows.Scan(&e.ID, &e.Type, &e.AggregateID, &e.Payload, &e.OccurredAt); err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to read data from database")
+		}
+		result = append(result, e)
+	}
+	return result, rows.Err()
+}
+
+func (s postgresOutboxStorage) MarkPublished(ctx context.Context, publisher string, id uuid.UUID) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := "INSERT INTO outbox_delivery(od_event, od_publisher) VALUES ($1, $2) ON CONFLICT DO NOTHING"
+	if _, err := dataSource.Exec(ctx, command, id, publisher); err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	return nil
+}
+
+func (s postgresOutboxStorage) MarkPublishedToAll(ctx context.Context, publishers []string, at time.Time) (int, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := `UPDATE outbox SET outbox_published_at = $2
+		WHERE outbox_published_at IS NULL
+		  AND (SELECT count(*) FROM outbox_delivery WHERE od_event = outbox_id AND od_publisher = ANY($1)) = cardinality($1::text[])`
+	tag, err := dataSource.Exec(ctx, command, publishers, at)
+	if err != nil {
+		log.Println(err)
+		return 0, errors.New("failed to write in the database")
+	}
+
+	// the deliveries are needed only until the event is published to all publishers
+	_, err = dataSource.Exec(ctx, `DELETE FROM outbox_delivery
+		WHERE od_event IN (SELECT outbox_id FROM outbox WHERE outbox_published_at IS NOT NULL)`)
+	if err != nil {
+		log.Println(err)
+		return 0, errors.New("failed to write in the database")
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+func (s postgresOutboxStorage) RemovePublished(ctx context.Context, before time.Time) (int, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	tag, err := dataSource.Exec(ctx, "DELETE FROM outbox WHERE outbox_published_at < $1", before)
+	if err != nil {
+		log.Println(err)
+		return 0, errors.New("failed to write in the database")
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+func NewPostgresOutboxStorage(p *pgxpool.Pool) adapters.OutboxStorage {
+	return &postgresOutboxStorage{
+		pool: p,
+	}
+}
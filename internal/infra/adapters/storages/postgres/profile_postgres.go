@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/pkg/postgres"
+)
+
+type postgresProfileStorage struct {
+	pool *pgxpool.Pool
+}
+
+const profileColumns = `profile_id, profile_name, profile_category, profile_age, profile_degree, profile_region,
+	profile_organization_type, profile_trl, profile_subjects, profile_needed_funding, profile_co_funding_capacity`
+
+func scanProfile(row pgx.Row) (models.Profile, error) {
+	var p models.Profile
+	err := row.Scan(&p.ID, &p.Name,
+		&p.Applicant.Category, &p.Applicant.Age, &p.Applicant.Degree, &p.Applicant.Region, &p.Applicant.OrganizationType,
+		&p.TRL, &p.Subjects, &p.NeededFunding, &p.CoFundingCapacity)
+	if p.Subjects == nil {
+		p.Subjects = make([]uuid.UUID, 0)
+	}
+	return p, err
+}
+
+// profileValues - values of the profile in the order of profileColumns
+func profileValues(p models.Profile) []interface{} {
+	subjects := p.Subjects
+	if subjects == nil {
+		subjects = make([]uuid.UUID, 0)
+	}
+	return []interface{}{p.ID, p.Name,
+		p.Applicant.Category, p.Applicant.Age, p.Applicant.Degree, p.Applicant.Region, p.Applicant.OrganizationType,
+		p.TRL, subjects, p.NeededFunding, p.CoFundingCapacity}
+}
+
+func (s postgresProfileStorage) GetByID(ctx context.Context, id uuid.UUID) (models.Profile, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	profile, err := scanProfile(dataSource.QueryRow(ctx, "SELECT "+profileColumns+" FROM applicant_profile WHERE profile_id = $1", id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Profile{}, adapters.ErrNotFound
+		}
+		log.Println(err)
+		return models.Profile{}, errors.New("failed to read from database")
+	}
+	return profile, nil
+}
+
+func (s postgresProfileStorage) GetAll(ctx context.Context) ([]models.Profile, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	rows, err := dataSource.Query(ctx, "SELECT "+profileColumns+" FROM applicant_profile ORDER BY profile_name")
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read data from database")
+	}
+	defer rows.Close()
+
+	profiles := make([]models.Profile, 0)
+	for rows.Next() {
+		profile, err := scanProfile(rows)
+		if err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to read data from database")
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
+
+func (s postgresProfileStorage) Add(ctx context.Context, profile models.Profile) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := "INSERT INTO applicant_profile(" + profileColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)"
+	if _, err := dataSource.Exec(ctx, command, profileValues(profile)...); err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	return nil
+}
+
+func (s postgresProfileStorage) Update(ctx context.Context, profile models.Profile) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := `UPDATE applicant_profile
+		SET profile_name = $2, profile_category = $3, profile_age = $4, profile_degree = $5, profile_region = $6,
+		    profile_organization_type = $7, profile_trl = $8, profile_subjects = $9, profile_needed_funding = $10,
+		    profile_co_funding_capacity = $11
+		WHERE profile_id = $1`
+
+	tag, err := dataSource.Exec(ctx, command, profileValues(profile)...)
+	if err != nil {
+		log.Println(err)
+		return errors.New("failed to update the database")
+	}
+	if tag.RowsAffected() == 0 {
+		return adapters.ErrNotFound
+	}
+	return nil
+}
+
+func (s postgresProfileStorage) Delete(ctx context.Context, id uuid.UUID) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	if _, err := dataSource.Exec(ctx, "DELETE FROM applicant_profile WHERE profile_id = $1", id); err != nil {
+		log.Println(err)
+		return errors.New("failed to delete from database")
+	}
+	return nil
+}
+
+func NewPostgresProfileStorage(p *pgxpool.Pool) adapters.ProfileStorage {
+	return &postgresProfileStorage{
+		pool: p,
+	}
+}
@@ -0,0 +1,268 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/pkg/postgres"
+)
+
+const savedSearchColumns = `search_id, search_user, search_name, search_filter, search_delivery, search_frequency,
+	search_created_at, search_last_delivered_at`
+
+// eventFilter - representation of models.EventFilter in search_filter column
+type eventFilter struct {
+	Organizers  []uuid.UUID `json:"organizers,omitempty"`
+	Competitors []uuid.UUID `json:"competitors,omitempty"`
+	Subjects    []uuid.UUID `json:"subjects,omitempty"`
+	MinTRL      int         `json:"minTrl,omitempty"`
+	MaxTRL      int         `json:"maxTrl,omitempty"`
+	MinFunding  int         `json:"minFunding,omitempty"`
+	MaxFunding  int         `json:"maxFunding,omitempty"`
+}
+
+type postgresSavedSearchStorage struct {
+	pool *pgxpool.Pool
+}
+
+func scanSavedSearch(row pgx.Row) (models.SavedSearch, error) {
+	var search models.SavedSearch
+	var filter eventFilter
+	var lastDelivered *time.Time
+
+	err := row.Scan(&search.ID, &search.User, &search.Name, &filter, &search.Delivery, &search.Frequency,
+		&search.CreatedAt, &lastDelivered)
+	if err != nil {
+		return models.SavedSearch{}, err
+	}
+
+	search.Filter = models.EventFilter(filter)
+	if lastDelivered != nil {
+		search.LastDeliveredAt = *lastDelivered
+	}
+	return search, nil
+}
+
+func (s postgresSavedSearchStorage) getMany(ctx context.Context, query string, args ...interface{}) ([]models.SavedSearch, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	rows, err := dataSource.Query(ctx, query, args...)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read data from database")
+	}
+	defer rows.Close()
+
+	result := make([]models.SavedSearch, 0)
+	for rows.Next() {
+		search, err := scanSavedSearch(rows)
+		if err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to read data from database")
+		}
+		result = append(result, search)
+	}
+	return result, nil
+}
+
+func (s postgresSavedSearchStorage) GetAll(ctx context.Context) ([]models.SavedSearch, error) {
+	return s.getMany(ctx, "SELECT "+savedSearchColumns+" FROM saved_search")
+}
+
+func (s postgresSavedSearchStorage) GetByUser(ctx context.Context, user uuid.UUID) ([]models.SavedSearch, error) {
+	return s.getMany(ctx, "SELECT "+savedSearchColumns+" FROM saved_search WHERE search_user = $1 ORDER BY search_created_at", user)
+}
+
+func (s postgresSavedSearchStorage) GetByID(ctx context.Context, id uuid.UUID) (models.SavedSearch, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	query := "SELECT " + savedSearchColumns + " FROM saved_search WHERE search_id = $1"
+
+	search, err := scanSavedSearch(dataSource.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.SavedSearch{}, adapters.ErrNotFound
+		}
+		log.Println(err)
+		return models.SavedSearch{}, errors.New("failed to read data from database")
+	}
+	return search, nil
+}
+
+func (s postgresSavedSearchStorage) Add(ctx context.Context, search models.SavedSearch) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := "INSERT INTO saved_search(" + savedSearchColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7, NULL)"
+	_, err := dataSource.Exec(ctx, command, search.ID, search.User, search.Name, eventFilter(search.Filter),
+		search.Delivery, search.Frequency, search.CreatedAt)
+	if err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	return nil
+}
+
+func (s postgresSavedSearchStorage) Update(ctx context.Context, search models.SavedSearch) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := `UPDATE saved_search SET search_name = $2, search_filter = $3, search_delivery = $4, search_frequency = $5
+		WHERE search_id = $1`
+	tag, err := dataSource.Exec(ctx, command, search.ID, search.Name, eventFilter(search.Filter), search.Delivery, search.Frequency)
+	if err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	if tag.RowsAffected() == 0 {
+		return adapters.ErrNotFound
+	}
+	return nil
+}
+
+func (s postgresSavedSearchStorage) Delete(ctx context.Context, id uuid.UUID) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	if _, err := dataSource.Exec(ctx, "DELETE FROM saved_search WHERE search_id = $1", id); err != nil {
+		log.Println(err)
+		return errors.New("failed to delete from database")
+	}
+	return nil
+}
+
+func (s postgresSavedSearchStorage) SetDelivered(ctx context.Context, id uuid.UUID, at time.Time) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := "UPDATE saved_search SET search_last_delivered_at = $2 WHERE search_id = $1"
+	if _, err := dataSource.Exec(ctx, command, id, at); err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	return nil
+}
+
+func NewPostgresSavedSearchStorage(p *pgxpool.Pool) adapters.SavedSearchStorage {
+	return &postgresSavedSearchStorage{
+		pool: p,
+	}
+}
+
+const searchAlertColumns = `alert_id, alert_search, alert_event, alert_title, alert_reason,
+	alert_created_at, alert_delivered_at, alert_read_at`
+
+type postgresSearchAlertStorage struct {
+	pool *pgxpool.Pool
+}
+
+func (s postgresSearchAlertStorage) getMany(ctx context.Context, query string, args ...interface{}) ([]models.SearchAlert, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	rows, err := dataSource.Query(ctx, query, args...)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read data from database")
+	}
+	defer rows.Close()
+
+	result := make([]models.SearchAlert, 0)
+	for rows.Next() {
+		var alert models.SearchAlert
+		var delivered, read *time.Time
+		err := rows.Scan(&alert.ID, &alert.Search, &alert.Event, &alert.Title, &alert.Reason,
+			&alert.CreatedAt, &delivered, &read)
+		if err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to read data from database")
+		}
+		if delivered != nil {
+			alert.DeliveredAt = *delivered
+		}
+		if read != nil {
+			alert.ReadAt = *read
+		}
+		result = append(result, alert)
+	}
+	return result, nil
+}
+
+func (s postgresSearchAlertStorage) Add(ctx context.Context, alerts []models.SearchAlert) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(alerts))
+	searches := make([]uuid.UUID, len(alerts))
+	events := make([]uuid.UUID, len(alerts))
+	titles := make([]string, len(alerts))
+	reasons := make([]string, len(alerts))
+	created := make([]time.Time, len(alerts))
+	for i, a := range alerts {
+		ids[i], searches[i], events[i], titles[i], reasons[i], created[i] = a.ID, a.Search, a.Event, a.Title, a.Reason, a.CreatedAt
+	}
+
+	command := `INSERT INTO search_alert(alert_id, alert_search, alert_event, alert_title, alert_reason, alert_created_at)
+		SELECT * FROM unnest($1::UUID[], $2::UUID[], $3::UUID[], $4::VARCHAR[], $5::VARCHAR[], $6::TIMESTAMPTZ[])
+		ON CONFLICT (alert_search, alert_event) DO NOTHING`
+	if _, err := dataSource.Exec(ctx, command, ids, searches, events, titles, reasons, created); err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	return nil
+}
+
+func (s postgresSearchAlertStorage) GetPending(ctx context.Context, search uuid.UUID) ([]models.SearchAlert, error) {
+	query := "SELECT " + searchAlertColumns + ` FROM search_alert
+		WHERE alert_search = $1 AND alert_delivered_at IS NULL ORDER BY alert_created_at`
+	return s.getMany(ctx, query, search)
+}
+
+func (s postgresSearchAlertStorage) MarkDelivered(ctx context.Context, ids []uuid.UUID, at time.Time) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := "UPDATE search_alert SET alert_delivered_at = $2 WHERE alert_id = ANY($1)"
+	if _, err := dataSource.Exec(ctx, command, ids, at); err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	return nil
+}
+
+func (s postgresSearchAlertStorage) GetDelivered(ctx context.Context, user uuid.UUID, unreadOnly bool) ([]models.SearchAlert, error) {
+	query := "SELECT " + searchAlertColumns + ` FROM search_alert
+		JOIN saved_search ON search_id = alert_search
+		WHERE search_user = $1 AND search_delivery = $2 AND alert_delivered_at IS NOT NULL
+			AND (NOT $3 OR alert_read_at IS NULL)
+		ORDER BY alert_delivered_at DESC, alert_created_at DESC`
+	return s.getMany(ctx, query, user, models.AlertDeliveryInApp, unreadOnly)
+}
+
+func (s postgresSearchAlertStorage) MarkRead(ctx context.Context, user uuid.UUID, ids []uuid.UUID, at time.Time) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := `UPDATE search_alert SET alert_read_at = $3
+		FROM saved_search
+		WHERE search_id = alert_search AND search_user = $1 AND alert_delivered_at IS NOT NULL AND alert_read_at IS NULL
+			AND (cardinality($2::UUID[]) = 0 OR alert_id = ANY($2))`
+	if ids == nil {
+		ids = make([]uuid.UUID, 0)
+	}
+	if _, err := dataSource.Exec(ctx, command, user, ids, at); err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	return nil
+}
+
+func NewPostgresSearchAlertStorage(p *pgxpool.Pool) adapters.SearchAlertStorage {
+	return &postgresSearchAlertStorage{
+		pool: p,
+	}
+}
@@ -2,8 +2,11 @@ package postgres
 
 import (
 	"context"
+	"errors"
+	"log"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/indigowar/map-of-events/internal/domain/adapters"
@@ -15,23 +18,50 @@ type sessionStorage struct {
 }
 
 func (s sessionStorage) GetByToken(ctx context.Context, token string) (models.TokenSession, error) {
-	//TODO implement me
-	panic("implement me")
+	session := models.TokenSession{Token: token}
+
+	err := s.pool.QueryRow(ctx, "SELECT owner FROM sessions WHERE token = $1", token).Scan(&session.User)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.TokenSession{}, adapters.ErrNotFound
+		}
+		log.Println(err)
+		return models.TokenSession{}, errors.New("failed to read session")
+	}
+	return session, nil
 }
 
 func (s sessionStorage) GetByUser(ctx context.Context, id uuid.UUID) (models.TokenSession, error) {
-	//TODO implement me
-	panic("implement me")
+	session := models.TokenSession{User: id}
+
+	err := s.pool.QueryRow(ctx, "SELECT token FROM sessions WHERE owner = $1", id).Scan(&session.Token)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.TokenSession{}, adapters.ErrNotFound
+		}
+		log.Println(err)
+		return models.TokenSession{}, errors.New("failed to read session")
+	}
+	return session, nil
 }
 
 func (s sessionStorage) Create(ctx context.Context, session models.TokenSession) error {
-	//TODO implement me
-	panic("implement me")
+	// a user has only one session, so the new one replaces the previous
+	_, err := s.pool.Exec(ctx, `INSERT INTO sessions(token, owner) VALUES ($1, $2)
+ON CONFLICT (owner) DO UPDATE SET token = excluded.token`, session.Token, session.User)
+	if err != nil {
+		log.Println(err)
+		return errors.New("failed to add session")
+	}
+	return nil
 }
 
 func (s sessionStorage) Delete(ctx context.Context, token string) error {
-	//TODO implement me
-	panic("implement me")
+	if _, err := s.pool.Exec(ctx, "DELETE FROM sessions WHERE token = $1", token); err != nil {
+		log.Println(err)
+		return errors.New("failed to delete session")
+	}
+	return nil
 }
 
 func NewSessionStorage(p *pgxpool.Pool) adapters.SessionStorage {
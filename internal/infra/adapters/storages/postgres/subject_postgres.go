@@ -3,10 +3,11 @@ package postgres
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/indigowar/map-of-events/internal/domain/adapters"
@@ -22,21 +23,97 @@ func (s postgresSubjectStorage) BeginTransaction(ctx context.Context) (interface
 	return s.pool.Begin(ctx)
 }
 
+func (s postgresSubjectStorage) CommitTransaction(ctx context.Context, transaction interface{}) error {
+	tx := transaction.(*pgxpool.Tx)
+	return tx.Commit(ctx)
+}
+
 func (s postgresSubjectStorage) CloseTransaction(ctx context.Context, transaction interface{}) error {
 	tx := transaction.(*pgxpool.Tx)
 	return tx.Rollback(ctx)
 }
 
+const subjectColumns = "subject_id, subject_name, subject_parent, subject_synonyms, subject_codes"
+
+// subjectCode - representation of models.SubjectCode in subject_codes column
+type subjectCode struct {
+	Scheme string `json:"scheme"`
+	Code   string `json:"code"`
+}
+
+func scanSubject(row pgx.Row) (models.Subject, error) {
+	var subject models.Subject
+	var parent *uuid.UUID
+	var codes []subjectCode
+
+	if err := row.Scan(&subject.ID, &subject.Name, &parent, &subject.Synonyms, &codes); err != nil {
+		return models.Subject{}, err
+	}
+
+	if parent != nil {
+		subject.Parent = *parent
+	}
+	if subject.Synonyms == nil {
+		subject.Synonyms = make([]string, 0)
+	}
+	subject.Codes = make([]models.SubjectCode, len(codes))
+	for i, c := range codes {
+		subject.Codes[i] = models.SubjectCode{Scheme: c.Scheme, Code: c.Code}
+	}
+	return subject, nil
+}
+
+// subjectValues - returns values of subject's columns except the id in the order of subjectColumns
+func subjectValues(subject models.Subject) (interface{}, []string, []subjectCode) {
+	var parent interface{}
+	if subject.Parent != uuid.Nil {
+		parent = subject.Parent
+	}
+
+	synonyms := subject.Synonyms
+	if synonyms == nil {
+		synonyms = make([]string, 0)
+	}
+
+	codes := make([]subjectCode, len(subject.Codes))
+	for i, c := range subject.Codes {
+		codes[i] = subjectCode{Scheme: c.Scheme, Code: c.Code}
+	}
+	return parent, synonyms, codes
+}
+
 func (s postgresSubjectStorage) GetByID(ctx context.Context, id uuid.UUID) (models.Subject, error) {
 	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
 
-	query := fmt.Sprintf("SELECT * FROM subject WHERE subject_id = '%s'", id.String())
+	query := "SELECT " + subjectColumns + " FROM subject WHERE subject_id = $1"
 
-	subject := models.Subject{
-		ID: id,
+	subject, err := scanSubject(dataSource.QueryRow(ctx, query, id))
+	if err != nil {
+		log.Println(err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Subject{}, adapters.ErrNotFound
+		}
+		return models.Subject{}, errors.New("failed to read from database")
 	}
 
-	if err := dataSource.QueryRow(ctx, query).Scan(&subject.ID, &subject.Name, &subject.EventID); err != nil {
+	return subject, nil
+}
+
+func (s postgresSubjectStorage) GetByName(ctx context.Context, name string) (models.Subject, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	// a subject with the same name is preferred to a subject with the same synonym
+	query := "SELECT " + subjectColumns + ` FROM subject
+		WHERE lower(subject_name) = lower($1)
+		   OR lower($1) = ANY (SELECT lower(synonym) FROM unnest(subject_synonyms) AS synonym)
+		ORDER BY lower(subject_name) = lower($1) DESC
+		LIMIT 1`
+
+	subject, err := scanSubject(dataSource.QueryRow(ctx, query, strings.TrimSpace(name)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Subject{}, adapters.ErrNotFound
+		}
 		log.Println(err)
 		return models.Subject{}, errors.New("failed to read from database")
 	}
@@ -47,31 +124,52 @@ func (s postgresSubjectStorage) GetByID(ctx context.Context, id uuid.UUID) (mode
 func (s postgresSubjectStorage) GetByEvent(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
 	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
 
-	query := fmt.Sprintf("SELECT subject_id FROM subject WHERE subject_event = '%s'", id.String())
-
-	rows, err := dataSource.Query(ctx, query)
+	rows, err := dataSource.Query(ctx, "SELECT subject_id FROM event_subject WHERE event_id = $1", id)
 	if err != nil {
 		log.Println(err)
 		return nil, errors.New("failed to read data from database")
 	}
+	defer rows.Close()
 
 	result := make([]uuid.UUID, 0)
 
 	for rows.Next() {
-		values, err := rows.Values()
-		if err != nil {
+		var subject uuid.UUID
+		if err := rows.Scan(&subject); err != nil {
 			log.Println(err)
 			return nil, errors.New("failed to read data from database")
 		}
+		result = append(result, subject)
+	}
 
-		byteId := values[0].([16]byte)
-		id, err := uuid.FromBytes(byteId[:])
+	return result, nil
+}
+
+func (s postgresSubjectStorage) GetByEvents(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]models.Subject, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	query := "SELECT es.event_id, " + subjectColumns + ` FROM event_subject es
+		JOIN subject USING (subject_id)
+		WHERE es.event_id = ANY($1)
+		ORDER BY subject_name`
+
+	rows, err := dataSource.Query(ctx, query, ids)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read data from database")
+	}
+	defer rows.Close()
+
+	result := make(map[uuid.UUID][]models.Subject)
+
+	for rows.Next() {
+		var eventId uuid.UUID
+		subject, err := scanSubject(prefixedRow{rows: rows, prefix: []interface{}{&eventId}})
 		if err != nil {
 			log.Println(err)
 			return nil, errors.New("failed to read data from database")
 		}
-
-		result = append(result, id)
+		result[eventId] = append(result[eventId], subject)
 	}
 
 	return result, nil
@@ -80,39 +178,56 @@ func (s postgresSubjectStorage) GetByEvent(ctx context.Context, id uuid.UUID) ([
 func (s postgresSubjectStorage) GetAll(ctx context.Context) ([]models.Subject, error) {
 	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
 
-	rows, err := dataSource.Query(ctx, "SELECT subject_id, subject_name FROM subject")
+	rows, err := dataSource.Query(ctx, "SELECT "+subjectColumns+" FROM subject ORDER BY subject_name")
 	if err != nil {
 		log.Println(err)
 		return nil, errors.New("failed to read data")
 	}
+	defer rows.Close()
 
 	subjects := make([]models.Subject, 0)
 
 	for rows.Next() {
-		values, err := rows.Values()
+		subject, err := scanSubject(rows)
 		if err != nil {
 			log.Println(err)
-			return nil, errors.New("failed to data")
+			return nil, errors.New("failed to read data")
 		}
-		parsedId := values[0].([16]byte)
-		id, err := uuid.FromBytes(parsedId[:])
+		subjects = append(subjects, subject)
+	}
+
+	return subjects, nil
+}
+
+func (s postgresSubjectStorage) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Subject, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	rows, err := dataSource.Query(ctx, "SELECT "+subjectColumns+" FROM subject WHERE subject_id = ANY($1)", ids)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read data")
+	}
+	defer rows.Close()
+
+	subjects := make([]models.Subject, 0, len(ids))
+	for rows.Next() {
+		subject, err := scanSubject(rows)
 		if err != nil {
 			log.Println(err)
-			continue
+			return nil, errors.New("failed to read data")
 		}
-		name := values[1].(string)
-
-		subjects = append(subjects, models.Subject{ID: id, Name: name})
+		subjects = append(subjects, subject)
 	}
-
-	return subjects, nil
+	return subjects, rows.Err()
 }
 
 func (s postgresSubjectStorage) Add(ctx context.Context, subject models.Subject) error {
 	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
 
-	command := "INSERT INTO subject(subject_id, subject_name, subject_event) VALUES ($1, $2, $3)"
-	_, err := dataSource.Exec(ctx, command, subject.ID, subject.Name, subject.EventID)
+	parent, synonyms, codes := subjectValues(subject)
+
+	command := "INSERT INTO subject(" + subjectColumns + ") VALUES ($1, $2, $3, $4, $5)"
+	_, err := dataSource.Exec(ctx, command, subject.ID, subject.Name, parent, synonyms, codes)
 	if err != nil {
 		log.Println(err)
 		return errors.New("failed to write in the database")
@@ -123,7 +238,7 @@ func (s postgresSubjectStorage) Add(ctx context.Context, subject models.Subject)
 func (s postgresSubjectStorage) Delete(ctx context.Context, id uuid.UUID) error {
 	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
 
-	command := "DELETE FROM subject WHERE subject_name = $1"
+	command := "DELETE FROM subject WHERE subject_id = $1"
 
 	if _, err := dataSource.Exec(ctx, command, id); err != nil {
 		log.Println(err)
@@ -135,15 +250,68 @@ func (s postgresSubjectStorage) Delete(ctx context.Context, id uuid.UUID) error
 func (s postgresSubjectStorage) Update(ctx context.Context, subject models.Subject) error {
 	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
 
-	command := "UPDATE subject SET subject_name = $1, subject_event = $2 WHERE subject_id = $3"
+	parent, synonyms, codes := subjectValues(subject)
+
+	command := `UPDATE subject
+		SET subject_name = $2, subject_parent = $3, subject_synonyms = $4, subject_codes = $5
+		WHERE subject_id = $1`
 
-	if _, err := dataSource.Exec(ctx, command, subject.Name, subject.EventID, subject.ID); err != nil {
+	tag, err := dataSource.Exec(ctx, command, subject.ID, subject.Name, parent, synonyms, codes)
+	if err != nil {
 		log.Println(err)
 		return errors.New("failed to update the database")
 	}
+	if tag.RowsAffected() == 0 {
+		return adapters.ErrNotFound
+	}
 	return nil
 }
 
+func (s postgresSubjectStorage) SetForEvent(ctx context.Context, eventId uuid.UUID, subjects []uuid.UUID) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	if _, err := dataSource.Exec(ctx, "DELETE FROM event_subject WHERE event_id = $1", eventId); err != nil {
+		log.Println(err)
+		return errors.New("failed to delete from database")
+	}
+
+	if len(subjects) == 0 {
+		return nil
+	}
+
+	command := `INSERT INTO event_subject(event_id, subject_id)
+		SELECT $1, subject_id FROM unnest($2::uuid[]) AS subject_id
+		ON CONFLICT DO NOTHING`
+
+	if _, err := dataSource.Exec(ctx, command, eventId, subjects); err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	return nil
+}
+
+// prefixedRow - scans first columns of the row into prefix and passes the rest to the Scan,
+// it allows to reuse scanSubject for the joined rows.
+type prefixedRow struct {
+	rows   pgx.Rows
+	prefix []interface{}
+}
+
+func (r prefixedRow) Scan(dest ...interface{}) error {
+	return r.rows.Scan(append(r.prefix, dest...)...)
+}
+
+func (s postgresSubjectStorage) Suggest(ctx context.Context, text string, limit int) ([]models.Suggestion, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	result, err := suggest(ctx, dataSource, "subject", "subject_id", "subject_name", text, limit)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read data from database")
+	}
+	return result, nil
+}
+
 func NewPostgresSubjectStorage(p *pgxpool.Pool) adapters.SubjectStorage {
 	return &postgresSubjectStorage{
 		pool: p,
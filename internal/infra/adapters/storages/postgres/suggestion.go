@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/pkg/postgres"
+)
+
+/*
+
+This file contains a query for suggestions of existing entities by their names(pg_trgm is required).
+
+The names starting with the typed text are ranked first, the other ones are ranked by
+word similarity, so "Минобрнауки" suggests "Минобрнауки России" and tolerates typos.
+The prefix is matched literally, "%" and "_" of the text are escaped for LIKE.
+
+*/
+
+const suggestionQueryTemplate = `
+SELECT DISTINCT ON (lower(%[3]s)) %[2]s, %[3]s,
+       CASE WHEN lower(%[3]s) LIKE lower($3) || '%%' ESCAPE '\' THEN 1.0::real
+            ELSE word_similarity(lower($1), lower(%[3]s)) END AS score
+FROM %[1]s
+WHERE lower(%[3]s) LIKE lower($3) || '%%' ESCAPE '\'
+   OR lower($1) <%% lower(%[3]s)
+ORDER BY lower(%[3]s), score DESC`
+
+// likeEscaper - escapes the wildcards of LIKE with the backslash
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// suggest - returns entities of the table, whose names are similar to the text
+func suggest(ctx context.Context, dataSource postgres.Connection, table, idColumn, nameColumn, text string, limit int) ([]models.Suggestion, error) {
+	query := fmt.Sprintf("SELECT * FROM (%s) AS s ORDER BY score DESC, 2 LIMIT $2",
+		fmt.Sprintf(suggestionQueryTemplate, table, idColumn, nameColumn))
+
+	rows, err := dataSource.Query(ctx, query, text, limit, likeEscaper.Replace(text))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]models.Suggestion, 0)
+
+	for rows.Next() {
+		var s models.Suggestion
+		var score float32
+		if err := rows.Scan(&s.ID, &s.Name, &score); err != nil {
+			return nil, err
+		}
+		s.Score = float64(score)
+		result = append(result, s)
+	}
+
+	return result, rows.Err()
+}
@@ -3,43 +3,53 @@ package postgres
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/indigowar/map-of-events/internal/domain/adapters"
 	"github.com/indigowar/map-of-events/internal/domain/models"
 )
 
+// uniqueViolation - the code of the error of postgres, when a unique constraint is violated
+const uniqueViolation = "23505"
+
 type userStorage struct {
 	pool *pgxpool.Pool
 }
 
 func (storage userStorage) GetByID(ctx context.Context, id uuid.UUID) (models.User, error) {
-	query := fmt.Sprintf("SELECT * FROM authenticatedusers WHERE id = '%s'", id.String())
+	query := "SELECT id, name, password FROM authenticatedusers WHERE id = $1"
 
 	var user models.User
 
-	err := storage.pool.QueryRow(ctx, query).Scan(&user.ID, &user.Name, &user.Password)
+	err := storage.pool.QueryRow(ctx, query, id).Scan(&user.ID, &user.Name, &user.Password)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.User{}, adapters.ErrNotFound
+		}
 		log.Println(err)
-		return models.User{}, errors.New("user was not found")
+		return models.User{}, errors.New("failed to read user")
 	}
 
 	return user, nil
 }
 
 func (storage userStorage) GetByName(ctx context.Context, name string) (models.User, error) {
-	query := fmt.Sprintf("SELECT * FROM authenticatedusers WHERE name= '%s'", name)
+	query := "SELECT id, name, password FROM authenticatedusers WHERE name = $1"
 
 	var user models.User
 
-	err := storage.pool.QueryRow(ctx, query).Scan(&user.ID, &user.Name, &user.Password)
+	err := storage.pool.QueryRow(ctx, query, name).Scan(&user.ID, &user.Name, &user.Password)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.User{}, adapters.ErrNotFound
+		}
 		log.Println(err)
-		return models.User{}, errors.New("user was not found")
+		return models.User{}, errors.New("failed to read user")
 	}
 	return user, nil
 }
@@ -49,6 +59,11 @@ func (storage userStorage) Create(ctx context.Context, user models.User) error {
 
 	_, err := storage.pool.Exec(ctx, command, user.ID, user.Name, user.Password)
 	if err != nil {
+		// the name is taken by a concurrent sign-up after it was checked
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return adapters.ErrAlreadyExists
+		}
 		log.Println(err)
 		return errors.New("failed to add user")
 	}
@@ -0,0 +1,273 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/pkg/postgres"
+)
+
+const webhookColumns = "webhook_id, webhook_url, webhook_secret, webhook_types, webhook_active, webhook_created_at"
+
+type postgresWebhookStorage struct {
+	pool *pgxpool.Pool
+}
+
+func scanWebhook(row pgx.Row) (models.Webhook, error) {
+	var w models.Webhook
+	err := row.Scan(&w.ID, &w.URL, &w.Secret, &w.Types, &w.Active, &w.CreatedAt)
+	return w, err
+}
+
+func (s postgresWebhookStorage) getMany(ctx context.Context, query string, args ...interface{}) ([]models.Webhook, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	rows, err := dataSource.Query(ctx, query, args...)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read data from database")
+	}
+	defer rows.Close()
+
+	result := make([]models.Webhook, 0)
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to read data from database")
+		}
+		result = append(result, w)
+	}
+	return result, nil
+}
+
+func (s postgresWebhookStorage) GetAll(ctx context.Context) ([]models.Webhook, error) {
+	return s.getMany(ctx, "SELECT "+webhookColumns+" FROM webhook ORDER BY webhook_created_at")
+}
+
+func (s postgresWebhookStorage) GetByType(ctx context.Context, changeType string) ([]models.Webhook, error) {
+	return s.getMany(ctx, "SELECT "+webhookColumns+" FROM webhook WHERE webhook_active AND $1 = ANY(webhook_types)", changeType)
+}
+
+func (s postgresWebhookStorage) GetByID(ctx context.Context, id uuid.UUID) (models.Webhook, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	w, err := scanWebhook(dataSource.QueryRow(ctx, "SELECT "+webhookColumns+" FROM webhook WHERE webhook_id = $1", id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Webhook{}, adapters.ErrNotFound
+		}
+		log.Println(err)
+		return models.Webhook{}, errors.New("failed to read data from database")
+	}
+	return w, nil
+}
+
+func (s postgresWebhookStorage) Add(ctx context.Context, w models.Webhook) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := "INSERT INTO webhook(" + webhookColumns + ") VALUES ($1, $2, $3, $4, $5, $6)"
+	if _, err := dataSource.Exec(ctx, command, w.ID, w.URL, w.Secret, w.Types, w.Active, w.CreatedAt); err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	return nil
+}
+
+func (s postgresWebhookStorage) Update(ctx context.Context, w models.Webhook) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := `UPDATE webhook SET webhook_url = $2, webhook_secret = $3, webhook_types = $4, webhook_active = $5
+		WHERE webhook_id = $1`
+	tag, err := dataSource.Exec(ctx, command, w.ID, w.URL, w.Secret, w.Types, w.Active)
+	if err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	if tag.RowsAffected() == 0 {
+		return adapters.ErrNotFound
+	}
+	return nil
+}
+
+func (s postgresWebhookStorage) Delete(ctx context.Context, id uuid.UUID) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	if _, err := dataSource.Exec(ctx, "DELETE FROM webhook WHERE webhook_id = $1", id); err != nil {
+		log.Println(err)
+		return errors.New("failed to delete from database")
+	}
+	return nil
+}
+
+func NewPostgresWebhookStorage(p *pgxpool.Pool) adapters.WebhookStorage {
+	return &postgresWebhookStorage{
+		pool: p,
+	}
+}
+
+const webhookDeliveryColumns = `delivery_id, delivery_webhook, delivery_type, delivery_payload, delivery_status,
+	delivery_attempts, delivery_next_attempt_at, delivery_created_at, delivery_delivered_at`
+
+type postgresWebhookDeliveryStorage struct {
+	pool *pgxpool.Pool
+}
+
+func (s postgresWebhookDeliveryStorage) getMany(ctx context.Context, query string, args ...interface{}) ([]models.WebhookDelivery, error) {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	rows, err := dataSource.Query(ctx, query, args...)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read data from database")
+	}
+
+	deliveries := make([]models.WebhookDelivery, 0)
+	index := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var d models.WebhookDelivery
+		var delivered *time.Time
+		err := rows.Scan(&d.ID, &d.Webhook, &d.Type, &d.Payload, &d.Status,
+			&d.Attempts, &d.NextAttemptAt, &d.CreatedAt, &delivered)
+		if err != nil {
+			rows.Close()
+			log.Println(err)
+			return nil, errors.New("failed to read data from database")
+		}
+		if delivered != nil {
+			d.DeliveredAt = *delivered
+		}
+		d.History = make([]models.WebhookAttempt, 0)
+		index[d.ID] = len(deliveries)
+		deliveries = append(deliveries, d)
+	}
+	rows.Close()
+
+	if len(deliveries) == 0 {
+		return deliveries, nil
+	}
+
+	ids := make([]uuid.UUID, len(deliveries))
+	for i, v := range deliveries {
+		ids[i] = v.ID
+	}
+
+	query = `SELECT delivery_id, attempt_at, attempt_status_code, attempt_error, attempt_duration_ms
+		FROM webhook_attempt WHERE delivery_id = ANY($1) ORDER BY attempt_at`
+	rows, err = dataSource.Query(ctx, query, ids)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("failed to read data from database")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		var attempt models.WebhookAttempt
+		var milliseconds int64
+		if err := rows.Scan(&id, &attempt.At, &attempt.StatusCode, &attempt.Error, &milliseconds); err != nil {
+			log.Println(err)
+			return nil, errors.New("failed to read data from database")
+		}
+		attempt.Duration = time.Duration(milliseconds) * time.Millisecond
+		deliveries[index[id]].History = append(deliveries[index[id]].History, attempt)
+	}
+	return deliveries, nil
+}
+
+func (s postgresWebhookDeliveryStorage) Add(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := "INSERT INTO webhook_delivery(" + webhookDeliveryColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULL)
+		ON CONFLICT (delivery_id) DO NOTHING`
+	for _, d := range deliveries {
+		_, err := dataSource.Exec(ctx, command, d.ID, d.Webhook, d.Type, d.Payload, d.Status, d.Attempts, d.NextAttemptAt, d.CreatedAt)
+		if err != nil {
+			log.Println(err)
+			return errors.New("failed to write in the database")
+		}
+	}
+	return nil
+}
+
+func (s postgresWebhookDeliveryStorage) GetDue(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	query := "SELECT " + webhookDeliveryColumns + ` FROM webhook_delivery
+		WHERE delivery_status = $1 AND delivery_next_attempt_at <= $2
+		ORDER BY delivery_next_attempt_at LIMIT $3`
+	return s.getMany(ctx, query, models.WebhookDeliveryPending, now, limit)
+}
+
+func (s postgresWebhookDeliveryStorage) GetByID(ctx context.Context, id uuid.UUID) (models.WebhookDelivery, error) {
+	deliveries, err := s.getMany(ctx, "SELECT "+webhookDeliveryColumns+" FROM webhook_delivery WHERE delivery_id = $1", id)
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	if len(deliveries) == 0 {
+		return models.WebhookDelivery{}, adapters.ErrNotFound
+	}
+	return deliveries[0], nil
+}
+
+func (s postgresWebhookDeliveryStorage) GetByWebhook(ctx context.Context, webhook uuid.UUID, status string, limit int) ([]models.WebhookDelivery, error) {
+	query := "SELECT " + webhookDeliveryColumns + ` FROM webhook_delivery
+		WHERE delivery_webhook = $1 AND ($2 = '' OR delivery_status = $2)
+		ORDER BY delivery_created_at DESC LIMIT $3`
+	return s.getMany(ctx, query, webhook, status, limit)
+}
+
+func (s postgresWebhookDeliveryStorage) GetByStatus(ctx context.Context, status string, limit int) ([]models.WebhookDelivery, error) {
+	query := "SELECT " + webhookDeliveryColumns + ` FROM webhook_delivery
+		WHERE delivery_status = $1 ORDER BY delivery_created_at DESC LIMIT $2`
+	return s.getMany(ctx, query, status, limit)
+}
+
+func (s postgresWebhookDeliveryStorage) Record(ctx context.Context, d models.WebhookDelivery, attempt models.WebhookAttempt) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	var delivered interface{}
+	if !d.DeliveredAt.IsZero() {
+		delivered = d.DeliveredAt
+	}
+
+	command := `WITH updated AS (
+			UPDATE webhook_delivery SET delivery_status = $2, delivery_attempts = $3, delivery_next_attempt_at = $4,
+				delivery_delivered_at = $5
+			WHERE delivery_id = $1
+			RETURNING delivery_id
+		)
+		INSERT INTO webhook_attempt(delivery_id, attempt_at, attempt_status_code, attempt_error, attempt_duration_ms)
+		SELECT delivery_id, $6, $7, $8, $9 FROM updated`
+	_, err := dataSource.Exec(ctx, command, d.ID, d.Status, d.Attempts, d.NextAttemptAt, delivered,
+		attempt.At, attempt.StatusCode, attempt.Error, attempt.Duration.Milliseconds())
+	if err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	return nil
+}
+
+func (s postgresWebhookDeliveryStorage) Requeue(ctx context.Context, id uuid.UUID, at time.Time) error {
+	dataSource := postgres.GetConnectionFromContextOrDefault(ctx, s.pool)
+
+	command := `UPDATE webhook_delivery SET delivery_status = $2, delivery_attempts = 0, delivery_next_attempt_at = $3
+		WHERE delivery_id = $1`
+	if _, err := dataSource.Exec(ctx, command, id, models.WebhookDeliveryPending, at); err != nil {
+		log.Println(err)
+		return errors.New("failed to write in the database")
+	}
+	return nil
+}
+
+func NewPostgresWebhookDeliveryStorage(p *pgxpool.Pool) adapters.WebhookDeliveryStorage {
+	return &postgresWebhookDeliveryStorage{
+		pool: p,
+	}
+}
@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+)
+
+// maxDrainedBody - the part of the response body read to reuse the connection
+const maxDrainedBody = 64 << 10
+
+type httpSender struct {
+	client *http.Client
+}
+
+func (s httpSender) Post(ctx context.Context, url string, header http.Header, body []byte) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header = header.Clone()
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	_, _ = io.CopyN(io.Discard, response.Body, maxDrainedBody)
+	return response.StatusCode, nil
+}
+
+// NewHTTPSender - creates a sender posting the payloads with the timeout, redirects are not followed
+func NewHTTPSender(timeout time.Duration) adapters.WebhookSender {
+	return &httpSender{
+		client: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
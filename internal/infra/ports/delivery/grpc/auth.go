@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+type userKey struct{}
+
+// authenticate - authenticates the user by "authorization: Bearer <token>" metadata like the HTTP middleware,
+// calls without the metadata pass as anonymous, calls with an invalid token are rejected with UNAUTHENTICATED
+func authenticate(ctx context.Context, svc services.AuthService) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ctx, nil
+	}
+
+	header := values[0]
+	token := strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+	if !strings.HasPrefix(header, "Bearer ") || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization")
+	}
+
+	user, err := svc.Authenticate(ctx, token)
+	if err != nil {
+		if errors.Is(err, services.AuthErrTokenIsInvalid) {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		log.Println(err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	return context.WithValue(ctx, userKey{}, user), nil
+}
+
+// UnaryAuth - authenticates the unary calls
+func UnaryAuth(svc services.AuthService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, svc)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuth - authenticates the streaming calls
+func StreamAuth(svc services.AuthService) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), svc)
+		if err != nil {
+			return err
+		}
+		return handler(srv, authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// User - returns id of the authenticated user, false for anonymous calls
+func User(ctx context.Context) (uuid.UUID, bool) {
+	user, ok := ctx.Value(userKey{}).(uuid.UUID)
+	return user, ok
+}
@@ -0,0 +1,106 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	eventmapv1 "github.com/indigowar/map-of-events/pkg/api/eventmap/v1"
+)
+
+type competitorServer struct {
+	eventmapv1.UnimplementedCompetitorServiceServer
+	svc services.CompetitorService
+}
+
+func (s *competitorServer) ListCompetitors(ctx context.Context, _ *eventmapv1.ListCompetitorsRequest) (*eventmapv1.ListCompetitorsResponse, error) {
+	competitors, err := s.svc.GetAll(ctx)
+	if err != nil {
+		return nil, competitorError(err)
+	}
+
+	result := &eventmapv1.ListCompetitorsResponse{Competitors: make([]*eventmapv1.Competitor, len(competitors))}
+	for i, c := range competitors {
+		result.Competitors[i] = buildCompetitor(c)
+	}
+	return result, nil
+}
+
+func (s *competitorServer) GetCompetitor(ctx context.Context, req *eventmapv1.GetCompetitorRequest) (*eventmapv1.Competitor, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	competitor, cErr := s.svc.GetByID(ctx, id)
+	if cErr != nil {
+		return nil, competitorError(cErr)
+	}
+	return buildCompetitor(competitor), nil
+}
+
+func (s *competitorServer) CreateCompetitor(ctx context.Context, req *eventmapv1.CreateCompetitorRequest) (*eventmapv1.Competitor, error) {
+	info := competitorInfo(req.GetCompetitor())
+	if err := info.Validate(); err != nil {
+		return nil, statusError(err)
+	}
+
+	competitor, err := s.svc.Create(ctx, info)
+	if err != nil {
+		return nil, competitorError(err)
+	}
+	return buildCompetitor(competitor), nil
+}
+
+func (s *competitorServer) UpdateCompetitor(ctx context.Context, req *eventmapv1.UpdateCompetitorRequest) (*eventmapv1.Competitor, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	info := competitorInfo(req.GetCompetitor())
+	if err := info.Validate(); err != nil {
+		return nil, statusError(err)
+	}
+
+	competitor, cErr := s.svc.Update(ctx, id, info)
+	if cErr != nil {
+		return nil, competitorError(cErr)
+	}
+	return buildCompetitor(competitor), nil
+}
+
+func (s *competitorServer) DeleteCompetitor(ctx context.Context, req *eventmapv1.DeleteCompetitorRequest) (*eventmapv1.DeleteCompetitorResponse, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.svc.Delete(ctx, id); err != nil {
+		return nil, competitorError(err)
+	}
+	return &eventmapv1.DeleteCompetitorResponse{}, nil
+}
+
+func competitorInfo(i *eventmapv1.CompetitorInput) services.CompetitorInfo {
+	return services.CompetitorInfo{
+		Name:              i.GetName(),
+		Category:          i.GetCategory(),
+		MinAge:            int(i.GetMinAge()),
+		MaxAge:            int(i.GetMaxAge()),
+		MinDegree:         i.GetMinDegree(),
+		Regions:           i.GetRegions(),
+		OrganizationTypes: i.GetOrganizationTypes(),
+	}
+}
+
+func buildCompetitor(c models.Competitor) *eventmapv1.Competitor {
+	return &eventmapv1.Competitor{
+		Id:                c.ID.String(),
+		Name:              c.Name,
+		Category:          c.Category,
+		MinAge:            int32(c.MinAge),
+		MaxAge:            int32(c.MaxAge),
+		MinDegree:         c.MinDegree,
+		Regions:           c.Regions,
+		OrganizationTypes: c.OrganizationTypes,
+	}
+}
@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+	pkgerrors "github.com/indigowar/map-of-events/pkg/errors"
+)
+
+// statusError - converts an error of the services to the status for the clients, it matches the statuses of the REST API:
+// the violations are INVALID_ARGUMENT with BadRequest details, the version mismatch is FAILED_PRECONDITION,
+// internal errors are logged and hidden
+func statusError(err error) error {
+	var violations validators.Violations
+	switch {
+	case errors.As(err, &violations):
+		return violationsStatus(violations)
+	case errors.Is(err, services.ErrVersionMismatch), errors.Is(err, services.ErrSubjectHasChildren):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, adapters.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	log.Println(err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+func violationsStatus(violations validators.Violations) error {
+	details := &errdetails.BadRequest{FieldViolations: make([]*errdetails.BadRequest_FieldViolation, len(violations))}
+	for i, v := range violations {
+		details.FieldViolations[i] = &errdetails.BadRequest_FieldViolation{Field: v.Field, Description: v.Message}
+	}
+
+	s, err := status.New(codes.InvalidArgument, "validation failed").WithDetails(details)
+	if err != nil {
+		log.Println(err)
+		return status.Error(codes.InvalidArgument, violations.Error())
+	}
+	return s.Err()
+}
+
+// competitorError - converts an error of the competitor service by its reason
+func competitorError(err pkgerrors.Error) error {
+	switch err.Reason() {
+	case services.ErrReasonNotFound:
+		return status.Error(codes.NotFound, err.ShortErr())
+	case services.ErrReasonAlreadyExist:
+		return status.Error(codes.AlreadyExists, err.ShortErr())
+	case services.ErrReasonValidationFailed:
+		return status.Error(codes.InvalidArgument, err.ShortErr())
+	}
+	log.Println(err.LongErr())
+	return status.Error(codes.Internal, "internal error")
+}
+
+// parseID - parses an id given by the client, the invalid ones are INVALID_ARGUMENT
+func parseID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, violationsStatus(validators.Violations{{Field: field, Message: "is not a valid id"}})
+	}
+	return id, nil
+}
+
+// parseOptionalID - like parseID, but the empty value is uuid.Nil
+func parseOptionalID(field, value string) (uuid.UUID, error) {
+	if value == "" {
+		return uuid.Nil, nil
+	}
+	return parseID(field, value)
+}
+
+func parseIDs(field string, values []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, len(values))
+	for i, v := range values {
+		id, err := parseID(field, v)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+func formatIDs(ids []uuid.UUID) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = id.String()
+	}
+	return result
+}
@@ -0,0 +1,315 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	eventmapv1 "github.com/indigowar/map-of-events/pkg/api/eventmap/v1"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+type eventServer struct {
+	eventmapv1.UnimplementedEventServiceServer
+	svc services.Services
+}
+
+func (s *eventServer) GetEvent(ctx context.Context, req *eventmapv1.GetEventRequest) (*eventmapv1.Event, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return s.get(ctx, id)
+}
+
+func (s *eventServer) ListEvents(req *eventmapv1.ListEventsRequest, stream eventmapv1.EventService_ListEventsServer) error {
+	filter, err := parseEventFilter(req.GetFilter())
+	if err != nil {
+		return err
+	}
+	if err := services.ValidateEventSort(req.GetSort()); err != nil {
+		return statusError(err)
+	}
+
+	events, err := s.svc.Event.Find(stream.Context(), filter)
+	if err == nil && req.GetSort() != "" {
+		events, err = s.svc.Event.SortByFunding(stream.Context(), events, req.GetSort() == services.EventSortFundingDescending)
+	}
+	if err != nil {
+		return statusError(err)
+	}
+
+	messages, err := s.buildEvents(stream.Context(), events)
+	if err != nil {
+		return err
+	}
+	for _, m := range messages {
+		if err := stream.Send(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *eventServer) CreateEvent(ctx context.Context, req *eventmapv1.CreateEventRequest) (*eventmapv1.Event, error) {
+	info, err := parseEventInput(req.GetEvent())
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.svc.Event.Create(ctx, info)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return s.get(ctx, event.ID)
+}
+
+func (s *eventServer) UpdateEvent(ctx context.Context, req *eventmapv1.UpdateEventRequest) (*eventmapv1.Event, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	info, err := parseEventInput(req.GetEvent())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.svc.Event.Update(ctx, id, int(req.GetVersion()), info); err != nil {
+		return nil, statusError(err)
+	}
+	return s.get(ctx, id)
+}
+
+func (s *eventServer) DeleteEvent(ctx context.Context, req *eventmapv1.DeleteEventRequest) (*eventmapv1.DeleteEventResponse, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.svc.Event.Delete(ctx, id, int(req.GetVersion())); err != nil {
+		return nil, statusError(err)
+	}
+	return &eventmapv1.DeleteEventResponse{}, nil
+}
+
+func (s *eventServer) SearchEvents(ctx context.Context, req *eventmapv1.SearchEventsRequest) (*eventmapv1.SearchEventsResponse, error) {
+	if req.GetQuery() == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	hits, err := s.svc.Event.Search(ctx, req.GetQuery(), limit)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	result := &eventmapv1.SearchEventsResponse{Hits: make([]*eventmapv1.SearchHit, len(hits))}
+	for i, h := range hits {
+		result.Hits[i] = &eventmapv1.SearchHit{EventId: h.EventID.String(), Title: h.Title, Rank: h.Rank, Snippet: h.Snippet}
+	}
+	return result, nil
+}
+
+// WatchChanges - streams the changes like the SSE stream: the replayed changes(or the missed message if some are lost),
+// the ready message and the live changes until the subscription has ended
+func (s *eventServer) WatchChanges(req *eventmapv1.WatchChangesRequest, stream eventmapv1.EventService_WatchChangesServer) error {
+	filter, err := parseEventFilter(req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	subscription := s.svc.ChangeStream.Subscribe(stream.Context(), filter, req.GetLastSeq())
+	defer subscription.Cancel()
+
+	if subscription.Missed {
+		missed := &eventmapv1.WatchChangesResponse_Missed_{Missed: &eventmapv1.WatchChangesResponse_Missed{}}
+		if err := stream.Send(&eventmapv1.WatchChangesResponse{Seq: subscription.Last, Kind: missed}); err != nil {
+			return err
+		}
+	} else {
+		for _, change := range subscription.Replay {
+			if err := stream.Send(buildChange(change)); err != nil {
+				return err
+			}
+		}
+		ready := &eventmapv1.WatchChangesResponse_Ready_{Ready: &eventmapv1.WatchChangesResponse_Ready{}}
+		if err := stream.Send(&eventmapv1.WatchChangesResponse{Seq: subscription.Last, Kind: ready}); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case change, ok := <-subscription.Changes:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(buildChange(change)); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (s *eventServer) get(ctx context.Context, id uuid.UUID) (*eventmapv1.Event, error) {
+	events, err := s.svc.Event.GetByIDs(ctx, []uuid.UUID{id})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if len(events) == 0 {
+		return nil, status.Error(codes.NotFound, "event was not found")
+	}
+
+	messages, err := s.buildEvents(ctx, events)
+	if err != nil {
+		return nil, err
+	}
+	return messages[0], nil
+}
+
+// buildEvents - loads the subjects of the events with one query
+func (s *eventServer) buildEvents(ctx context.Context, events []models.Event) ([]*eventmapv1.Event, error) {
+	ids := make([]uuid.UUID, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+	}
+
+	subjects, err := s.svc.Subject.GetAllForEvents(ctx, ids)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	result := make([]*eventmapv1.Event, len(events))
+	for i, e := range events {
+		result[i] = buildEvent(e, subjects[e.ID])
+	}
+	return result, nil
+}
+
+func buildEvent(e models.Event, subs []models.Subject) *eventmapv1.Event {
+	f, cf := e.FoundingRange, e.CoFoundingRange
+
+	names := make([]string, len(subs))
+	for i, v := range subs {
+		names[i] = v.Name
+	}
+
+	return &eventmapv1.Event{
+		Id:                  e.ID.String(),
+		Title:               e.Title,
+		OrganizerId:         e.Organizer.String(),
+		FoundingType:        e.FoundingType,
+		FoundingRange:       &eventmapv1.Range{Low: f.LowMoney().Major(), High: f.HighMoney().Major(), Currency: f.Currency},
+		CoFoundingRange:     &eventmapv1.Range{Low: cf.Low, High: cf.High},
+		SubmissionDeadline:  timestamppb.New(e.SubmissionDeadline),
+		ConsiderationPeriod: e.ConsiderationPeriod,
+		RealisationPeriod:   e.RealisationPeriod,
+		Result:              e.Result,
+		Site:                e.Site,
+		Document:            e.Document,
+		InternalContacts:    e.InternalContacts,
+		Trl:                 int32(e.TRL),
+		CompetitorIds:       formatIDs(e.Competitors),
+		Subjects:            names,
+		Version:             int32(e.Version),
+	}
+}
+
+func buildChange(change services.StreamedChange) *eventmapv1.WatchChangesResponse {
+	message := &eventmapv1.Change{
+		Type:       change.Type,
+		ObjectId:   change.ObjectID.String(),
+		OccurredAt: timestamppb.New(change.OccurredAt),
+	}
+
+	switch o := change.Object.(type) {
+	case models.Event:
+		if change.Type != models.ChangeEventDeleted {
+			message.Object = &eventmapv1.Change_Event{
+				Event: buildEvent(o, change.Facts.Subjects),
+			}
+		}
+	case models.Organizer:
+		message.Object = &eventmapv1.Change_Organizer{Organizer: buildOrganizer(o)}
+	}
+
+	return &eventmapv1.WatchChangesResponse{Seq: change.Seq, Kind: &eventmapv1.WatchChangesResponse_Change{Change: message}}
+}
+
+func parseEventFilter(f *eventmapv1.EventFilter) (models.EventFilter, error) {
+	organizers, err := parseIDs("organizerIds", f.GetOrganizerIds())
+	if err != nil {
+		return models.EventFilter{}, err
+	}
+	competitors, err := parseIDs("competitorIds", f.GetCompetitorIds())
+	if err != nil {
+		return models.EventFilter{}, err
+	}
+	subjects, err := parseIDs("subjectIds", f.GetSubjectIds())
+	if err != nil {
+		return models.EventFilter{}, err
+	}
+
+	return models.EventFilter{
+		Organizers:  organizers,
+		Competitors: competitors,
+		Subjects:    subjects,
+		MinTRL:      int(f.GetMinTrl()),
+		MaxTRL:      int(f.GetMaxTrl()),
+		MinFunding:  int(f.GetMinFunding()),
+		MaxFunding:  int(f.GetMaxFunding()),
+	}, nil
+}
+
+func parseEventInput(i *eventmapv1.EventInput) (services.EventCreateInfo, error) {
+	organizer, err := parseOptionalID("organizer", i.GetOrganizerId())
+	if err != nil {
+		return services.EventCreateInfo{}, err
+	}
+	competitors, err := parseIDs("competitors", i.GetCompetitorIds())
+	if err != nil {
+		return services.EventCreateInfo{}, err
+	}
+
+	var deadline time.Time
+	if i.GetSubmissionDeadline() != nil {
+		deadline = i.GetSubmissionDeadline().AsTime()
+	}
+
+	return services.EventCreateInfo{
+		Title:               i.GetTitle(),
+		Organizer:           organizer,
+		FoundingType:        i.GetFoundingType(),
+		FoundingRangeLow:    i.GetFoundingRange().GetLow(),
+		FoundingRangeHigh:   i.GetFoundingRange().GetHigh(),
+		FoundingCurrency:    i.GetFoundingRange().GetCurrency(),
+		CoFoundingRangeLow:  i.GetCoFoundingRange().GetLow(),
+		CoFoundingRangeHigh: i.GetCoFoundingRange().GetHigh(),
+		SubmissionDeadline:  deadline,
+		ConsiderationPeriod: i.GetConsiderationPeriod(),
+		RealisationPeriod:   i.GetRealisationPeriod(),
+		Result:              i.GetResult(),
+		Site:                i.GetSite(),
+		Document:            i.GetDocument(),
+		InternalContacts:    i.GetInternalContacts(),
+		TRL:                 int(i.GetTrl()),
+		Competitors:         competitors,
+		Subjects:            i.GetSubjects(),
+	}, nil
+}
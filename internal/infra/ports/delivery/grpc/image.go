@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	"context"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	eventmapv1 "github.com/indigowar/map-of-events/pkg/api/eventmap/v1"
+	"github.com/indigowar/map-of-events/pkg/random"
+)
+
+type imageServer struct {
+	eventmapv1.UnimplementedImageServiceServer
+	svc services.ImageService
+}
+
+func (s *imageServer) UploadImage(ctx context.Context, req *eventmapv1.UploadImageRequest) (*eventmapv1.UploadImageResponse, error) {
+	if len(req.GetContent()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "content is required")
+	}
+
+	image, err := s.svc.Create(ctx, random.RandStringRunes(10), req.GetContent())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &eventmapv1.UploadImageResponse{Link: image.Link}, nil
+}
+
+func (s *imageServer) GetImage(ctx context.Context, req *eventmapv1.GetImageRequest) (*eventmapv1.Image, error) {
+	image, err := s.svc.Get(ctx, req.GetLink())
+	if err != nil {
+		log.Println(err)
+		return nil, status.Error(codes.NotFound, "image was not found")
+	}
+	return &eventmapv1.Image{Link: image.Link, Content: image.Value}, nil
+}
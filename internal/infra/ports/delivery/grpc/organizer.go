@@ -0,0 +1,128 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	eventmapv1 "github.com/indigowar/map-of-events/pkg/api/eventmap/v1"
+)
+
+type organizerServer struct {
+	eventmapv1.UnimplementedOrganizerServiceServer
+	svc services.OrganizerService
+}
+
+func (s *organizerServer) ListOrganizers(ctx context.Context, _ *eventmapv1.ListOrganizersRequest) (*eventmapv1.ListOrganizersResponse, error) {
+	organizers, err := s.svc.GetAll(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	result := &eventmapv1.ListOrganizersResponse{Organizers: make([]*eventmapv1.Organizer, len(organizers))}
+	for i, o := range organizers {
+		result.Organizers[i] = buildOrganizer(o)
+	}
+	return result, nil
+}
+
+func (s *organizerServer) GetOrganizer(ctx context.Context, req *eventmapv1.GetOrganizerRequest) (*eventmapv1.Organizer, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	organizer, err := s.svc.GetByID(ctx, id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return buildOrganizer(organizer), nil
+}
+
+func (s *organizerServer) CreateOrganizer(ctx context.Context, req *eventmapv1.CreateOrganizerRequest) (*eventmapv1.Organizer, error) {
+	level, err := parseOptionalID("level", req.GetLevelId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := services.ValidateOrganizer(req.GetName(), req.GetLogo(), level); err != nil {
+		return nil, statusError(err)
+	}
+
+	organizer, err := s.svc.Create(ctx, req.GetName(), req.GetLogo(), level)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return buildOrganizer(organizer), nil
+}
+
+func (s *organizerServer) UpdateOrganizer(ctx context.Context, req *eventmapv1.UpdateOrganizerRequest) (*eventmapv1.Organizer, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	level, err := parseOptionalID("level", req.GetLevelId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := services.ValidateOrganizer(req.GetName(), req.GetLogo(), level); err != nil {
+		return nil, statusError(err)
+	}
+
+	organizer, err := s.svc.Update(ctx, id, int(req.GetVersion()), req.GetName(), req.GetLogo(), level)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return buildOrganizer(organizer), nil
+}
+
+func (s *organizerServer) DeleteOrganizer(ctx context.Context, req *eventmapv1.DeleteOrganizerRequest) (*eventmapv1.DeleteOrganizerResponse, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.svc.Delete(ctx, id, int(req.GetVersion())); err != nil {
+		return nil, statusError(err)
+	}
+	return &eventmapv1.DeleteOrganizerResponse{}, nil
+}
+
+func (s *organizerServer) ListOrganizerLevels(ctx context.Context, _ *eventmapv1.ListOrganizerLevelsRequest) (*eventmapv1.ListOrganizerLevelsResponse, error) {
+	levels, err := s.svc.GetAllLevels(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	result := &eventmapv1.ListOrganizerLevelsResponse{Levels: make([]*eventmapv1.OrganizerLevel, len(levels))}
+	for i, l := range levels {
+		result.Levels[i] = buildOrganizerLevel(l)
+	}
+	return result, nil
+}
+
+func (s *organizerServer) CreateOrganizerLevel(ctx context.Context, req *eventmapv1.CreateOrganizerLevelRequest) (*eventmapv1.OrganizerLevel, error) {
+	if err := services.ValidateOrganizerLevel(req.GetName(), req.GetCode()); err != nil {
+		return nil, statusError(err)
+	}
+
+	level, err := s.svc.CreateLevel(ctx, req.GetName(), req.GetCode())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return buildOrganizerLevel(level), nil
+}
+
+func buildOrganizer(o models.Organizer) *eventmapv1.Organizer {
+	return &eventmapv1.Organizer{
+		Id:      o.ID.String(),
+		Name:    o.Name,
+		Logo:    o.Logo,
+		LevelId: o.Level.String(),
+		Version: int32(o.Version),
+	}
+}
+
+func buildOrganizerLevel(l models.OrganizerLevel) *eventmapv1.OrganizerLevel {
+	return &eventmapv1.OrganizerLevel{Id: l.ID.String(), Name: l.Name, Code: l.Code}
+}
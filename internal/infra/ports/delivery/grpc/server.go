@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	eventmapv1 "github.com/indigowar/map-of-events/pkg/api/eventmap/v1"
+)
+
+// Server - the gRPC API with the health checking
+type Server struct {
+	server *grpc.Server
+	health *health.Server
+}
+
+// NewServer - registers the services of the API, the services are described by reflection if it's enabled
+func NewServer(svc services.Services, reflect bool) *Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryAuth(svc.Auth)),
+		grpc.ChainStreamInterceptor(StreamAuth(svc.Auth)),
+	)
+
+	eventmapv1.RegisterEventServiceServer(server, &eventServer{svc: svc})
+	eventmapv1.RegisterOrganizerServiceServer(server, &organizerServer{svc: svc.Organizer})
+	eventmapv1.RegisterCompetitorServiceServer(server, &competitorServer{svc: svc.Competitor})
+	eventmapv1.RegisterSubjectServiceServer(server, &subjectServer{svc: svc.Subject})
+	eventmapv1.RegisterImageServiceServer(server, &imageServer{svc: svc.Image})
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	for name := range server.GetServiceInfo() {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+
+	if reflect {
+		reflection.Register(server)
+	}
+
+	return &Server{server: server, health: healthServer}
+}
+
+func (s *Server) Serve(listener net.Listener) error {
+	return s.server.Serve(listener)
+}
+
+// Shutdown - reports NOT_SERVING to the health checks and waits for the calls to end,
+// the calls still running when ctx is done are cancelled
+func (s *Server) Shutdown(ctx context.Context) {
+	s.health.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.server.Stop()
+	}
+}
@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	eventmapv1 "github.com/indigowar/map-of-events/pkg/api/eventmap/v1"
+)
+
+type subjectServer struct {
+	eventmapv1.UnimplementedSubjectServiceServer
+	svc services.SubjectService
+}
+
+func (s *subjectServer) ListSubjects(ctx context.Context, _ *eventmapv1.ListSubjectsRequest) (*eventmapv1.ListSubjectsResponse, error) {
+	subjects, err := s.svc.GetAllExisting(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	result := &eventmapv1.ListSubjectsResponse{Subjects: make([]*eventmapv1.Subject, len(subjects))}
+	for i, v := range subjects {
+		result.Subjects[i] = buildSubject(v)
+	}
+	return result, nil
+}
+
+func (s *subjectServer) GetSubject(ctx context.Context, req *eventmapv1.GetSubjectRequest) (*eventmapv1.Subject, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := s.svc.GetByID(ctx, id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return buildSubject(subject), nil
+}
+
+func (s *subjectServer) CreateSubject(ctx context.Context, req *eventmapv1.CreateSubjectRequest) (*eventmapv1.Subject, error) {
+	info, err := subjectInfo(req.GetSubject())
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := s.svc.Create(ctx, info)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return buildSubject(subject), nil
+}
+
+func (s *subjectServer) UpdateSubject(ctx context.Context, req *eventmapv1.UpdateSubjectRequest) (*eventmapv1.Subject, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	info, err := subjectInfo(req.GetSubject())
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := s.svc.Update(ctx, id, info)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return buildSubject(subject), nil
+}
+
+func (s *subjectServer) DeleteSubject(ctx context.Context, req *eventmapv1.DeleteSubjectRequest) (*eventmapv1.DeleteSubjectResponse, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.svc.Delete(ctx, id); err != nil {
+		return nil, statusError(err)
+	}
+	return &eventmapv1.DeleteSubjectResponse{}, nil
+}
+
+func subjectInfo(i *eventmapv1.SubjectInput) (services.SubjectInfo, error) {
+	parent, err := parseOptionalID("parent", i.GetParentId())
+	if err != nil {
+		return services.SubjectInfo{}, err
+	}
+
+	codes := make([]models.SubjectCode, len(i.GetCodes()))
+	for index, c := range i.GetCodes() {
+		codes[index] = models.SubjectCode{Scheme: c.GetScheme(), Code: c.GetCode()}
+	}
+
+	return services.SubjectInfo{Name: i.GetName(), Parent: parent, Synonyms: i.GetSynonyms(), Codes: codes}, nil
+}
+
+func buildSubject(s models.Subject) *eventmapv1.Subject {
+	var parent string
+	if s.Parent != uuid.Nil {
+		parent = s.Parent.String()
+	}
+
+	codes := make([]*eventmapv1.SubjectCode, len(s.Codes))
+	for i, c := range s.Codes {
+		codes[i] = &eventmapv1.SubjectCode{Scheme: c.Scheme, Code: c.Code}
+	}
+
+	return &eventmapv1.Subject{Id: s.ID.String(), Name: s.Name, ParentId: parent, Synonyms: s.Synonyms, Codes: codes}
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+// userKey - key of the authenticated user's id in gin.Context
+const userKey = "user"
+
+// Middleware - authenticates the user by "Authorization: Bearer <token>" header,
+// requests without the header pass as anonymous, requests with an invalid token are rejected with 401.
+func Middleware(svc services.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			c.Next()
+			return
+		}
+
+		token := strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+		if !strings.HasPrefix(header, "Bearer ") || token == "" {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		user, err := svc.Authenticate(c, token)
+		if err != nil {
+			if errors.Is(err, services.AuthErrTokenIsInvalid) {
+				c.AbortWithStatus(http.StatusUnauthorized)
+				return
+			}
+			log.Println(err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		c.Set(userKey, user)
+		c.Next()
+	}
+}
+
+// Required - rejects anonymous requests with 401, should be used after Middleware
+func Required() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := User(c); !ok {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Next()
+	}
+}
+
+// Admin - rejects anonymous requests with 401 and requests of not admin users with 403,
+// should be used after Middleware
+func Admin(svc services.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := User(c)
+		if !ok {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		if !svc.IsAdmin(user) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		c.Next()
+	}
+}
+
+// User - returns id of the authenticated user, false for anonymous requests
+func User(c *gin.Context) (uuid.UUID, bool) {
+	value, ok := c.Get(userKey)
+	if !ok {
+		return uuid.Nil, false
+	}
+	user, ok := value.(uuid.UUID)
+	return user, ok
+}
@@ -0,0 +1,65 @@
+package graphql
+
+import (
+	"errors"
+	"log"
+
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+	pkgerrors "github.com/indigowar/map-of-events/pkg/errors"
+)
+
+// Codes of the errors given in the "extensions" of the GraphQL errors, they match the statuses of the REST API
+const (
+	codeValidationFailed = "VALIDATION_FAILED"
+	codeVersionMismatch  = "VERSION_MISMATCH"
+	codeNotFound         = "NOT_FOUND"
+	codeAlreadyExists    = "ALREADY_EXISTS"
+	codeInternal         = "INTERNAL"
+)
+
+// apiError - an error of a resolver with a code for the clients
+type apiError struct {
+	code       string
+	message    string
+	violations validators.Violations
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func (e *apiError) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": e.code}
+	if e.violations != nil {
+		extensions["violations"] = e.violations
+	}
+	return extensions
+}
+
+// resolverError - converts an error of the services to the error for the clients, internal errors are logged and hidden
+func resolverError(err error) error {
+	var violations validators.Violations
+	switch {
+	case errors.As(err, &violations):
+		return &apiError{code: codeValidationFailed, message: "validation failed", violations: violations}
+	case errors.Is(err, services.ErrVersionMismatch):
+		return &apiError{code: codeVersionMismatch, message: err.Error()}
+	}
+	log.Println(err)
+	return &apiError{code: codeInternal, message: "internal error"}
+}
+
+// competitorError - converts an error of the competitor service by its reason
+func competitorError(err pkgerrors.Error) error {
+	switch err.Reason() {
+	case services.ErrReasonNotFound:
+		return &apiError{code: codeNotFound, message: err.ShortErr()}
+	case services.ErrReasonAlreadyExist:
+		return &apiError{code: codeAlreadyExists, message: err.ShortErr()}
+	case services.ErrReasonValidationFailed:
+		return &apiError{code: codeValidationFailed, message: err.ShortErr()}
+	}
+	log.Println(err.LongErr())
+	return &apiError{code: codeInternal, message: "internal error"}
+}
@@ -0,0 +1,65 @@
+package graphql
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/openapi"
+	gql "github.com/indigowar/map-of-events/pkg/graphql"
+)
+
+// maxRequestBytes - the maximum size of a GraphQL request, the larger ones are rejected with 413 before they're parsed
+const maxRequestBytes = 1 << 20
+
+// QueryHandler - executes a GraphQL request given as JSON {"query", "variables", "operationName"},
+// the response is 400 if the request was rejected before the execution, otherwise 200 with the data and the errors of the fields
+func QueryHandler(schema *gql.Schema, svc services.Services, limits gql.Limits) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxRequestBytes+1))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		if len(body) > maxRequestBytes {
+			c.Status(http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		var request gql.Request
+		if err := json.Unmarshal(body, &request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		response := schema.Execute(withLoaders(c, svc), request, limits)
+		if response.Data == nil {
+			c.JSON(http.StatusBadRequest, response)
+			return
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// SchemaHandler - returns the schema in the schema definition language
+func SchemaHandler(schema *gql.Schema) gin.HandlerFunc {
+	sdl := schema.SDL()
+	return func(c *gin.Context) {
+		c.String(http.StatusOK, sdl)
+	}
+}
+
+// Routes - describes routes of this package for the OpenAPI document
+func Routes() []openapi.Route {
+	tags := []string{"graphql"}
+	return []openapi.Route{
+		{Method: http.MethodPost, Path: "/graphql", Summary: "Executes a GraphQL query or mutation", Tags: tags, Request: gql.Request{}, Response: gql.Response{}},
+		{Method: http.MethodGet, Path: "/graphql/schema", Summary: "Returns the GraphQL schema in SDL", Tags: tags, Response: "", ResponseContentType: "text/plain"},
+	}
+}
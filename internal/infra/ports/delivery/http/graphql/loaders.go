@@ -0,0 +1,186 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	gql "github.com/indigowar/map-of-events/pkg/graphql"
+)
+
+type loadersKey struct{}
+
+// kind - a kind of the reference data, fetch loads the existing objects of the ids
+type kind struct {
+	name  string
+	fetch func(ctx context.Context, svc services.Services, ids []uuid.UUID) (map[uuid.UUID]interface{}, error)
+}
+
+var (
+	organizerKind = kind{name: "organizer", fetch: func(ctx context.Context, svc services.Services, ids []uuid.UUID) (map[uuid.UUID]interface{}, error) {
+		organizers, err := svc.Organizer.GetByIDs(ctx, ids)
+		if err != nil {
+			return nil, resolverError(err)
+		}
+		result := make(map[uuid.UUID]interface{}, len(organizers))
+		for _, o := range organizers {
+			result[o.ID] = o
+		}
+		return result, nil
+	}}
+
+	levelKind = kind{name: "level", fetch: func(ctx context.Context, svc services.Services, ids []uuid.UUID) (map[uuid.UUID]interface{}, error) {
+		levels, err := svc.Organizer.GetLevelsByIDs(ctx, ids)
+		if err != nil {
+			return nil, resolverError(err)
+		}
+		result := make(map[uuid.UUID]interface{}, len(levels))
+		for _, v := range levels {
+			result[v.ID] = v
+		}
+		return result, nil
+	}}
+
+	competitorKind = kind{name: "competitor", fetch: func(ctx context.Context, svc services.Services, ids []uuid.UUID) (map[uuid.UUID]interface{}, error) {
+		competitors, err := svc.Competitor.GetByIDs(ctx, ids)
+		if err != nil {
+			return nil, competitorError(err)
+		}
+		result := make(map[uuid.UUID]interface{}, len(competitors))
+		for _, c := range competitors {
+			result[c.ID] = c
+		}
+		return result, nil
+	}}
+
+	subjectKind = kind{name: "subject", fetch: func(ctx context.Context, svc services.Services, ids []uuid.UUID) (map[uuid.UUID]interface{}, error) {
+		subjects, err := svc.Subject.GetByIDs(ctx, ids)
+		if err != nil {
+			return nil, resolverError(err)
+		}
+		result := make(map[uuid.UUID]interface{}, len(subjects))
+		for _, s := range subjects {
+			result[s.ID] = s
+		}
+		return result, nil
+	}}
+)
+
+// loaders - caches the reference data loaded by the resolvers during a request.
+// The resolvers get all parents of a selection at once, so the objects they refer to
+// are loaded with one call per kind and selection level, and only the ones not loaded yet.
+// The fields are resolved one by one, so there is no locking
+type loaders struct {
+	svc services.Services
+
+	// loaded - the objects of every kind by their ids, a missing object is kept as nil
+	loaded map[string]map[uuid.UUID]interface{}
+}
+
+func withLoaders(ctx context.Context, svc services.Services) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{svc: svc})
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// reset - drops the cached data, it's called by the mutations
+func (l *loaders) reset() {
+	*l = loaders{svc: l.svc}
+}
+
+// load - returns the objects of the kind by their ids, the ids not requested yet are loaded in one call.
+// uuid.Nil and the ids of missing objects are mapped to nil
+func (l *loaders) load(ctx context.Context, k kind, ids []uuid.UUID) (map[uuid.UUID]interface{}, error) {
+	if l.loaded == nil {
+		l.loaded = make(map[string]map[uuid.UUID]interface{})
+	}
+	cache := l.loaded[k.name]
+	if cache == nil {
+		cache = make(map[uuid.UUID]interface{})
+		l.loaded[k.name] = cache
+	}
+
+	missing := make([]uuid.UUID, 0)
+	seen := make(map[uuid.UUID]bool)
+	for _, id := range ids {
+		if _, ok := cache[id]; ok || id == uuid.Nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		missing = append(missing, id)
+	}
+	if len(missing) == 0 {
+		return cache, nil
+	}
+
+	objects, err := k.fetch(ctx, l.svc, missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range missing {
+		// not a typed nil, so the missing objects are null
+		cache[id] = objects[id]
+	}
+	return cache, nil
+}
+
+// one - returns the object of the kind with the id or nil if it's missing
+func (l *loaders) one(ctx context.Context, k kind, id uuid.UUID) (interface{}, error) {
+	objects, err := l.load(ctx, k, []uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+	return objects[id], nil
+}
+
+// resolveReference - resolves the object of the kind every parent refers to, they're loaded at once
+func resolveReference(k kind, reference func(parent interface{}) uuid.UUID) gql.BatchResolver {
+	return func(ctx context.Context, parents []interface{}, _ map[string]interface{}) ([]interface{}, error) {
+		ids := make([]uuid.UUID, len(parents))
+		for i, p := range parents {
+			ids[i] = reference(p)
+		}
+
+		objects, err := loadersFrom(ctx).load(ctx, k, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]interface{}, len(parents))
+		for i, id := range ids {
+			result[i] = objects[id]
+		}
+		return result, nil
+	}
+}
+
+// resolveReferences - resolves the objects of the kind every parent refers to, the missing ones are skipped,
+// the objects of all parents are loaded at once
+func resolveReferences(k kind, references func(parent interface{}) []uuid.UUID) gql.BatchResolver {
+	return func(ctx context.Context, parents []interface{}, _ map[string]interface{}) ([]interface{}, error) {
+		ids := make([]uuid.UUID, 0)
+		for _, p := range parents {
+			ids = append(ids, references(p)...)
+		}
+
+		objects, err := loadersFrom(ctx).load(ctx, k, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]interface{}, len(parents))
+		for i, p := range parents {
+			list := make([]interface{}, 0)
+			for _, id := range references(p) {
+				if o := objects[id]; o != nil {
+					list = append(list, o)
+				}
+			}
+			result[i] = list
+		}
+		return result, nil
+	}
+}
@@ -0,0 +1,446 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	gql "github.com/indigowar/map-of-events/pkg/graphql"
+)
+
+// NewSchema - builds the schema over the services, mutations mirror create/update operations of the REST API
+func NewSchema(svc services.Services) *gql.Schema {
+	t := newTypes(svc)
+	return &gql.Schema{
+		Query:    newQuery(svc, t),
+		Mutation: newMutation(svc, t),
+	}
+}
+
+// root - a resolver of a field of Query or Mutation, they have no parent
+func root(resolve func(ctx context.Context, args input) (interface{}, error)) gql.BatchResolver {
+	return gql.ResolveEach(func(ctx context.Context, _ interface{}, args map[string]interface{}) (interface{}, error) {
+		return resolve(ctx, args)
+	})
+}
+
+func idArgument() []*gql.Argument {
+	return []*gql.Argument{{Name: "id", Type: nonNull(idType)}}
+}
+
+func newQuery(svc services.Services, t types) *gql.Object {
+	eventFilter := &gql.InputObject{
+		Name:        "EventFilter",
+		Description: "An event satisfies the filter if it satisfies all given criteria",
+		Fields: []*gql.Argument{
+			{Name: "organizers", Type: &gql.List{Of: nonNull(idType)}},
+			{Name: "competitors", Type: &gql.List{Of: nonNull(idType)}},
+			{Name: "subjects", Type: &gql.List{Of: nonNull(idType)}, Description: "An event should have one of the subjects or their descendants"},
+			{Name: "minTrl", Type: gql.Int},
+			{Name: "maxTrl", Type: gql.Int},
+			{Name: "minFunding", Type: amountType, Description: "The founding range of an event converted to the reference currency " +
+				"should intersect with minFunding and maxFunding"},
+			{Name: "maxFunding", Type: amountType},
+		},
+	}
+
+	return &gql.Object{
+		Name: "Query",
+		Fields: []*gql.Field{
+			{Name: "events", Type: gql.ListOf(t.event), Args: []*gql.Argument{
+				{Name: "filter", Type: eventFilter},
+				{Name: "sort", Type: gql.String, Description: "funding or -funding orders the events by the founding ranges converted to the reference currency"},
+			},
+				Resolve: root(func(ctx context.Context, args input) (interface{}, error) {
+					f := args.object("filter")
+					filter := models.EventFilter{
+						Organizers:  f.ids("organizers"),
+						Competitors: f.ids("competitors"),
+						Subjects:    f.ids("subjects"),
+						MinTRL:      f.int("minTrl"),
+						MaxTRL:      f.int("maxTrl"),
+						MinFunding:  int(f.amount("minFunding")),
+						MaxFunding:  int(f.amount("maxFunding")),
+					}
+					if err := services.ValidateEventFilter(filter); err != nil {
+						return nil, resolverError(err)
+					}
+					sort := args.string("sort")
+					if err := services.ValidateEventSort(sort); err != nil {
+						return nil, resolverError(err)
+					}
+
+					events, err := svc.Event.Find(ctx, filter)
+					if err == nil && sort != "" {
+						events, err = svc.Event.SortByFunding(ctx, events, sort == services.EventSortFundingDescending)
+					}
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return events, nil
+				})},
+			{Name: "event", Type: t.event, Args: idArgument(),
+				Resolve: root(func(ctx context.Context, args input) (interface{}, error) {
+					events, err := svc.Event.GetByIDs(ctx, []uuid.UUID{args.id("id")})
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					if len(events) == 0 {
+						return nil, nil
+					}
+					return events[0], nil
+				})},
+			{Name: "organizers", Type: gql.ListOf(t.organizer),
+				Resolve: root(func(ctx context.Context, _ input) (interface{}, error) {
+					organizers, err := svc.Organizer.GetAll(ctx)
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return organizers, nil
+				})},
+			{Name: "organizer", Type: t.organizer, Args: idArgument(),
+				Resolve: root(func(ctx context.Context, args input) (interface{}, error) {
+					return loadersFrom(ctx).one(ctx, organizerKind, args.id("id"))
+				})},
+			{Name: "organizerLevels", Type: gql.ListOf(t.organizerLevel),
+				Resolve: root(func(ctx context.Context, _ input) (interface{}, error) {
+					levels, err := svc.Organizer.GetAllLevels(ctx)
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return levels, nil
+				})},
+			{Name: "competitors", Type: gql.ListOf(t.competitor),
+				Resolve: root(func(ctx context.Context, _ input) (interface{}, error) {
+					competitors, err := svc.Competitor.GetAll(ctx)
+					if err != nil {
+						return nil, competitorError(err)
+					}
+					return competitors, nil
+				})},
+			{Name: "competitor", Type: t.competitor, Args: idArgument(),
+				Resolve: root(func(ctx context.Context, args input) (interface{}, error) {
+					return loadersFrom(ctx).one(ctx, competitorKind, args.id("id"))
+				})},
+			{Name: "subjects", Type: gql.ListOf(t.subject),
+				Resolve: root(func(ctx context.Context, _ input) (interface{}, error) {
+					subjects, err := svc.Subject.GetAllExisting(ctx)
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return subjects, nil
+				})},
+			{Name: "subject", Type: t.subject, Args: idArgument(),
+				Resolve: root(func(ctx context.Context, args input) (interface{}, error) {
+					return loadersFrom(ctx).one(ctx, subjectKind, args.id("id"))
+				})},
+		},
+	}
+}
+
+func newMutation(svc services.Services, t types) *gql.Object {
+	eventInput := &gql.InputObject{
+		Name: "EventInput",
+		Fields: []*gql.Argument{
+			{Name: "title", Type: nonNull(gql.String)},
+			{Name: "organizer", Type: nonNull(idType)},
+			{Name: "foundingType", Type: gql.String},
+			{Name: "foundingRangeLow", Type: amountType},
+			{Name: "foundingRangeHigh", Type: amountType},
+			{Name: "foundingCurrency", Type: gql.String, Description: "ISO 4217 code of the founding range, the reference currency if it's not given"},
+			{Name: "coFoundingRangeLow", Type: gql.Int},
+			{Name: "coFoundingRangeHigh", Type: gql.Int},
+			{Name: "submissionDeadline", Type: nonNull(dateTimeType)},
+			{Name: "considerationPeriod", Type: gql.String},
+			{Name: "realisationPeriod", Type: gql.String},
+			{Name: "result", Type: gql.String},
+			{Name: "site", Type: gql.String},
+			{Name: "document", Type: gql.String},
+			{Name: "internalContacts", Type: gql.String},
+			{Name: "trl", Type: nonNull(gql.Int)},
+			{Name: "competitors", Type: &gql.List{Of: nonNull(idType)}},
+			{Name: "subjects", Type: &gql.List{Of: nonNull(gql.String)}, Description: "Names or synonyms of the subjects, missing subjects are added"},
+		},
+	}
+	organizerInput := &gql.InputObject{
+		Name: "OrganizerInput",
+		Fields: []*gql.Argument{
+			{Name: "name", Type: nonNull(gql.String)},
+			{Name: "logo", Type: gql.String},
+			{Name: "level", Type: nonNull(idType)},
+		},
+	}
+	organizerLevelInput := &gql.InputObject{
+		Name: "OrganizerLevelInput",
+		Fields: []*gql.Argument{
+			{Name: "name", Type: nonNull(gql.String)},
+			{Name: "code", Type: nonNull(gql.String)},
+		},
+	}
+	competitorInput := &gql.InputObject{
+		Name: "CompetitorInput",
+		Fields: []*gql.Argument{
+			{Name: "name", Type: nonNull(gql.String)},
+			{Name: "category", Type: gql.String},
+			{Name: "minAge", Type: gql.Int},
+			{Name: "maxAge", Type: gql.Int},
+			{Name: "minDegree", Type: gql.String},
+			{Name: "regions", Type: &gql.List{Of: nonNull(gql.String)}},
+			{Name: "organizationTypes", Type: &gql.List{Of: nonNull(gql.String)}},
+		},
+	}
+	subjectInput := &gql.InputObject{
+		Name: "SubjectInput",
+		Fields: []*gql.Argument{
+			{Name: "name", Type: nonNull(gql.String)},
+			{Name: "parent", Type: idType},
+			{Name: "synonyms", Type: &gql.List{Of: nonNull(gql.String)}},
+			{Name: "codes", Type: &gql.List{Of: nonNull(&gql.InputObject{
+				Name: "SubjectCodeInput",
+				Fields: []*gql.Argument{
+					{Name: "scheme", Type: nonNull(gql.String)},
+					{Name: "code", Type: nonNull(gql.String)},
+				},
+			})}},
+		},
+	}
+
+	versionArgument := &gql.Argument{Name: "version", Type: nonNull(gql.Int),
+		Description: "The version the client has seen, the object is not updated if it was changed since, -1 skips the check"}
+
+	// mutations change the data, so the objects cached by the earlier fields are dropped
+	mutation := func(resolve func(ctx context.Context, args input) (interface{}, error)) gql.BatchResolver {
+		return root(func(ctx context.Context, args input) (interface{}, error) {
+			loadersFrom(ctx).reset()
+			return resolve(ctx, args)
+		})
+	}
+
+	return &gql.Object{
+		Name: "Mutation",
+		Fields: []*gql.Field{
+			{Name: "createEvent", Type: nonNull(t.event), Args: []*gql.Argument{{Name: "input", Type: nonNull(eventInput)}},
+				Resolve: mutation(func(ctx context.Context, args input) (interface{}, error) {
+					info := eventInfo(args.object("input"))
+					event, err := svc.Event.Create(ctx, info)
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return event, nil
+				})},
+			{Name: "updateEvent", Type: nonNull(t.event),
+				Args: []*gql.Argument{{Name: "id", Type: nonNull(idType)}, versionArgument, {Name: "input", Type: nonNull(eventInput)}},
+				Resolve: mutation(func(ctx context.Context, args input) (interface{}, error) {
+					info := eventInfo(args.object("input"))
+					event, err := svc.Event.Update(ctx, args.id("id"), args.int("version"), info)
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return event, nil
+				})},
+
+			{Name: "createOrganizer", Type: nonNull(t.organizer), Args: []*gql.Argument{{Name: "input", Type: nonNull(organizerInput)}},
+				Resolve: mutation(func(ctx context.Context, args input) (interface{}, error) {
+					in := args.object("input")
+					if err := services.ValidateOrganizer(in.string("name"), in.string("logo"), in.id("level")); err != nil {
+						return nil, resolverError(err)
+					}
+					organizer, err := svc.Organizer.Create(ctx, in.string("name"), in.string("logo"), in.id("level"))
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return organizer, nil
+				})},
+			{Name: "updateOrganizer", Type: nonNull(t.organizer),
+				Args: []*gql.Argument{{Name: "id", Type: nonNull(idType)}, versionArgument, {Name: "input", Type: nonNull(organizerInput)}},
+				Resolve: mutation(func(ctx context.Context, args input) (interface{}, error) {
+					in := args.object("input")
+					if err := services.ValidateOrganizer(in.string("name"), in.string("logo"), in.id("level")); err != nil {
+						return nil, resolverError(err)
+					}
+					organizer, err := svc.Organizer.Update(ctx, args.id("id"), args.int("version"), in.string("name"), in.string("logo"), in.id("level"))
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return organizer, nil
+				})},
+			{Name: "createOrganizerLevel", Type: nonNull(t.organizerLevel), Args: []*gql.Argument{{Name: "input", Type: nonNull(organizerLevelInput)}},
+				Resolve: mutation(func(ctx context.Context, args input) (interface{}, error) {
+					in := args.object("input")
+					if err := services.ValidateOrganizerLevel(in.string("name"), in.string("code")); err != nil {
+						return nil, resolverError(err)
+					}
+					level, err := svc.Organizer.CreateLevel(ctx, in.string("name"), in.string("code"))
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return level, nil
+				})},
+
+			{Name: "createCompetitor", Type: nonNull(t.competitor), Args: []*gql.Argument{{Name: "input", Type: nonNull(competitorInput)}},
+				Resolve: mutation(func(ctx context.Context, args input) (interface{}, error) {
+					info := competitorInfo(args.object("input"))
+					if err := info.Validate(); err != nil {
+						return nil, resolverError(err)
+					}
+					competitor, err := svc.Competitor.Create(ctx, info)
+					if err != nil {
+						return nil, competitorError(err)
+					}
+					return competitor, nil
+				})},
+			{Name: "updateCompetitor", Type: nonNull(t.competitor),
+				Args: []*gql.Argument{{Name: "id", Type: nonNull(idType)}, {Name: "input", Type: nonNull(competitorInput)}},
+				Resolve: mutation(func(ctx context.Context, args input) (interface{}, error) {
+					info := competitorInfo(args.object("input"))
+					if err := info.Validate(); err != nil {
+						return nil, resolverError(err)
+					}
+					competitor, err := svc.Competitor.Update(ctx, args.id("id"), info)
+					if err != nil {
+						return nil, competitorError(err)
+					}
+					return competitor, nil
+				})},
+
+			{Name: "createSubject", Type: nonNull(t.subject), Args: []*gql.Argument{{Name: "input", Type: nonNull(subjectInput)}},
+				Resolve: mutation(func(ctx context.Context, args input) (interface{}, error) {
+					subject, err := svc.Subject.Create(ctx, subjectInfo(args.object("input")))
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return subject, nil
+				})},
+			{Name: "updateSubject", Type: nonNull(t.subject),
+				Args: []*gql.Argument{{Name: "id", Type: nonNull(idType)}, {Name: "input", Type: nonNull(subjectInput)}},
+				Resolve: mutation(func(ctx context.Context, args input) (interface{}, error) {
+					subject, err := svc.Subject.Update(ctx, args.id("id"), subjectInfo(args.object("input")))
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return subject, nil
+				})},
+		},
+	}
+}
+
+func eventInfo(in input) services.EventCreateInfo {
+	return services.EventCreateInfo{
+		Title:               in.string("title"),
+		Organizer:           in.id("organizer"),
+		FoundingType:        in.string("foundingType"),
+		FoundingRangeLow:    in.amount("foundingRangeLow"),
+		FoundingRangeHigh:   in.amount("foundingRangeHigh"),
+		FoundingCurrency:    in.string("foundingCurrency"),
+		CoFoundingRangeLow:  int64(in.int("coFoundingRangeLow")),
+		CoFoundingRangeHigh: int64(in.int("coFoundingRangeHigh")),
+		SubmissionDeadline:  in.time("submissionDeadline"),
+		ConsiderationPeriod: in.string("considerationPeriod"),
+		RealisationPeriod:   in.string("realisationPeriod"),
+		Result:              in.string("result"),
+		Site:                in.string("site"),
+		Document:            in.string("document"),
+		InternalContacts:    in.string("internalContacts"),
+		TRL:                 in.int("trl"),
+		Competitors:         in.ids("competitors"),
+		Subjects:            in.strings("subjects"),
+	}
+}
+
+func competitorInfo(in input) services.CompetitorInfo {
+	return services.CompetitorInfo{
+		Name:              in.string("name"),
+		Category:          in.string("category"),
+		MinAge:            in.int("minAge"),
+		MaxAge:            in.int("maxAge"),
+		MinDegree:         in.string("minDegree"),
+		Regions:           in.strings("regions"),
+		OrganizationTypes: in.strings("organizationTypes"),
+	}
+}
+
+func subjectInfo(in input) services.SubjectInfo {
+	codes := make([]models.SubjectCode, 0)
+	for _, c := range in.objects("codes") {
+		codes = append(codes, models.SubjectCode{Scheme: c.string("scheme"), Code: c.string("code")})
+	}
+	synonyms := in.strings("synonyms")
+	if synonyms == nil {
+		synonyms = make([]string, 0)
+	}
+	return services.SubjectInfo{Name: in.string("name"), Parent: in.id("parent"), Synonyms: synonyms, Codes: codes}
+}
+
+// input - coerced arguments or fields of an input object, missing and null values are read as zero values
+type input map[string]interface{}
+
+func (i input) string(name string) string {
+	s, _ := i[name].(string)
+	return s
+}
+
+func (i input) int(name string) int {
+	n, _ := i[name].(int)
+	return n
+}
+
+func (i input) amount(name string) int64 {
+	n, _ := i[name].(int64)
+	return n
+}
+
+func (i input) id(name string) uuid.UUID {
+	id, _ := i[name].(uuid.UUID)
+	return id
+}
+
+func (i input) time(name string) time.Time {
+	t, _ := i[name].(time.Time)
+	return t
+}
+
+func (i input) object(name string) input {
+	o, _ := i[name].(map[string]interface{})
+	return o
+}
+
+func (i input) list(name string) []interface{} {
+	l, _ := i[name].([]interface{})
+	return l
+}
+
+func (i input) ids(name string) []uuid.UUID {
+	list := i.list(name)
+	if list == nil {
+		return nil
+	}
+	result := make([]uuid.UUID, len(list))
+	for index, v := range list {
+		result[index] = v.(uuid.UUID)
+	}
+	return result
+}
+
+func (i input) strings(name string) []string {
+	list := i.list(name)
+	if list == nil {
+		return nil
+	}
+	result := make([]string, len(list))
+	for index, v := range list {
+		result[index] = v.(string)
+	}
+	return result
+}
+
+func (i input) objects(name string) []input {
+	list := i.list(name)
+	result := make([]input, len(list))
+	for index, v := range list {
+		result[index] = v.(map[string]interface{})
+	}
+	return result
+}
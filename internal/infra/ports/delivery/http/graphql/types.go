@@ -0,0 +1,202 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	gql "github.com/indigowar/map-of-events/pkg/graphql"
+)
+
+var (
+	// idType - ids are uuids, the invalid ones are rejected before the execution
+	idType = &gql.Scalar{
+		Name:      "ID",
+		Serialize: func(v interface{}) interface{} { return fmt.Sprint(v) },
+		Coerce: func(v interface{}) (interface{}, error) {
+			s, ok := v.(string)
+			if !ok {
+				return nil, errors.New("should be an id")
+			}
+			id, err := uuid.Parse(s)
+			if err != nil {
+				return nil, errors.New("is not a valid id")
+			}
+			return id, nil
+		},
+	}
+	// amountType - Int of GraphQL is 32-bit, so the amounts of money are given by their own scalar,
+	// it's an integer in the major units of the currency up to 2^53, so it's exact in JSON
+	amountType = &gql.Scalar{
+		Name:      "Amount",
+		Serialize: func(v interface{}) interface{} { return v },
+		Coerce: func(v interface{}) (interface{}, error) {
+			switch n := v.(type) {
+			case int:
+				return int64(n), nil
+			case float64:
+				if n == math.Trunc(n) && math.Abs(n) <= 1<<53 {
+					return int64(n), nil
+				}
+			}
+			return nil, errors.New("should be an integer")
+		},
+	}
+	dateTimeType = &gql.Scalar{
+		Name:      "DateTime",
+		Serialize: func(v interface{}) interface{} { return v.(time.Time).Format(time.RFC3339) },
+		Coerce: func(v interface{}) (interface{}, error) {
+			s, ok := v.(string)
+			if !ok {
+				return nil, errors.New("should be a date in RFC 3339")
+			}
+			t, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				return nil, errors.New("should be a date in RFC 3339")
+			}
+			return t, nil
+		},
+	}
+)
+
+func nonNull(t gql.Type) gql.Type {
+	return &gql.NonNull{Of: t}
+}
+
+// types - the object types of the schema, the values of the types are the models
+type types struct {
+	event          *gql.Object
+	organizer      *gql.Object
+	organizerLevel *gql.Object
+	rangeType      *gql.Object
+	competitor     *gql.Object
+	subject        *gql.Object
+	subjectCode    *gql.Object
+}
+
+func newTypes(svc services.Services) types {
+	t := types{
+		event:          &gql.Object{Name: "Event"},
+		organizer:      &gql.Object{Name: "Organizer"},
+		organizerLevel: &gql.Object{Name: "OrganizerLevel"},
+		rangeType: &gql.Object{Name: "Range", Description: "A range of the funding in the major units of the currency, " +
+			"the co-funding is given in percents"},
+		competitor:  &gql.Object{Name: "Competitor", Description: "A category of applicants with its eligibility criteria"},
+		subject:     &gql.Object{Name: "Subject"},
+		subjectCode: &gql.Object{Name: "SubjectCode"},
+	}
+
+	event := func(get func(e models.Event) interface{}) gql.BatchResolver {
+		return gql.Property(func(p interface{}) interface{} { return get(p.(models.Event)) })
+	}
+	t.event.Fields = []*gql.Field{
+		{Name: "id", Type: nonNull(idType), Resolve: event(func(e models.Event) interface{} { return e.ID })},
+		{Name: "title", Type: nonNull(gql.String), Resolve: event(func(e models.Event) interface{} { return e.Title })},
+		{Name: "organizer", Type: t.organizer, Resolve: resolveReference(organizerKind,
+			func(p interface{}) uuid.UUID { return p.(models.Event).Organizer })},
+		{Name: "foundingType", Type: nonNull(gql.String), Resolve: event(func(e models.Event) interface{} { return e.FoundingType })},
+		{Name: "foundingRange", Type: t.rangeType, Resolve: event(func(e models.Event) interface{} { return e.FoundingRange })},
+		{Name: "coFoundingRange", Type: t.rangeType, Resolve: event(func(e models.Event) interface{} { return e.CoFoundingRange })},
+		{Name: "submissionDeadline", Type: nonNull(dateTimeType), Resolve: event(func(e models.Event) interface{} { return e.SubmissionDeadline })},
+		{Name: "considerationPeriod", Type: nonNull(gql.String), Resolve: event(func(e models.Event) interface{} { return e.ConsiderationPeriod })},
+		{Name: "realisationPeriod", Type: nonNull(gql.String), Resolve: event(func(e models.Event) interface{} { return e.RealisationPeriod })},
+		{Name: "result", Type: nonNull(gql.String), Resolve: event(func(e models.Event) interface{} { return e.Result })},
+		{Name: "site", Type: nonNull(gql.String), Resolve: event(func(e models.Event) interface{} { return e.Site })},
+		{Name: "document", Type: nonNull(gql.String), Resolve: event(func(e models.Event) interface{} { return e.Document })},
+		{Name: "internalContacts", Type: nonNull(gql.String), Resolve: event(func(e models.Event) interface{} { return e.InternalContacts })},
+		{Name: "trl", Type: nonNull(gql.Int), Resolve: event(func(e models.Event) interface{} { return e.TRL })},
+		{Name: "competitors", Type: gql.ListOf(t.competitor), Resolve: resolveReferences(competitorKind,
+			func(p interface{}) []uuid.UUID { return p.(models.Event).Competitors })},
+		{Name: "subjects", Type: gql.ListOf(t.subject), Resolve: func(ctx context.Context, parents []interface{}, _ map[string]interface{}) ([]interface{}, error) {
+			ids := make([]uuid.UUID, len(parents))
+			for i, p := range parents {
+				ids[i] = p.(models.Event).ID
+			}
+			subjects, err := svc.Subject.GetAllForEvents(ctx, ids)
+			if err != nil {
+				return nil, resolverError(err)
+			}
+			result := make([]interface{}, len(parents))
+			for i, id := range ids {
+				result[i] = subjects[id]
+			}
+			return result, nil
+		}},
+		{Name: "version", Type: nonNull(gql.Int), Description: "A revision of the event, it's given to updateEvent",
+			Resolve: event(func(e models.Event) interface{} { return e.Version })},
+	}
+
+	organizer := func(get func(o models.Organizer) interface{}) gql.BatchResolver {
+		return gql.Property(func(p interface{}) interface{} { return get(p.(models.Organizer)) })
+	}
+	t.organizer.Fields = []*gql.Field{
+		{Name: "id", Type: nonNull(idType), Resolve: organizer(func(o models.Organizer) interface{} { return o.ID })},
+		{Name: "name", Type: nonNull(gql.String), Resolve: organizer(func(o models.Organizer) interface{} { return o.Name })},
+		{Name: "logo", Type: nonNull(gql.String), Resolve: organizer(func(o models.Organizer) interface{} { return o.Logo })},
+		{Name: "level", Type: t.organizerLevel, Resolve: resolveReference(levelKind,
+			func(p interface{}) uuid.UUID { return p.(models.Organizer).Level })},
+		{Name: "version", Type: nonNull(gql.Int), Description: "A revision of the organizer, it's given to updateOrganizer",
+			Resolve: organizer(func(o models.Organizer) interface{} { return o.Version })},
+	}
+
+	level := func(get func(l models.OrganizerLevel) interface{}) gql.BatchResolver {
+		return gql.Property(func(p interface{}) interface{} { return get(p.(models.OrganizerLevel)) })
+	}
+	t.organizerLevel.Fields = []*gql.Field{
+		{Name: "id", Type: nonNull(idType), Resolve: level(func(l models.OrganizerLevel) interface{} { return l.ID })},
+		{Name: "name", Type: nonNull(gql.String), Resolve: level(func(l models.OrganizerLevel) interface{} { return l.Name })},
+		{Name: "code", Type: nonNull(gql.String), Resolve: level(func(l models.OrganizerLevel) interface{} { return l.Code })},
+	}
+
+	t.rangeType.Fields = []*gql.Field{
+		{Name: "low", Type: nonNull(amountType), Resolve: gql.Property(func(p interface{}) interface{} { return p.(models.RangeModel).LowMoney().Major() })},
+		{Name: "high", Type: nonNull(amountType), Resolve: gql.Property(func(p interface{}) interface{} { return p.(models.RangeModel).HighMoney().Major() })},
+		{Name: "currency", Type: gql.String, Description: "ISO 4217 code, it's null for the co-funding",
+			Resolve: gql.Property(func(p interface{}) interface{} {
+				if c := p.(models.RangeModel).Currency; c != "" {
+					return c
+				}
+				return nil
+			})},
+	}
+
+	competitor := func(get func(c models.Competitor) interface{}) gql.BatchResolver {
+		return gql.Property(func(p interface{}) interface{} { return get(p.(models.Competitor)) })
+	}
+	t.competitor.Fields = []*gql.Field{
+		{Name: "id", Type: nonNull(idType), Resolve: competitor(func(c models.Competitor) interface{} { return c.ID })},
+		{Name: "name", Type: nonNull(gql.String), Resolve: competitor(func(c models.Competitor) interface{} { return c.Name })},
+		{Name: "category", Type: nonNull(gql.String), Resolve: competitor(func(c models.Competitor) interface{} { return c.Category })},
+		{Name: "minAge", Type: nonNull(gql.Int), Resolve: competitor(func(c models.Competitor) interface{} { return c.MinAge })},
+		{Name: "maxAge", Type: nonNull(gql.Int), Resolve: competitor(func(c models.Competitor) interface{} { return c.MaxAge })},
+		{Name: "minDegree", Type: nonNull(gql.String), Resolve: competitor(func(c models.Competitor) interface{} { return c.MinDegree })},
+		{Name: "regions", Type: gql.ListOf(gql.String), Resolve: competitor(func(c models.Competitor) interface{} { return c.Regions })},
+		{Name: "organizationTypes", Type: gql.ListOf(gql.String),
+			Resolve: competitor(func(c models.Competitor) interface{} { return c.OrganizationTypes })},
+	}
+
+	subject := func(get func(s models.Subject) interface{}) gql.BatchResolver {
+		return gql.Property(func(p interface{}) interface{} { return get(p.(models.Subject)) })
+	}
+	t.subject.Fields = []*gql.Field{
+		{Name: "id", Type: nonNull(idType), Resolve: subject(func(s models.Subject) interface{} { return s.ID })},
+		{Name: "name", Type: nonNull(gql.String), Resolve: subject(func(s models.Subject) interface{} { return s.Name })},
+		{Name: "parent", Type: t.subject, Resolve: resolveReference(subjectKind,
+			func(p interface{}) uuid.UUID { return p.(models.Subject).Parent })},
+		{Name: "synonyms", Type: gql.ListOf(gql.String), Resolve: subject(func(s models.Subject) interface{} { return s.Synonyms })},
+		{Name: "codes", Type: gql.ListOf(t.subjectCode), Resolve: subject(func(s models.Subject) interface{} { return s.Codes })},
+	}
+
+	t.subjectCode.Fields = []*gql.Field{
+		{Name: "scheme", Type: nonNull(gql.String), Resolve: gql.Property(func(p interface{}) interface{} { return p.(models.SubjectCode).Scheme })},
+		{Name: "code", Type: nonNull(gql.String), Resolve: gql.Property(func(p interface{}) interface{} { return p.(models.SubjectCode).Code })},
+	}
+
+	return t
+}
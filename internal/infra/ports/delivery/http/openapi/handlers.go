@@ -0,0 +1,51 @@
+package openapi
+
+import (
+	"embed"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed swagger.html
+var swaggerPage string
+
+// swaggerUI - the vendored files of Swagger UI, so the page doesn't depend on a CDN
+//
+//go:embed swagger-ui/*.css swagger-ui/*.js
+var swaggerUI embed.FS
+
+// UIAssets - names of the Swagger UI files, which are served next to the page
+var UIAssets = []string{"swagger-ui.css", "swagger-ui-bundle.js"}
+
+// SpecHandler - serves the document as json
+func SpecHandler(doc *Document) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, doc)
+	}
+}
+
+// UIHandler - serves the Swagger UI page, that renders the document located at specURL,
+// assetsURL is a path where the files of UIAssets are served by AssetHandler
+func UIHandler(specURL, assetsURL string) gin.HandlerFunc {
+	page := strings.NewReplacer("{{SPEC_URL}}", specURL, "{{ASSETS_URL}}", assetsURL).Replace(swaggerPage)
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(page))
+	}
+}
+
+// AssetHandler - serves the file of Swagger UI with given name, it panics if the file is not one of UIAssets
+func AssetHandler(name string) gin.HandlerFunc {
+	data, err := swaggerUI.ReadFile(path.Join("swagger-ui", name))
+	if err != nil {
+		panic(err)
+	}
+	contentType := mime.TypeByExtension(path.Ext(name))
+
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, contentType, data)
+	}
+}
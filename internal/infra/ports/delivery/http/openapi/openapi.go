@@ -0,0 +1,322 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+
+This package builds an OpenAPI 3 document from the request/response structures
+used by the handlers, so the documentation can not drift from the code.
+
+Every delivery package describes its routes as a list of Route,
+the schemas are generated from the given values by reflection of their json tags.
+
+*/
+
+const (
+	ContentTypeJSON   = "application/json"
+	ContentTypeBinary = "application/octet-stream"
+)
+
+// Route - a description of one registered route.
+type Route struct {
+	Method  string
+	Path    string
+	Summary string
+	Tags    []string
+	// Query - names of optional query parameters
+	Query []string
+
+	// Request - a value of request body type, nil if the route has no body
+	Request            interface{}
+	RequestContentType string
+
+	// Response - a value of response body type, nil if the route returns only a status
+	Response            interface{}
+	ResponseContentType string
+	Status              int
+
+	// Auth - the route requires "Authorization: Bearer <token>" header
+	Auth bool
+	// Admin - the route requires the token of an admin user, it implies Auth
+	Admin bool
+}
+
+// bearerAuth - name of the security scheme used by the routes with Auth
+const bearerAuth = "bearerAuth"
+
+// Document - an OpenAPI 3 document.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Components struct {
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem - operations of a single path keyed by lowercase http method.
+type PathItem map[string]Operation
+
+type Operation struct {
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+func New(title, version string) *Document {
+	return &Document{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: title, Version: version},
+		Paths:   make(map[string]PathItem),
+		Components: Components{SecuritySchemes: map[string]SecurityScheme{
+			bearerAuth: {Type: "http", Scheme: "bearer"},
+		}},
+	}
+}
+
+// Add - adds all routes to the document, prefix is a path of the group they're registered in.
+func (d *Document) Add(prefix string, routes ...Route) {
+	for _, r := range routes {
+		path, params := convertPath(joinPath(prefix, r.Path))
+		for _, q := range r.Query {
+			params = append(params, Parameter{Name: q, In: "query", Schema: &Schema{Type: "string"}})
+		}
+
+		item, ok := d.Paths[path]
+		if !ok {
+			item = make(PathItem)
+			d.Paths[path] = item
+		}
+
+		op := Operation{
+			Summary:    r.Summary,
+			Tags:       r.Tags,
+			Parameters: params,
+			Responses:  make(map[string]Response),
+		}
+
+		if r.Request != nil {
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]MediaType{contentTypeOrDefault(r.RequestContentType): {Schema: SchemaOf(r.Request)}},
+			}
+		}
+
+		status := r.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		response := Response{Description: http.StatusText(status)}
+		if r.Response != nil {
+			response.Content = map[string]MediaType{contentTypeOrDefault(r.ResponseContentType): {Schema: SchemaOf(r.Response)}}
+		}
+		op.Responses[strconv.Itoa(status)] = response
+
+		if r.Auth || r.Admin {
+			op.Security = []map[string][]string{{bearerAuth: {}}}
+			op.Responses[strconv.Itoa(http.StatusUnauthorized)] = Response{Description: http.StatusText(http.StatusUnauthorized)}
+		}
+		if r.Admin {
+			op.Responses[strconv.Itoa(http.StatusForbidden)] = Response{Description: http.StatusText(http.StatusForbidden)}
+		}
+
+		item[strings.ToLower(r.Method)] = op
+	}
+}
+
+// Has - reports if the document contains an operation for gin-style path and method.
+func (d *Document) Has(method, ginPath string) bool {
+	path, _ := convertPath(ginPath)
+	item, ok := d.Paths[path]
+	if !ok {
+		return false
+	}
+	_, ok = item[strings.ToLower(method)]
+	return ok
+}
+
+// Operations - returns "METHOD path" of all documented operations in a stable order.
+func (d *Document) Operations() []string {
+	result := make([]string, 0)
+	for path, item := range d.Paths {
+		for method := range item {
+			result = append(result, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func contentTypeOrDefault(t string) string {
+	if t == "" {
+		return ContentTypeJSON
+	}
+	return t
+}
+
+func joinPath(prefix, path string) string {
+	parts := make([]string, 0, 2)
+	for _, p := range []string{prefix, path} {
+		if p = strings.Trim(p, "/"); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// convertPath - converts gin path(/event/:id) into OpenAPI one(/event/{id}) and collects its parameters
+func convertPath(ginPath string) (string, []Parameter) {
+	segments := strings.Split(ginPath, "/")
+	params := make([]Parameter, 0)
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") || strings.HasPrefix(s, "*") {
+			name := s[1:]
+			segments[i] = "{" + name + "}"
+			params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+	}
+	if len(params) == 0 {
+		params = nil
+	}
+	return strings.Join(segments, "/"), params
+}
+
+var (
+	uuidType = reflect.TypeOf(uuid.UUID{})
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+// SchemaOf - generates a schema of the value's type using its json tags.
+func SchemaOf(v interface{}) *Schema {
+	return schemaOfType(reflect.TypeOf(v), make(map[reflect.Type]bool))
+}
+
+// schemaOfType - visiting contains structs being described, a recursive struct is described
+// as an object without properties on the second level, because schemas are inlined.
+func schemaOfType(t reflect.Type, visiting map[reflect.Type]bool) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "binary"}
+		}
+		return &Schema{Type: "array", Items: schemaOfType(t.Elem(), visiting)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaOfType(t.Elem(), visiting)}
+	case reflect.Struct:
+		if visiting[t] {
+			return &Schema{Type: "object"}
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		addStructFields(s, t, visiting)
+		return s
+	}
+	return &Schema{}
+}
+
+func addStructFields(s *Schema, t reflect.Type, visiting map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addStructFields(s, ft, visiting)
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		s.Properties[name] = schemaOfType(f.Type, visiting)
+	}
+}
@@ -11,6 +11,10 @@ import (
 	"github.com/indigowar/map-of-events/pkg/random"
 )
 
+type uploadResponse struct {
+	Link string `json:"link"`
+}
+
 func UploadHandler(svc services.ImageService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		image, err := ioutil.ReadAll(c.Request.Body)
@@ -22,9 +26,7 @@ func UploadHandler(svc services.ImageService) gin.HandlerFunc {
 
 		result, err := svc.Create(c, random.RandStringRunes(10), image)
 
-		c.JSON(http.StatusCreated, gin.H{
-			"link": result.Link,
-		})
+		c.JSON(http.StatusCreated, uploadResponse{Link: result.Link})
 	}
 }
 
@@ -0,0 +1,18 @@
+package files
+
+import (
+	"net/http"
+
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/openapi"
+)
+
+// Routes - describes routes of this package for the OpenAPI document
+func Routes() []openapi.Route {
+	image := []string{"image"}
+
+	return []openapi.Route{
+		{Method: http.MethodPost, Path: "/image", Summary: "Uploads an image, returns its link", Tags: image,
+			Request: []byte{}, RequestContentType: openapi.ContentTypeBinary, Response: uploadResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/image/:link", Summary: "Returns an image by its link", Tags: image, Response: ""},
+	}
+}
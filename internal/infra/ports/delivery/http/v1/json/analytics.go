@@ -0,0 +1,163 @@
+package json
+
+import (
+	"encoding/csv"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/validation"
+)
+
+const (
+	mimeCSV = "text/csv"
+
+	// dateLayout - layout of the dates in the query
+	dateLayout = "2006-01-02"
+)
+
+type analyticsGroupView struct {
+	// Key - id of the organizer, level, subject or competitor, TRL or month of the deadline(2006-01),
+	// it's empty for the events without a subject or a competitor
+	Key    string `json:"key"`
+	Name   string `json:"name,omitempty"`
+	Events int    `json:"events"`
+	// FoundingLow, FoundingHigh - in the major units of the reference currency, the founding ranges
+	// in the currencies without a rate are not summed
+	FoundingLow    int64  `json:"foundingLow"`
+	FoundingHigh   int64  `json:"foundingHigh"`
+	Currency       string `json:"currency"`
+	CoFoundingLow  int64  `json:"coFoundingLow"`
+	CoFoundingHigh int64  `json:"coFoundingHigh"`
+}
+
+type analyticsResponse struct {
+	Dimension string               `json:"dimension"`
+	Groups    []analyticsGroupView `json:"groups"`
+}
+
+// analyticsQuery - names of the query parameters of the analytics
+var analyticsQuery = []string{"open", "from", "to", "format"}
+
+// AnalyticsHandler - counts the events and sums their ranges by the groups of the dimension,
+// it responds with CSV for ?format=csv or Accept: text/csv
+func AnalyticsHandler(svc services.AnalyticsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter, ok := parseAnalyticsFilter(c)
+		if !ok {
+			return
+		}
+
+		dimension := c.Param("dimension")
+		groups, err := svc.Aggregate(c, dimension, filter)
+		if err != nil {
+			if validation.WriteError(c, err) {
+				return
+			}
+			log.Println(err)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		if c.Query("format") == "csv" || (c.Query("format") == "" && c.NegotiateFormat(gin.MIMEJSON, mimeCSV) == mimeCSV) {
+			writeAnalyticsCSV(c, dimension, groups)
+			return
+		}
+
+		response := analyticsResponse{Dimension: dimension, Groups: make([]analyticsGroupView, len(groups))}
+		for i, g := range groups {
+			response.Groups[i] = analyticsGroupView{
+				Key:            g.Key,
+				Name:           g.Name,
+				Events:         g.Events,
+				FoundingLow:    models.Money{Amount: g.FoundingLow, Currency: g.Currency}.Major(),
+				FoundingHigh:   models.Money{Amount: g.FoundingHigh, Currency: g.Currency}.Major(),
+				Currency:       g.Currency,
+				CoFoundingLow:  g.CoFoundingLow,
+				CoFoundingHigh: g.CoFoundingHigh,
+			}
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// parseAnalyticsFilter - ?open=true counts only the events with the deadline in the future,
+// from and to(2006-01-02) bound the deadline, to is exclusive
+func parseAnalyticsFilter(c *gin.Context) (models.AnalyticsFilter, bool) {
+	violations := make(validators.Violations, 0)
+
+	date := func(name string) time.Time {
+		value := c.Query(name)
+		if value == "" {
+			return time.Time{}
+		}
+		t, err := time.Parse(dateLayout, value)
+		if err != nil {
+			violations = append(violations, validators.Violation{Field: name, Message: "should be a date like 2006-01-02"})
+		}
+		return t
+	}
+
+	filter := models.AnalyticsFilter{DeadlineFrom: date("from"), DeadlineTo: date("to")}
+
+	if value := c.Query("open"); value != "" {
+		open, err := strconv.ParseBool(value)
+		if err != nil {
+			violations = append(violations, validators.Violation{Field: "open", Message: "should be true or false"})
+		}
+		if now := time.Now().UTC(); open && filter.DeadlineFrom.Before(now) {
+			filter.DeadlineFrom = now
+		}
+	}
+
+	if format := c.Query("format"); format != "" && format != "json" && format != "csv" {
+		violations = append(violations, validators.Violation{Field: "format", Message: "should be json or csv"})
+	}
+
+	if len(violations) != 0 {
+		validation.WriteError(c, violations)
+		return models.AnalyticsFilter{}, false
+	}
+	return filter, true
+}
+
+func writeAnalyticsCSV(c *gin.Context, dimension string, groups []models.AnalyticsGroup) {
+	c.Header("Content-Type", mimeCSV+"; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="analytics-`+dimension+`.csv"`)
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write([]string{dimension, "name", "events", "founding_low", "founding_high", "currency", "co_founding_low", "co_founding_high"})
+	for _, g := range groups {
+		_ = w.Write([]string{
+			csvText(g.Key),
+			csvText(g.Name),
+			strconv.Itoa(g.Events),
+			strconv.FormatInt(models.Money{Amount: g.FoundingLow, Currency: g.Currency}.Major(), 10),
+			strconv.FormatInt(models.Money{Amount: g.FoundingHigh, Currency: g.Currency}.Major(), 10),
+			csvText(g.Currency),
+			strconv.FormatInt(g.CoFoundingLow, 10),
+			strconv.FormatInt(g.CoFoundingHigh, 10),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		log.Println(err)
+	}
+}
+
+// csvText - a text cell of CSV, the text starting like a formula is prefixed with a quote,
+// so a spreadsheet shows the names of organizers and subjects instead of evaluating them
+func csvText(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
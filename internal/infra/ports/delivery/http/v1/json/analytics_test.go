@@ -0,0 +1,55 @@
+package json
+
+import (
+	"encoding/csv"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+)
+
+func TestWriteAnalyticsCSVEscapesFormulas(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name, cell string
+	}{
+		{"=HYPERLINK(\"http://evil\")", "'=HYPERLINK(\"http://evil\")"},
+		{"+1+1", "'+1+1"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"Фонд = наука", "Фонд = наука"},
+		{"", ""},
+	}
+
+	groups := make([]models.AnalyticsGroup, len(tests))
+	for i, tt := range tests {
+		groups[i] = models.AnalyticsGroup{Key: tt.name, Name: tt.name, Events: 1, Currency: "RUB"}
+	}
+
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	writeAnalyticsCSV(c, "organizer", groups)
+
+	records, err := csv.NewReader(recorder.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("the response is not CSV: %v", err)
+	}
+	if len(records) != len(tests)+1 {
+		t.Fatalf("the response has %d records, want %d", len(records), len(tests)+1)
+	}
+
+	for i, tt := range tests {
+		record := records[i+1]
+		if got := []string{record[0], record[1]}; !reflect.DeepEqual(got, []string{tt.cell, tt.cell}) {
+			t.Errorf("cells of %q = %q, want %q", tt.name, got, tt.cell)
+		}
+		if record[2] != "1" {
+			t.Errorf("events of %q = %q, want the number as it is", tt.name, record[2])
+		}
+	}
+}
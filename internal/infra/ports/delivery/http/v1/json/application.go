@@ -0,0 +1,272 @@
+package json
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/validation"
+)
+
+type stageChangeView struct {
+	Stage     string    `json:"stage"`
+	ChangedAt time.Time `json:"changedAt"`
+}
+
+type applicationNoteView struct {
+	ID        uuid.UUID `json:"id"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type applicationFileView struct {
+	Link       string    `json:"link"`
+	Name       string    `json:"name"`
+	AttachedAt time.Time `json:"attachedAt"`
+}
+
+type applicationView struct {
+	ID        uuid.UUID             `json:"id"`
+	Event     uuid.UUID             `json:"event"`
+	Team      uuid.UUID             `json:"team"`
+	Stage     string                `json:"stage"`
+	CreatedAt time.Time             `json:"createdAt"`
+	History   []stageChangeView     `json:"history"`
+	Notes     []applicationNoteView `json:"notes"`
+	Files     []applicationFileView `json:"files"`
+}
+
+type createApplicationRequest struct {
+	Event uuid.UUID `json:"event"`
+	Team  uuid.UUID `json:"team"`
+}
+
+type changeStageRequest struct {
+	Stage string `json:"stage"`
+}
+
+type addNoteRequest struct {
+	Text string `json:"text"`
+}
+
+type attachFileRequest struct {
+	Name string `json:"name"`
+	Link string `json:"link"`
+}
+
+func buildApplicationView(a models.Application) applicationView {
+	view := applicationView{
+		ID:        a.ID,
+		Event:     a.Event,
+		Team:      a.Team,
+		Stage:     a.Stage,
+		CreatedAt: a.CreatedAt,
+		History:   make([]stageChangeView, len(a.History)),
+		Notes:     make([]applicationNoteView, len(a.Notes)),
+		Files:     make([]applicationFileView, len(a.Files)),
+	}
+	for i, v := range a.History {
+		view.History[i] = stageChangeView{Stage: v.Stage, ChangedAt: v.ChangedAt}
+	}
+	for i, v := range a.Notes {
+		view.Notes[i] = applicationNoteView{ID: v.ID, Text: v.Text, CreatedAt: v.CreatedAt}
+	}
+	for i, v := range a.Files {
+		view.Files[i] = applicationFileView{Link: v.Link, Name: v.Name, AttachedAt: v.AttachedAt}
+	}
+	return view
+}
+
+// writeApplicationError - responds with the status matching err, validation errors are responded with violations
+func writeApplicationError(c *gin.Context, err error) {
+	switch {
+	case validation.WriteError(c, err):
+	case errors.Is(err, adapters.ErrNotFound):
+		c.Status(http.StatusNotFound)
+	case errors.Is(err, services.ErrApplicationExists):
+		c.JSON(http.StatusConflict, gin.H{"msg": err.Error()})
+	default:
+		c.Status(http.StatusInternalServerError)
+	}
+}
+
+func GetApplicationByIDHandler(svc services.ApplicationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		application, err := svc.GetByID(c, id)
+		if err != nil {
+			log.Println(err)
+			writeApplicationError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, buildApplicationView(application))
+	}
+}
+
+// GetEventApplicationsHandler - returns all applications to the event
+func GetEventApplicationsHandler(svc services.ApplicationService) gin.HandlerFunc {
+	return applicationListHandler(func(ctx context.Context, id uuid.UUID) ([]models.Application, error) {
+		return svc.GetAllForEvent(ctx, id)
+	})
+}
+
+// GetTeamApplicationsHandler - returns all applications of the team(profile)
+func GetTeamApplicationsHandler(svc services.ApplicationService) gin.HandlerFunc {
+	return applicationListHandler(func(ctx context.Context, id uuid.UUID) ([]models.Application, error) {
+		return svc.GetAllForTeam(ctx, id)
+	})
+}
+
+func applicationListHandler(list func(ctx context.Context, id uuid.UUID) ([]models.Application, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		applications, err := list(c, id)
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		result := make([]applicationView, len(applications))
+		for i, v := range applications {
+			result[i] = buildApplicationView(v)
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func CreateApplicationHandler(svc services.ApplicationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request createApplicationRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		application, err := svc.Create(c, request.Event, request.Team)
+		if err != nil {
+			log.Println(err)
+			writeApplicationError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, buildApplicationView(application))
+	}
+}
+
+func ChangeApplicationStageHandler(svc services.ApplicationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		var request changeStageRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		application, err := svc.ChangeStage(c, id, request.Stage)
+		if err != nil {
+			log.Println(err)
+			writeApplicationError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, buildApplicationView(application))
+	}
+}
+
+func AddApplicationNoteHandler(svc services.ApplicationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		var request addNoteRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		note, err := svc.AddNote(c, id, request.Text)
+		if err != nil {
+			log.Println(err)
+			writeApplicationError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, applicationNoteView{ID: note.ID, Text: note.Text, CreatedAt: note.CreatedAt})
+	}
+}
+
+// AttachApplicationFileHandler - attaches a file uploaded with POST /image to the application
+func AttachApplicationFileHandler(svc services.ApplicationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		var request attachFileRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		file, err := svc.AttachFile(c, id, request.Name, request.Link)
+		if err != nil {
+			log.Println(err)
+			writeApplicationError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, applicationFileView{Link: file.Link, Name: file.Name, AttachedAt: file.AttachedAt})
+	}
+}
+
+func DeleteApplicationHandler(svc services.ApplicationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		if err := svc.Delete(c, id); err != nil {
+			log.Println(err)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+}
@@ -0,0 +1,120 @@
+package json
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+const (
+	// streamHeartbeat - a comment is sent so often, so proxies do not close an idle stream
+	streamHeartbeat = 15 * time.Second
+	// streamRetry - the clients reconnect after it when the stream has ended
+	streamRetry = time.Second
+
+	// streamReady - the replayed changes are sent, the following ones are live
+	streamReady = "ready"
+	// streamReset - some changes after Last-Event-ID are lost, the client should reload the objects
+	streamReset = "reset"
+)
+
+// changeView - data of a change in the stream, it's an event in minimal version, an organizer,
+// or only the id for deleted events
+type changeView struct {
+	Type       string      `json:"type"`
+	ID         uuid.UUID   `json:"id"`
+	OccurredAt time.Time   `json:"occurredAt"`
+	Data       interface{} `json:"data"`
+}
+
+type deletedEventView struct {
+	ID uuid.UUID `json:"id"`
+}
+
+func (h *EventHandler) buildChangeView(change services.StreamedChange) changeView {
+	view := changeView{Type: change.Type, ID: change.ObjectID, OccurredAt: change.OccurredAt}
+
+	switch o := change.Object.(type) {
+	case models.Event:
+		if change.Type == models.ChangeEventDeleted {
+			view.Data = deletedEventView{ID: o.ID}
+		} else {
+			view.Data = h.buildMinimalView(o, change.Facts.Subjects)
+		}
+	case models.Organizer:
+		view.Data = organizerBinding{o.ID, o.Name, o.Logo, o.Level, o.Version}
+	}
+	return view
+}
+
+// Stream - streams changes of events and organizers satisfying the filter as Server-Sent Events,
+// the id of every event is the position in the stream, it's resumed after Last-Event-ID header(or lastEventId parameter)
+func (h *EventHandler) Stream(c *gin.Context) {
+	filter, ok := parseEventFilter(c)
+	if !ok {
+		return
+	}
+
+	var lastSeq uint64
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("lastEventId")
+	}
+	if lastEventID != "" {
+		var err error
+		if lastSeq, err = strconv.ParseUint(lastEventID, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"msg": "Last-Event-ID should be an id of a streamed event"})
+			return
+		}
+	}
+
+	subscription := h.svc.ChangeStream.Subscribe(c, filter, lastSeq)
+	defer subscription.Cancel()
+
+	c.Header("Content-Type", sse.ContentType)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	_, _ = fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetry.Milliseconds())
+
+	if subscription.Missed {
+		c.Render(-1, sse.Event{Id: formatSeq(subscription.Last), Event: streamReset, Data: ""})
+	} else {
+		for _, change := range subscription.Replay {
+			c.Render(-1, sse.Event{Id: formatSeq(change.Seq), Event: change.Type, Data: h.buildChangeView(change)})
+		}
+		c.Render(-1, sse.Event{Id: formatSeq(subscription.Last), Event: streamReady, Data: ""})
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case change, ok := <-subscription.Changes:
+			if !ok {
+				return false
+			}
+			c.Render(-1, sse.Event{Id: formatSeq(change.Seq), Event: change.Type, Data: h.buildChangeView(change)})
+			return true
+		case <-heartbeat.C:
+			_, _ = io.WriteString(w, ": heartbeat\n\n")
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+func formatSeq(seq uint64) string {
+	return strconv.FormatUint(seq, 10)
+}
@@ -1,18 +1,111 @@
 package json
 
 import (
+	"bytes"
+	"encoding/json"
 	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"github.com/indigowar/map-of-events/internal/domain/models"
 	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/validation"
+	"github.com/indigowar/map-of-events/pkg/errors"
 )
 
 type Competitor struct {
-	Id             uuid.UUID `json:"id"`
-	CompetitorName string    `json:"name"`
+	Id                uuid.UUID `json:"id"`
+	CompetitorName    string    `json:"name"`
+	Category          string    `json:"category"`
+	MinAge            int       `json:"minAge"`
+	MaxAge            int       `json:"maxAge"`
+	MinDegree         string    `json:"minDegree"`
+	Regions           []string  `json:"regions"`
+	OrganizationTypes []string  `json:"organizationTypes"`
+}
+
+type competitorRequest struct {
+	Name              string   `json:"name"`
+	Category          string   `json:"category"`
+	MinAge            int      `json:"minAge"`
+	MaxAge            int      `json:"maxAge"`
+	MinDegree         string   `json:"minDegree"`
+	Regions           []string `json:"regions"`
+	OrganizationTypes []string `json:"organizationTypes"`
+}
+
+func (r competitorRequest) info() services.CompetitorInfo {
+	return services.CompetitorInfo{
+		Name:              r.Name,
+		Category:          r.Category,
+		MinAge:            r.MinAge,
+		MaxAge:            r.MaxAge,
+		MinDegree:         r.MinDegree,
+		Regions:           r.Regions,
+		OrganizationTypes: r.OrganizationTypes,
+	}
+}
+
+func buildCompetitor(c models.Competitor) Competitor {
+	return Competitor{
+		Id:                c.ID,
+		CompetitorName:    c.Name,
+		Category:          c.Category,
+		MinAge:            c.MinAge,
+		MaxAge:            c.MaxAge,
+		MinDegree:         c.MinDegree,
+		Regions:           c.Regions,
+		OrganizationTypes: c.OrganizationTypes,
+	}
+}
+
+// bindCompetitorRequest - the request is a competitor object or just a name string(the old format)
+func bindCompetitorRequest(c *gin.Context) (competitorRequest, bool) {
+	body, err := c.GetRawData()
+	if err != nil {
+		log.Println(err)
+		c.Status(http.StatusBadRequest)
+		return competitorRequest{}, false
+	}
+
+	var request competitorRequest
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte(`"`)) {
+		err = json.Unmarshal(body, &request.Name)
+	} else {
+		err = json.Unmarshal(body, &request)
+	}
+	if err != nil {
+		log.Println(err)
+		c.Status(http.StatusBadRequest)
+		return competitorRequest{}, false
+	}
+	return request, true
+}
+
+// writeCompetitorError - responds with the status matching the reason of err
+func writeCompetitorError(c *gin.Context, err errors.Error) {
+	switch err.Reason() {
+	case services.ErrReasonInternalError:
+		log.Println(err.LongErr())
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"msg": err.ShortErr(),
+		})
+	case services.ErrReasonAlreadyExist:
+		log.Println(err.LongErr())
+		c.JSON(http.StatusConflict, gin.H{
+			"msg": err.ShortErr(),
+		})
+	case services.ErrReasonNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"msg": err.ShortErr(),
+		})
+	case services.ErrReasonValidationFailed:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": err.ShortErr(),
+		})
+	}
 }
 
 func GetAllCompetitorsHandler(svc services.CompetitorService) func(c *gin.Context) {
@@ -20,52 +113,82 @@ func GetAllCompetitorsHandler(svc services.CompetitorService) func(c *gin.Contex
 		comps, err := svc.GetAll(c)
 
 		if err != nil {
-			switch err.Reason() {
-			case services.ErrReasonInternalError:
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"msg": err.ShortErr(),
-				})
-				break
-			}
+			writeCompetitorError(c, err)
 			return
 		}
 
 		result := make([]Competitor, len(comps))
 		for i, v := range comps {
-			result[i] = Competitor{v.ID, v.Name}
+			result[i] = buildCompetitor(v)
 		}
 		c.JSON(http.StatusOK, result)
 	}
 }
 
-func CreateCompetitorHandler(srv services.CompetitorService) func(c *gin.Context) {
+func GetCompetitorByIDHandler(svc services.CompetitorService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var name string
-		if err := c.ShouldBindJSON(&name); err != nil {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
 			log.Println(err)
 			c.Status(http.StatusBadRequest)
 			return
 		}
 
-		obj, err := srv.Create(c, name)
+		competitor, e := svc.GetByID(c, id)
+		if e != nil {
+			log.Println(e.LongErr())
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusOK, buildCompetitor(competitor))
+	}
+}
 
+func CreateCompetitorHandler(srv services.CompetitorService) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		request, ok := bindCompetitorRequest(c)
+		if !ok {
+			return
+		}
+
+		if validation.WriteError(c, request.info().Validate()) {
+			return
+		}
+
+		obj, err := srv.Create(c, request.info())
 		if err != nil {
-			switch err.Reason() {
-			case services.ErrReasonInternalError:
-				log.Println(err.LongErr())
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"msg": err.ShortErr(),
-				})
-				break
-			case services.ErrReasonAlreadyExist:
-				log.Println(err.LongErr())
-				c.JSON(http.StatusConflict, gin.H{
-					"msg": err.ShortErr(),
-				})
-				break
-			}
+			writeCompetitorError(c, err)
+			return
+		}
+		c.JSON(http.StatusAccepted, buildCompetitor(obj))
+	}
+}
+
+func UpdateCompetitorHandler(srv services.CompetitorService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		var request competitorRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		if validation.WriteError(c, request.info().Validate()) {
+			return
+		}
+
+		obj, e := srv.Update(c, id, request.info())
+		if e != nil {
+			writeCompetitorError(c, e)
 			return
 		}
-		c.JSON(http.StatusAccepted, Competitor{obj.ID, obj.Name})
+		c.JSON(http.StatusOK, buildCompetitor(obj))
 	}
 }
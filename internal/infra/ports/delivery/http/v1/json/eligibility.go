@@ -0,0 +1,66 @@
+package json
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/validation"
+)
+
+type applicantProfileView struct {
+	Category         string `json:"category"`
+	Age              int    `json:"age"`
+	Degree           string `json:"degree"`
+	Region           string `json:"region"`
+	OrganizationType string `json:"organizationType"`
+}
+
+func (v applicantProfileView) profile() models.ApplicantProfile {
+	return models.ApplicantProfile{
+		Category:         v.Category,
+		Age:              v.Age,
+		Degree:           v.Degree,
+		Region:           v.Region,
+		OrganizationType: v.OrganizationType,
+	}
+}
+
+type eligibilityView struct {
+	EventID  uuid.UUID `json:"eventId"`
+	Title    string    `json:"title"`
+	Eligible bool      `json:"eligible"`
+	Reasons  []string  `json:"reasons"`
+}
+
+// EligibilityCheckHandler - checks the applicant profile from the body against competitors of all events
+func EligibilityCheckHandler(svc services.EligibilityService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request applicantProfileView
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		eligibility, err := svc.Check(c, request.profile())
+		if err != nil {
+			log.Println(err)
+			if validation.WriteError(c, err) {
+				return
+			}
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		result := make([]eligibilityView, len(eligibility))
+		for i, v := range eligibility {
+			result[i] = eligibilityView{EventID: v.EventID, Title: v.Title, Eligible: v.Eligible, Reasons: v.Reasons}
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,57 @@
+package json
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+// setETag - sets ETag of the response from a version of the object
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// ifMatchVersion - reads an expected version of the object from the required If-Match header,
+// if it's missing or malformed, it responds and returns false
+func ifMatchVersion(c *gin.Context) (int, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"msg": "If-Match header is required"})
+		return 0, false
+	}
+
+	if header == "*" {
+		return services.AnyVersion, true
+	}
+
+	// If-Match uses the strong comparison(RFC 9110, 13.1.1), so a weak tag never matches
+	if strings.HasPrefix(header, "W/") {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"msg": "If-Match requires a strong entity tag"})
+		return 0, false
+	}
+
+	tag, err := strconv.Unquote(header)
+	if err == nil {
+		var version int
+		if version, err = strconv.Atoi(tag); err == nil && version >= 0 {
+			return version, true
+		}
+	}
+
+	c.JSON(http.StatusPreconditionFailed, gin.H{"msg": "If-Match header does not match the object"})
+	return 0, false
+}
+
+// writeVersionMismatch - if err is services.ErrVersionMismatch, responds with 412 and returns true
+func writeVersionMismatch(c *gin.Context, err error) bool {
+	if !errors.Is(err, services.ErrVersionMismatch) {
+		return false
+	}
+	c.JSON(http.StatusPreconditionFailed, gin.H{"msg": err.Error()})
+	return true
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/indigowar/map-of-events/internal/domain/models"
 	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/validation"
+	"github.com/indigowar/map-of-events/pkg/mergepatch"
 )
 
 type EventHandler struct {
@@ -22,12 +25,23 @@ func NewEventHandler(s services.Services) EventHandler {
 }
 
 func (h *EventHandler) GetAllEvents(c *gin.Context) {
-	ids, err := h.svc.Event.GetAll(c)
-	if err != nil {
-		c.Status(http.StatusInternalServerError)
+	events, ok := findSortedEvents(c, h.svc.Event)
+	if !ok {
+		return
+	}
+
+	result, status := h.serializeAll(c, events, h.buildView)
+	if status != 0 {
+		c.Status(status)
+		return
+	}
+
+	if status := h.markFavourites(c, result); status != 0 {
+		c.Status(status)
 		return
 	}
-	c.JSON(http.StatusOK, ids)
+
+	c.JSON(http.StatusOK, result)
 }
 
 func (h *EventHandler) GetEventByID(c *gin.Context) {
@@ -43,7 +57,14 @@ func (h *EventHandler) GetEventByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	views := []interface{}{result}
+	if status := h.markFavourites(c, views); status != 0 {
+		c.Status(status)
+		return
+	}
+
+	setETag(c, result.(eventJSONView).Version)
+	c.JSON(http.StatusOK, views[0])
 }
 
 func (h *EventHandler) DeleteEvent(c *gin.Context) {
@@ -53,8 +74,16 @@ func (h *EventHandler) DeleteEvent(c *gin.Context) {
 		return
 	}
 
-	err = h.svc.Event.Delete(c, id)
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	err = h.svc.Event.Delete(c, id, version)
 	if err != nil {
+		if writeVersionMismatch(c, err) {
+			return
+		}
 		c.Status(http.StatusBadRequest)
 		return
 	}
@@ -62,17 +91,20 @@ func (h *EventHandler) DeleteEvent(c *gin.Context) {
 }
 
 func (h *EventHandler) GetAllAsMinimal(c *gin.Context) {
-	ids, err := h.svc.Event.AllIDs(c)
-	if err != nil {
-		log.Println(err)
-		c.Status(http.StatusInternalServerError)
+	events, ok := findSortedEvents(c, h.svc.Event)
+	if !ok {
 		return
 	}
 
-	result := make([]interface{}, len(ids))
+	result, status := h.serializeAll(c, events, h.buildMinimalView)
+	if status != 0 {
+		c.Status(status)
+		return
+	}
 
-	for i, id := range ids {
-		result[i], _ = h.getAndSerialize(c, id, h.buildMinimalView)
+	if status := h.markFavourites(c, result); status != 0 {
+		c.Status(status)
+		return
 	}
 
 	c.JSON(http.StatusOK, result)
@@ -92,7 +124,13 @@ func (h *EventHandler) GetByIDMinimal(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	views := []interface{}{result}
+	if status := h.markFavourites(c, views); status != 0 {
+		c.Status(status)
+		return
+	}
+
+	c.JSON(http.StatusOK, views[0])
 }
 
 func (h *EventHandler) Create(c *gin.Context) {
@@ -103,9 +141,15 @@ func (h *EventHandler) Create(c *gin.Context) {
 		return
 	}
 
-	event, err := h.svc.Event.Create(c, h.createInfoFromView(info))
+	// the service reports the violations of the fields together with the missing referenced objects
+	createInfo := h.createInfoFromView(info)
+
+	event, err := h.svc.Event.Create(c, createInfo)
 	if err != nil {
 		log.Println(err)
+		if validation.WriteError(c, err) {
+			return
+		}
 		c.Status(http.StatusInternalServerError)
 		return
 	}
@@ -116,6 +160,7 @@ func (h *EventHandler) Create(c *gin.Context) {
 		return
 	}
 
+	setETag(c, result.(eventJSONView).Version)
 	c.JSON(http.StatusCreated, result)
 }
 
@@ -127,6 +172,11 @@ func (h *EventHandler) Update(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	var info createInfoView
 	if err := c.ShouldBindJSON(&info); err != nil {
 		log.Println(err)
@@ -134,9 +184,14 @@ func (h *EventHandler) Update(c *gin.Context) {
 		return
 	}
 
-	_, err = h.svc.Event.Update(c, id, h.createInfoFromView(info))
+	createInfo := h.createInfoFromView(info)
+
+	_, err = h.svc.Event.Update(c, id, version, createInfo)
 	if err != nil {
 		log.Println(err)
+		if validation.WriteError(c, err) || writeVersionMismatch(c, err) {
+			return
+		}
 		c.Status(http.StatusInternalServerError)
 		return
 	}
@@ -147,17 +202,120 @@ func (h *EventHandler) Update(c *gin.Context) {
 		return
 	}
 
+	setETag(c, result.(eventJSONView).Version)
 	c.JSON(http.StatusAccepted, result)
 }
 
+// Patch - updates only the fields of event given in a JSON Merge Patch(RFC 7396)
+func (h *EventHandler) Patch(c *gin.Context) {
+	id, err := h.parseIDFromParam(c)
+	if err != nil {
+		log.Println(err)
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	patch, err := readMergePatch(c)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	event, err := h.svc.Event.GetByID(c, id)
+	if err != nil {
+		log.Println(err)
+		c.Status(http.StatusNotFound)
+		return
+	}
+
+	// the patch is applied to the state the client has seen
+	if !services.VersionMatches(version, event.Version) {
+		writeVersionMismatch(c, services.ErrVersionMismatch)
+		return
+	}
+
+	current, status := h.getAndSerialize(c, id, h.buildCreateInfoView)
+	if status != 0 {
+		c.Status(status)
+		return
+	}
+
+	info := current.(createInfoView)
+	if err := mergepatch.ApplyTo(&info, patch); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"msg": err.Error()})
+		return
+	}
+
+	createInfo := h.createInfoFromView(info)
+
+	if _, err := h.svc.Event.Update(c, id, event.Version, createInfo); err != nil {
+		log.Println(err)
+		if validation.WriteError(c, err) || writeVersionMismatch(c, err) {
+			return
+		}
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	result, status := h.getAndSerialize(c, id, h.buildView)
+	if status != 0 {
+		c.Status(status)
+		return
+	}
+
+	setETag(c, result.(eventJSONView).Version)
+	c.JSON(http.StatusOK, result)
+}
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// Search - full-text search over events, query is given in "q", amount of results in "limit"
+func (h *EventHandler) Search(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": "query parameter q is required"})
+		return
+	}
+
+	limit, ok := parseLimit(c, defaultSearchLimit, maxSearchLimit)
+	if !ok {
+		return
+	}
+
+	hits, err := h.svc.Event.Search(c, query, limit)
+	if err != nil {
+		log.Println(err)
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	result := make([]eventSearchJSONView, len(hits))
+	for i, v := range hits {
+		result[i] = eventSearchJSONView{ID: v.EventID, Title: v.Title, Rank: v.Rank, Snippet: v.Snippet}
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 type createInfoView struct {
-	Title               string      `json:"title"`
-	Organizer           uuid.UUID   `json:"organizer"`
-	FoundingType        string      `json:"foundingType"`
-	FoundingRangeLow    int         `json:"foundingRangeLow"`
-	FoundingRangeHigh   int         `json:"foundingRangeHigh"`
-	CoFoundingRangeLow  int         `json:"coFoundingRangeLow"`
-	CoFoundingRangeHigh int         `json:"coFoundingRangeHigh"`
+	Title             string    `json:"title"`
+	Organizer         uuid.UUID `json:"organizer"`
+	FoundingType      string    `json:"foundingType"`
+	FoundingRangeLow  int64     `json:"foundingRangeLow"`
+	FoundingRangeHigh int64     `json:"foundingRangeHigh"`
+	// FoundingCurrency - ISO 4217 code of the founding range given in its major units, the reference currency if it's empty
+	FoundingCurrency    string      `json:"foundingCurrency"`
+	CoFoundingRangeLow  int64       `json:"coFoundingRangeLow"`
+	CoFoundingRangeHigh int64       `json:"coFoundingRangeHigh"`
 	SubmissionDeadline  time.Time   `json:"submissionDeadline"`
 	ConsiderationPeriod string      `json:"considerationPeriod"`
 	RealisationPeriod   string      `json:"realisationPeriod"`
@@ -181,25 +339,19 @@ func (h *EventHandler) parseIDFromParam(c *gin.Context) (uuid.UUID, error) {
 	return id, nil
 }
 
-func (h *EventHandler) buildView(e models.Event, f, cf models.RangeModel, subs []models.Subject) interface{} {
+func (h *EventHandler) buildView(e models.Event, subs []models.Subject) interface{} {
 	s := make([]string, len(subs))
 	for i, v := range subs {
 		s[i] = v.Name
 	}
 
 	return eventJSONView{
-		ID:           e.ID,
-		Title:        e.Title,
-		Organizer:    e.Organizer,
-		FoundingType: e.FoundingType,
-		FoundingRange: rangeJSONView{
-			Low:  f.Low,
-			High: f.High,
-		},
-		CoFoundingRange: rangeJSONView{
-			Low:  cf.Low,
-			High: cf.High,
-		},
+		ID:                  e.ID,
+		Title:               e.Title,
+		Organizer:           e.Organizer,
+		FoundingType:        e.FoundingType,
+		FoundingRange:       newRangeJSONView(e.FoundingRange),
+		CoFoundingRange:     newRangeJSONView(e.CoFoundingRange),
 		SubmissionDeadline:  e.SubmissionDeadline,
 		ConsiderationPeriod: e.ConsiderationPeriod,
 		RealisationPeriod:   e.RealisationPeriod,
@@ -210,6 +362,7 @@ func (h *EventHandler) buildView(e models.Event, f, cf models.RangeModel, subs [
 		TRL:                 e.TRL,
 		Competitors:         e.Competitors,
 		Subjects:            s,
+		Version:             e.Version,
 	}
 }
 
@@ -221,53 +374,84 @@ func (h *EventHandler) getAndSerialize(ctx context.Context, id uuid.UUID, serial
 		return nil, http.StatusNotFound
 	}
 
-	// load info about it's founding range
-	foundingRange, err := h.svc.FoundingRange.GetByID(ctx, event.FoundingRange)
+	// load info about it's subjects
+	subjects, err := h.svc.Subject.GetAllForEvent(ctx, event.ID)
 	if err != nil {
 		log.Println(err)
 		return nil, http.StatusInternalServerError
 	}
 
-	// load info about it's co-founding range
-	coFoundingRange, err := h.svc.CoFoundingRange.GetByID(ctx, event.CoFoundingRange)
-	if err != nil {
-		log.Println(err)
-		return nil, http.StatusInternalServerError
+	return serializer(event, subjects), 0
+}
+
+// serializeAll - serializes a list of events, loading related subjects
+// with one query instead of one query per event.
+func (h *EventHandler) serializeAll(ctx context.Context, events []models.Event, serializer serializerFunc) ([]interface{}, int) {
+	ids := make([]uuid.UUID, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
 	}
 
-	// load info about it's subjects
-	subjects, err := h.svc.Subject.GetAllForEvent(ctx, event.ID)
+	subjects, err := h.svc.Subject.GetAllForEvents(ctx, ids)
 	if err != nil {
 		log.Println(err)
 		return nil, http.StatusInternalServerError
 	}
 
-	return serializer(event, foundingRange, coFoundingRange, subjects), 0
+	result := make([]interface{}, len(events))
+	for i, e := range events {
+		result[i] = serializer(e, subjects[e.ID])
+	}
+	return result, 0
 }
 
-func (h *EventHandler) buildMinimalView(e models.Event, f, cf models.RangeModel, subs []models.Subject) interface{} {
+func (h *EventHandler) buildMinimalView(e models.Event, subs []models.Subject) interface{} {
 	s := make([]string, len(subs))
 	for i, v := range subs {
 		s[i] = v.Name
 	}
 	return eventMinimalJSONView{
-		ID:           e.ID,
-		Title:        e.Title,
-		FoundingType: e.FoundingType,
-		FoundingRange: rangeJSONView{
-			Low:  f.Low,
-			High: f.High,
-		},
-		CoFoundingRange: rangeJSONView{
-			Low:  cf.Low,
-			High: cf.High,
-		},
+		ID:                 e.ID,
+		Title:              e.Title,
+		Organizer:          e.Organizer,
+		FoundingType:       e.FoundingType,
+		FoundingRange:      newRangeJSONView(e.FoundingRange),
+		CoFoundingRange:    newRangeJSONView(e.CoFoundingRange),
 		SubmissionDeadline: e.SubmissionDeadline,
 		TRL:                e.TRL,
 		Subjects:           s,
 	}
 }
 
+// buildCreateInfoView - builds the representation of event that is used for creation and update
+func (h *EventHandler) buildCreateInfoView(e models.Event, subs []models.Subject) interface{} {
+	s := make([]string, len(subs))
+	for i, v := range subs {
+		s[i] = v.Name
+	}
+
+	return createInfoView{
+		Title:               e.Title,
+		Organizer:           e.Organizer,
+		FoundingType:        e.FoundingType,
+		FoundingRangeLow:    e.FoundingRange.LowMoney().Major(),
+		FoundingRangeHigh:   e.FoundingRange.HighMoney().Major(),
+		FoundingCurrency:    e.FoundingRange.Currency,
+		CoFoundingRangeLow:  e.CoFoundingRange.Low,
+		CoFoundingRangeHigh: e.CoFoundingRange.High,
+		SubmissionDeadline:  e.SubmissionDeadline,
+		ConsiderationPeriod: e.ConsiderationPeriod,
+		RealisationPeriod:   e.RealisationPeriod,
+		Result:              e.Result,
+		Site:                e.Site,
+		Document:            e.Document,
+		InternalContacts:    e.InternalContacts,
+		TRL:                 e.TRL,
+		Competitors:         e.Competitors,
+		Subjects:            s,
+	}
+}
+
 func (h *EventHandler) createInfoFromView(i createInfoView) services.EventCreateInfo {
 	return services.EventCreateInfo{
 		Title:               i.Title,
@@ -275,6 +459,7 @@ func (h *EventHandler) createInfoFromView(i createInfoView) services.EventCreate
 		FoundingType:        i.FoundingType,
 		FoundingRangeLow:    i.FoundingRangeLow,
 		FoundingRangeHigh:   i.FoundingRangeHigh,
+		FoundingCurrency:    i.FoundingCurrency,
 		CoFoundingRangeHigh: i.CoFoundingRangeHigh,
 		CoFoundingRangeLow:  i.CoFoundingRangeLow,
 		SubmissionDeadline:  i.SubmissionDeadline,
@@ -290,11 +475,17 @@ func (h *EventHandler) createInfoFromView(i createInfoView) services.EventCreate
 	}
 }
 
-type serializerFunc func(event models.Event, f, cf models.RangeModel, subs []models.Subject) interface{}
+type serializerFunc func(event models.Event, subs []models.Subject) interface{}
 
+// rangeJSONView - the bounds are in the major units of the currency, the co-founding ranges have no currency
 type rangeJSONView struct {
-	Low  int `json:"low"`
-	High int `json:"high"`
+	Low      int64  `json:"low"`
+	High     int64  `json:"high"`
+	Currency string `json:"currency,omitempty"`
+}
+
+func newRangeJSONView(r models.RangeModel) rangeJSONView {
+	return rangeJSONView{Low: r.LowMoney().Major(), High: r.HighMoney().Major(), Currency: r.Currency}
 }
 
 type eventJSONView struct {
@@ -314,6 +505,16 @@ type eventJSONView struct {
 	TRL                 int           `json:"trl"`
 	Competitors         []uuid.UUID   `json:"competitors"`
 	Subjects            []string      `json:"subjects"`
+	Version             int           `json:"version"`
+	// IsFavourite - set only for the authenticated users
+	IsFavourite *bool `json:"isFavourite,omitempty"`
+}
+
+type eventSearchJSONView struct {
+	ID      uuid.UUID `json:"id"`
+	Title   string    `json:"title"`
+	Rank    float64   `json:"rank"`
+	Snippet string    `json:"snippet"`
 }
 
 type eventMinimalJSONView struct {
@@ -326,4 +527,6 @@ type eventMinimalJSONView struct {
 	SubmissionDeadline time.Time     `json:"submissionDeadline"`
 	TRL                int           `json:"trl"`
 	Subjects           []string      `json:"subjects"`
+	// IsFavourite - set only for the authenticated users
+	IsFavourite *bool `json:"isFavourite,omitempty"`
 }
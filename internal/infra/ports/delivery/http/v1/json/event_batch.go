@@ -0,0 +1,164 @@
+package json
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/validation"
+)
+
+// Modes of a batch
+const (
+	batchModeAllOrNothing = "allOrNothing"
+	batchModeBestEffort   = "bestEffort"
+)
+
+type eventBatchRequest struct {
+	// Mode - allOrNothing(by default) applies all operations in one transaction, bestEffort applies every operation on its own
+	Mode       string               `json:"mode"`
+	Operations []eventOperationView `json:"operations"`
+}
+
+type eventOperationView struct {
+	// Op - create, update or delete
+	Op string    `json:"op"`
+	ID uuid.UUID `json:"id"`
+	// Version - the version the client has seen, it's required for update and delete, -1 skips the check like If-Match: *
+	Version *int            `json:"version"`
+	Event   *createInfoView `json:"event"`
+}
+
+// States of the operations of a batch
+const (
+	operationStateApplied    = "applied"
+	operationStateFailed     = "failed"
+	operationStateRolledBack = "rolledBack"
+	operationStateSkipped    = "skipped"
+)
+
+// eventOperationResultView - the result of an operation, Status is the status the single request would have
+type eventOperationResultView struct {
+	// State - applied, failed, rolledBack(applied and undone with the batch) or skipped(not attempted)
+	State   string                `json:"state"`
+	Status  int                   `json:"status"`
+	ID      *uuid.UUID            `json:"id,omitempty"`
+	Version int                   `json:"version,omitempty"`
+	Msg     string                `json:"msg,omitempty"`
+	Errors  validators.Violations `json:"errors,omitempty"`
+}
+
+type eventBatchResponse struct {
+	Results []eventOperationResultView `json:"results"`
+}
+
+// Batch - applies a list of create, update and delete operations on events, the response has the result of every operation,
+// the malformed batches are rejected with 400 before any operation is applied
+func (h *EventHandler) Batch(c *gin.Context) {
+	var request eventBatchRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Println(err)
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	checks := []validators.FieldCheck{
+		validators.String("mode", request.Mode, validators.Optional(validators.OneOf(batchModeAllOrNothing, batchModeBestEffort))),
+	}
+	operations := make([]services.EventOperation, len(request.Operations))
+	for i, o := range request.Operations {
+		operations[i] = services.EventOperation{Type: o.Op, ID: o.ID}
+		if o.Event != nil {
+			operations[i].Info = h.createInfoFromView(*o.Event)
+		}
+		if o.Version != nil {
+			operations[i].Version = *o.Version
+		}
+
+		field := fmt.Sprintf("operations[%d]", i)
+		if o.Op == services.EventOperationCreate || o.Op == services.EventOperationUpdate {
+			checks = append(checks, requiredCheck(field+".event", o.Event != nil))
+		}
+		if o.Op == services.EventOperationUpdate || o.Op == services.EventOperationDelete {
+			checks = append(checks, requiredCheck(field+".version", o.Version != nil))
+		}
+	}
+	if validation.WriteError(c, validators.Validate(checks...)) {
+		return
+	}
+
+	results, err := h.svc.Event.Batch(c, operations, request.Mode != batchModeBestEffort)
+	if err != nil {
+		if validation.WriteError(c, err) {
+			return
+		}
+		log.Println(err)
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	response := eventBatchResponse{Results: make([]eventOperationResultView, len(results))}
+	for i, r := range results {
+		response.Results[i] = buildOperationResultView(operations[i].Type, r)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// requiredCheck - a violation of the field, if it's not given
+func requiredCheck(field string, given bool) validators.FieldCheck {
+	return func() validators.Violations {
+		if given {
+			return nil
+		}
+		return validators.Violations{{Field: field, Message: "is required"}}
+	}
+}
+
+// buildOperationResultView - the statuses match the responses of POST, PUT and DELETE of /event,
+// 424 is the status of the operations, which are rolled back or skipped because another operation has failed
+func buildOperationResultView(operation string, result services.EventOperationResult) eventOperationResultView {
+	view := operationResultView(operation, result)
+	switch {
+	case result.Err == nil:
+		view.State = operationStateApplied
+	case errors.Is(result.Err, services.ErrBatchRolledBack):
+		view.State = operationStateRolledBack
+	case errors.Is(result.Err, services.ErrBatchSkipped):
+		view.State = operationStateSkipped
+	default:
+		view.State = operationStateFailed
+	}
+	return view
+}
+
+func operationResultView(operation string, result services.EventOperationResult) eventOperationResultView {
+	var violations validators.Violations
+	switch {
+	case result.Err == nil:
+		view := eventOperationResultView{Status: http.StatusAccepted, ID: &result.Event.ID, Version: result.Event.Version}
+		if operation == services.EventOperationCreate {
+			view.Status = http.StatusCreated
+		}
+		if operation == services.EventOperationDelete {
+			view.Version = 0
+		}
+		return view
+	case errors.As(result.Err, &violations):
+		return eventOperationResultView{Status: http.StatusBadRequest, Msg: "validation failed", Errors: violations}
+	case errors.Is(result.Err, services.ErrVersionMismatch):
+		return eventOperationResultView{Status: http.StatusPreconditionFailed, Msg: result.Err.Error()}
+	case errors.Is(result.Err, adapters.ErrNotFound):
+		return eventOperationResultView{Status: http.StatusNotFound, Msg: "event was not found"}
+	case errors.Is(result.Err, services.ErrBatchRolledBack), errors.Is(result.Err, services.ErrBatchSkipped):
+		return eventOperationResultView{Status: http.StatusFailedDependency, Msg: result.Err.Error()}
+	}
+	log.Println(result.Err)
+	return eventOperationResultView{Status: http.StatusInternalServerError, Msg: "internal error"}
+}
@@ -0,0 +1,115 @@
+package json
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+// fakeBatchEvents - returns the results of the mode, the operations are checked by the service itself
+type fakeBatchEvents struct {
+	services.EventService
+	results map[bool][]services.EventOperationResult
+}
+
+func (f fakeBatchEvents) Batch(_ context.Context, operations []services.EventOperation, allOrNothing bool) ([]services.EventOperationResult, error) {
+	if err := services.ValidateEventOperations(operations); err != nil {
+		return nil, err
+	}
+	return f.results[allOrNothing], nil
+}
+
+func TestEventBatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	created := models.Event{ID: uuid.New(), Version: 1}
+	updated := models.Event{ID: uuid.New(), Version: 3}
+	handler := NewEventHandler(services.Services{Event: fakeBatchEvents{results: map[bool][]services.EventOperationResult{
+		// bestEffort - the delete has failed, the other operations are applied
+		false: {{Event: created}, {Err: adapters.ErrNotFound}, {Event: updated}},
+		// allOrNothing - the create is rolled back with the failed delete, the update is not attempted
+		true: {{Err: services.ErrBatchRolledBack}, {Err: adapters.ErrNotFound}, {Err: services.ErrBatchSkipped}},
+	}}})
+
+	r := gin.New()
+	r.POST("/event_batch", handler.Batch)
+
+	operations := `[
+		{"op": "create", "event": {"title": "Grant"}},
+		{"op": "delete", "id": "` + uuid.NewString() + `", "version": 1},
+		{"op": "update", "id": "` + updated.ID.String() + `", "version": 2, "event": {"title": "Grant 2026"}}
+	]`
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		// results - state and status of every operation
+		results [][2]interface{}
+	}{
+		{
+			name:    "best effort",
+			body:    `{"mode": "bestEffort", "operations": ` + operations + `}`,
+			status:  http.StatusOK,
+			results: [][2]interface{}{{"applied", 201}, {"failed", 404}, {"applied", 202}},
+		},
+		{
+			name:    "all or nothing by default",
+			body:    `{"operations": ` + operations + `}`,
+			status:  http.StatusOK,
+			results: [][2]interface{}{{"rolledBack", 424}, {"failed", 404}, {"skipped", 424}},
+		},
+		{
+			name:   "unknown mode",
+			body:   `{"mode": "some", "operations": ` + operations + `}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "update without version",
+			body:   `{"operations": [{"op": "update", "id": "` + updated.ID.String() + `", "event": {"title": "Grant"}}]}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "empty batch",
+			body:   `{"operations": []}`,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/event_batch", strings.NewReader(tt.body)))
+
+		if recorder.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d, body %s", tt.name, recorder.Code, tt.status, recorder.Body)
+			continue
+		}
+		if tt.status != http.StatusOK {
+			continue
+		}
+
+		var response eventBatchResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Errorf("%s: the response is not a batch response: %v", tt.name, err)
+			continue
+		}
+		got := make([][2]interface{}, len(response.Results))
+		for i, v := range response.Results {
+			got[i] = [2]interface{}{v.State, v.Status}
+		}
+		if !reflect.DeepEqual(got, tt.results) {
+			t.Errorf("%s: results = %v, want %v", tt.name, got, tt.results)
+		}
+	}
+}
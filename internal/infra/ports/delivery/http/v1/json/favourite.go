@@ -0,0 +1,129 @@
+package json
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/auth"
+)
+
+// GetFavourites - returns favourite events of the authenticated user, the latest starred first
+func (h *EventHandler) GetFavourites(c *gin.Context) {
+	user, ok := auth.User(c)
+	if !ok {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	events, err := h.svc.Favourite.GetAll(c, user)
+	if err != nil {
+		log.Println(err)
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	result, status := h.serializeAll(c, events, h.buildView)
+	if status != 0 {
+		c.Status(status)
+		return
+	}
+
+	if status := h.markFavourites(c, result); status != 0 {
+		c.Status(status)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// AddFavourite - stars the event for the authenticated user
+func (h *EventHandler) AddFavourite(c *gin.Context) {
+	user, ok := auth.User(c)
+	if !ok {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := h.parseIDFromParam(c)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.svc.Favourite.Add(c, user, id); err != nil {
+		log.Println(err)
+		if errors.Is(err, adapters.ErrNotFound) {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveFavourite - un-stars the event for the authenticated user
+func (h *EventHandler) RemoveFavourite(c *gin.Context) {
+	user, ok := auth.User(c)
+	if !ok {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	id, err := h.parseIDFromParam(c)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.svc.Favourite.Remove(c, user, id); err != nil {
+		log.Println(err)
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// markFavourites - sets isFavourite of the event views for the authenticated user,
+// views of anonymous requests are left as is. Returns a status of the error or 0.
+func (h *EventHandler) markFavourites(c *gin.Context, views []interface{}) int {
+	user, ok := auth.User(c)
+	if !ok {
+		return 0
+	}
+
+	ids := make([]uuid.UUID, 0, len(views))
+	for _, v := range views {
+		switch view := v.(type) {
+		case eventJSONView:
+			ids = append(ids, view.ID)
+		case eventMinimalJSONView:
+			ids = append(ids, view.ID)
+		}
+	}
+
+	favourites, err := h.svc.Favourite.AreFavourite(c, user, ids)
+	if err != nil {
+		log.Println(err)
+		return http.StatusInternalServerError
+	}
+
+	for i, v := range views {
+		switch view := v.(type) {
+		case eventJSONView:
+			isFavourite := favourites[view.ID]
+			view.IsFavourite = &isFavourite
+			views[i] = view
+		case eventMinimalJSONView:
+			isFavourite := favourites[view.ID]
+			view.IsFavourite = &isFavourite
+			views[i] = view
+		}
+	}
+	return 0
+}
@@ -0,0 +1,94 @@
+package json
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/auth"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/validation"
+)
+
+type notificationPreferencesView struct {
+	Email      string `json:"email"`
+	Language   string `json:"language"`
+	DaysBefore []int  `json:"daysBefore"`
+	Enabled    bool   `json:"enabled"`
+}
+
+func buildNotificationPreferencesView(p models.NotificationPreferences) notificationPreferencesView {
+	days := p.DaysBefore
+	if days == nil {
+		days = []int{}
+	}
+	return notificationPreferencesView{
+		Email:      p.Email,
+		Language:   p.Language,
+		DaysBefore: days,
+		Enabled:    p.Enabled,
+	}
+}
+
+// GetNotificationPreferencesHandler - returns deadline reminder preferences of the authenticated user
+func GetNotificationPreferencesHandler(svc services.ReminderService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := auth.User(c)
+		if !ok {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+
+		preferences, err := svc.GetPreferences(c, user)
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.JSON(http.StatusOK, buildNotificationPreferencesView(preferences))
+	}
+}
+
+// UpdateNotificationPreferencesHandler - replaces deadline reminder preferences of the authenticated user
+func UpdateNotificationPreferencesHandler(svc services.ReminderService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := auth.User(c)
+		if !ok {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+
+		var request notificationPreferencesView
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		preferences := models.NotificationPreferences{
+			User:       user,
+			Email:      request.Email,
+			Language:   request.Language,
+			DaysBefore: request.DaysBefore,
+			Enabled:    request.Enabled,
+		}
+		if err := svc.SavePreferences(c, preferences); err != nil {
+			log.Println(err)
+			if validation.WriteError(c, err) {
+				return
+			}
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		preferences, err := svc.GetPreferences(c, user)
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.JSON(http.StatusOK, buildNotificationPreferencesView(preferences))
+	}
+}
@@ -0,0 +1,145 @@
+package json
+
+import (
+	"net/http"
+
+	"github.com/gin-contrib/sse"
+
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/openapi"
+	"github.com/indigowar/map-of-events/pkg/mergepatch"
+)
+
+// Routes - describes routes of this package for the OpenAPI document
+func Routes() []openapi.Route {
+	competitor := []string{"competitor"}
+	ranges := []string{"range"}
+	organizer := []string{"organizer"}
+	event := []string{"event"}
+	suggestion := []string{"suggestion"}
+	subject := []string{"subject"}
+	profile := []string{"profile"}
+	application := []string{"application"}
+	session := []string{"session"}
+	favourite := []string{"favourite"}
+	notification := []string{"notification"}
+	savedSearch := []string{"saved search"}
+	webhook := []string{"webhook"}
+	analytics := []string{"analytics"}
+
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/competitor", Summary: "Returns all competitors", Tags: competitor, Response: []Competitor{}},
+		{Method: http.MethodPost, Path: "/competitor", Summary: "Creates a competitor(the body can be just a name string)", Tags: competitor, Request: competitorRequest{}, Response: Competitor{}, Status: http.StatusAccepted},
+		{Method: http.MethodGet, Path: "/competitor/:id", Summary: "Returns a competitor", Tags: competitor, Response: Competitor{}},
+		{Method: http.MethodPut, Path: "/competitor/:id", Summary: "Updates a competitor and its eligibility criteria", Tags: competitor, Request: competitorRequest{}, Response: Competitor{}},
+		{Method: http.MethodPost, Path: "/eligibility_check", Summary: "Tells which events the applicant qualifies for and why not for the others", Tags: competitor,
+			Request: applicantProfileView{}, Response: []eligibilityView{}},
+
+		{Method: http.MethodGet, Path: "/founding_range/:id", Summary: "Gone: the ranges have no ids, use /event/:id/founding_range", Tags: ranges, Status: http.StatusGone},
+		{Method: http.MethodGet, Path: "/founding_range", Summary: "Returns a maximal available founding range", Tags: ranges, Response: rangeType{}},
+		{Method: http.MethodGet, Path: "/co_founding_range/:id", Summary: "Gone: the ranges have no ids, use /event/:id/co_founding_range", Tags: ranges, Status: http.StatusGone},
+		{Method: http.MethodGet, Path: "/co_founding_range", Summary: "Returns a maximal available co-founding range", Tags: ranges, Response: rangeType{}},
+
+		{Method: http.MethodGet, Path: "/organizer_level", Summary: "Returns all organizer levels", Tags: organizer, Response: []orgLevelBinding{}},
+		{Method: http.MethodPost, Path: "/organizer_level", Summary: "Creates an organizer level", Tags: organizer, Request: createOrganizerLevelRequest{}, Response: orgLevelBinding{}, Status: http.StatusCreated},
+
+		{Method: http.MethodGet, Path: "/organizer", Summary: "Returns all organizers", Tags: organizer, Response: []organizerBinding{}},
+		{Method: http.MethodPost, Path: "/organizer", Summary: "Creates an organizer", Tags: organizer, Request: createOrganizerRequest{}, Response: organizerBinding{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/organizer/:id", Summary: "Returns an organizer", Tags: organizer, Response: organizerBinding{}},
+		{Method: http.MethodPut, Path: "/organizer/:id", Summary: "Updates an organizer", Tags: organizer, Request: createOrganizerRequest{}, Response: organizerBinding{}},
+		{Method: http.MethodPatch, Path: "/organizer/:id", Summary: "Updates given fields of an organizer(JSON Merge Patch)", Tags: organizer,
+			Request: createOrganizerRequest{}, RequestContentType: mergepatch.ContentType, Response: organizerBinding{}},
+		{Method: http.MethodDelete, Path: "/organizer/:id", Summary: "Deletes an organizer", Tags: organizer},
+
+		{Method: http.MethodGet, Path: "/event", Summary: "Returns all events satisfying the filter", Tags: event, Query: eventListQuery, Response: []eventJSONView{}},
+		{Method: http.MethodPost, Path: "/event", Summary: "Creates an event", Tags: event, Request: createInfoView{}, Response: eventJSONView{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/event/:id", Summary: "Returns an event", Tags: event, Response: eventJSONView{}},
+		{Method: http.MethodGet, Path: "/event/:id/founding_range", Summary: "Returns the founding range of the event", Tags: ranges, Response: rangeType{}},
+		{Method: http.MethodGet, Path: "/event/:id/co_founding_range", Summary: "Returns the co-founding range of the event", Tags: ranges, Response: rangeType{}},
+		{Method: http.MethodPut, Path: "/event/:id", Summary: "Updates an event", Tags: event, Request: createInfoView{}, Response: eventJSONView{}, Status: http.StatusAccepted},
+		{Method: http.MethodPatch, Path: "/event/:id", Summary: "Updates given fields of an event(JSON Merge Patch)", Tags: event,
+			Request: createInfoView{}, RequestContentType: mergepatch.ContentType, Response: eventJSONView{}},
+		{Method: http.MethodDelete, Path: "/event/:id", Summary: "Deletes an event", Tags: event, Status: http.StatusAccepted},
+
+		{Method: http.MethodGet, Path: "/change_stream", Summary: "Streams changes of events and organizers satisfying the filter as Server-Sent Events", Tags: event,
+			Query: append([]string{"lastEventId"}, eventFilterQuery...), Response: changeView{}, ResponseContentType: sse.ContentType},
+		{Method: http.MethodGet, Path: "/event_search", Summary: "Full-text search over events", Tags: event, Query: []string{"q", "limit"}, Response: []eventSearchJSONView{}},
+		{Method: http.MethodPost, Path: "/event_batch", Summary: "Creates, updates and deletes events in one request, returns the result of every operation", Tags: event,
+			Request: eventBatchRequest{}, Response: eventBatchResponse{}},
+
+		{Method: http.MethodGet, Path: "/subject", Summary: "Returns all subjects of the catalogue", Tags: subject, Response: []subjectView{}},
+		{Method: http.MethodPost, Path: "/subject", Summary: "Adds a subject to the catalogue", Tags: subject, Request: subjectRequest{}, Response: subjectView{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/subject/:id", Summary: "Returns a subject", Tags: subject, Response: subjectView{}},
+		{Method: http.MethodPut, Path: "/subject/:id", Summary: "Updates a subject", Tags: subject, Request: subjectRequest{}, Response: subjectView{}},
+		{Method: http.MethodDelete, Path: "/subject/:id", Summary: "Deletes a subject without child subjects", Tags: subject},
+		{Method: http.MethodGet, Path: "/subject_tree", Summary: "Returns the catalogue of subjects as a tree", Tags: subject, Response: []subjectTreeView{}},
+
+		{Method: http.MethodGet, Path: "/profile", Summary: "Returns all applicant profiles", Tags: profile, Response: []profileView{}},
+		{Method: http.MethodPost, Path: "/profile", Summary: "Creates an applicant profile", Tags: profile, Request: profileRequest{}, Response: profileView{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/profile/:id", Summary: "Returns an applicant profile", Tags: profile, Response: profileView{}},
+		{Method: http.MethodPut, Path: "/profile/:id", Summary: "Updates an applicant profile", Tags: profile, Request: profileRequest{}, Response: profileView{}},
+		{Method: http.MethodDelete, Path: "/profile/:id", Summary: "Deletes an applicant profile", Tags: profile},
+		{Method: http.MethodGet, Path: "/profile/:id/recommendations", Summary: "Returns events the profile can apply to ranked by fit with explanation of scores", Tags: profile,
+			Query: []string{"limit"}, Response: []recommendationView{}},
+
+		{Method: http.MethodPost, Path: "/application", Summary: "Creates an application of a team to an event in the idea stage", Tags: application,
+			Request: createApplicationRequest{}, Response: applicationView{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/application/:id", Summary: "Returns an application with its history, notes and files", Tags: application, Response: applicationView{}},
+		{Method: http.MethodDelete, Path: "/application/:id", Summary: "Deletes an application", Tags: application},
+		{Method: http.MethodPut, Path: "/application/:id/stage", Summary: "Moves an application to a stage", Tags: application, Request: changeStageRequest{}, Response: applicationView{}},
+		{Method: http.MethodPost, Path: "/application/:id/note", Summary: "Adds a note to an application", Tags: application,
+			Request: addNoteRequest{}, Response: applicationNoteView{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/application/:id/file", Summary: "Attaches a file uploaded with POST /image to an application", Tags: application,
+			Request: attachFileRequest{}, Response: applicationFileView{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/event/:id/application", Summary: "Returns all applications to an event", Tags: application, Response: []applicationView{}},
+		{Method: http.MethodGet, Path: "/profile/:id/application", Summary: "Returns all applications of a team", Tags: application, Response: []applicationView{}},
+
+		{Method: http.MethodPost, Path: "/user", Summary: "Creates a user and starts their session", Tags: session,
+			Request: credentialsRequest{}, Response: sessionView{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/session", Summary: "Starts a session of the user, the previous session of the user ends", Tags: session,
+			Request: credentialsRequest{}, Response: sessionView{}},
+
+		{Method: http.MethodGet, Path: "/favourite", Summary: "Returns favourite events of the user", Tags: favourite, Response: []eventJSONView{}, Auth: true},
+		{Method: http.MethodPut, Path: "/favourite/:id", Summary: "Adds an event to favourites of the user", Tags: favourite, Status: http.StatusNoContent, Auth: true},
+		{Method: http.MethodDelete, Path: "/favourite/:id", Summary: "Removes an event from favourites of the user", Tags: favourite, Status: http.StatusNoContent, Auth: true},
+
+		{Method: http.MethodGet, Path: "/notification_preferences", Summary: "Returns deadline reminder preferences of the user", Tags: notification,
+			Response: notificationPreferencesView{}, Auth: true},
+		{Method: http.MethodPut, Path: "/notification_preferences", Summary: "Replaces deadline reminder preferences of the user", Tags: notification,
+			Request: notificationPreferencesView{}, Response: notificationPreferencesView{}, Auth: true},
+
+		{Method: http.MethodGet, Path: "/saved_search", Summary: "Returns saved searches of the user", Tags: savedSearch, Response: []savedSearchView{}, Auth: true},
+		{Method: http.MethodPost, Path: "/saved_search", Summary: "Saves a search, the user is alerted about new events matching it", Tags: savedSearch,
+			Request: savedSearchRequest{}, Response: savedSearchView{}, Status: http.StatusCreated, Auth: true},
+		{Method: http.MethodGet, Path: "/saved_search/:id", Summary: "Returns a saved search", Tags: savedSearch, Response: savedSearchView{}, Auth: true},
+		{Method: http.MethodPut, Path: "/saved_search/:id", Summary: "Updates a saved search", Tags: savedSearch,
+			Request: savedSearchRequest{}, Response: savedSearchView{}, Auth: true},
+		{Method: http.MethodDelete, Path: "/saved_search/:id", Summary: "Deletes a saved search with its alerts", Tags: savedSearch, Status: http.StatusNoContent, Auth: true},
+		{Method: http.MethodGet, Path: "/search_alert", Summary: "Returns in-app alerts of the user's saved searches", Tags: savedSearch,
+			Query: []string{"unread"}, Response: []searchAlertView{}, Auth: true},
+		{Method: http.MethodPost, Path: "/search_alert/read", Summary: "Marks alerts as read, all of them if ids are empty", Tags: savedSearch,
+			Request: markAlertsReadRequest{}, Status: http.StatusNoContent, Auth: true},
+
+		{Method: http.MethodGet, Path: "/webhook", Summary: "Returns all webhook subscriptions", Tags: webhook, Response: []webhookView{}, Admin: true},
+		{Method: http.MethodPost, Path: "/webhook", Summary: "Subscribes a webhook to the changes, the response has the signing secret", Tags: webhook,
+			Request: webhookRequest{}, Response: webhookView{}, Status: http.StatusCreated, Admin: true},
+		{Method: http.MethodGet, Path: "/webhook/:id", Summary: "Returns a webhook subscription", Tags: webhook, Response: webhookView{}, Admin: true},
+		{Method: http.MethodPut, Path: "/webhook/:id", Summary: "Updates a webhook subscription", Tags: webhook,
+			Request: webhookRequest{}, Response: webhookView{}, Admin: true},
+		{Method: http.MethodDelete, Path: "/webhook/:id", Summary: "Deletes a webhook subscription with its deliveries", Tags: webhook, Status: http.StatusNoContent, Admin: true},
+		{Method: http.MethodGet, Path: "/webhook/:id/delivery", Summary: "Returns the delivery log of a webhook", Tags: webhook,
+			Query: []string{"status", "limit"}, Response: []webhookDeliveryView{}, Admin: true},
+		{Method: http.MethodGet, Path: "/webhook_dead_letter", Summary: "Returns deliveries, that have failed all attempts", Tags: webhook,
+			Query: []string{"limit"}, Response: []webhookDeliveryView{}, Admin: true},
+		{Method: http.MethodPost, Path: "/webhook_delivery/:id/retry", Summary: "Queues a dead delivery again", Tags: webhook, Status: http.StatusAccepted, Admin: true},
+
+		{Method: http.MethodGet, Path: "/minimal_event", Summary: "Returns all events satisfying the filter in minimal version", Tags: event, Query: eventListQuery, Response: []eventMinimalJSONView{}},
+		{Method: http.MethodGet, Path: "/minimal_event/:id", Summary: "Returns an event in minimal version", Tags: event, Response: eventMinimalJSONView{}},
+
+		{Method: http.MethodGet, Path: "/organizer_suggestion", Summary: "Suggests existing organizers by a typed name", Tags: suggestion, Query: []string{"q", "limit"}, Response: []suggestionView{}},
+		{Method: http.MethodGet, Path: "/competitor_suggestion", Summary: "Suggests existing competitors by a typed name", Tags: suggestion, Query: []string{"q", "limit"}, Response: []suggestionView{}},
+		{Method: http.MethodGet, Path: "/subject_suggestion", Summary: "Suggests existing subjects by a typed name", Tags: suggestion, Query: []string{"q", "limit"}, Response: []suggestionView{}},
+
+		{Method: http.MethodGet, Path: "/analytics/:dimension", Summary: "Counts the events and sums their ranges by organizer, level, subject, competitor, trl or deadline_month(CSV for format=csv)",
+			Tags: analytics, Query: analyticsQuery, Response: analyticsResponse{}},
+	}
+}
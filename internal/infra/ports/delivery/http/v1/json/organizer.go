@@ -8,6 +8,8 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/validation"
+	"github.com/indigowar/map-of-events/pkg/mergepatch"
 )
 
 type orgLevelBinding struct {
@@ -49,6 +51,10 @@ func CreateOrganizerLevelHandler(svc services.OrganizerService) func(c *gin.Cont
 			return
 		}
 
+		if validation.WriteError(c, services.ValidateOrganizerLevel(level.Name, level.Code)) {
+			return
+		}
+
 		o, err := svc.CreateLevel(c, level.Name, level.Code)
 		if err != nil {
 			log.Println(err)
@@ -59,14 +65,36 @@ func CreateOrganizerLevelHandler(svc services.OrganizerService) func(c *gin.Cont
 	}
 }
 
+// Logos - how the organizer routes exchange the logos, LogoLinks gives the links to the images as they are,
+// v2 inlines the images into the organizers
+type Logos interface {
+	// Link - the link of the image of a logo given by the client, Store creates the image if it's needed
+	Link(logo string) string
+	Store(c *gin.Context, link, logo string) error
+	// Show - the logo given to the client by the link of the image
+	Show(c *gin.Context, link string) string
+}
+
+// LogoLinks - the logos are the links to the images uploaded by POST /image
+var LogoLinks Logos = logoLinks{}
+
+type logoLinks struct{}
+
+func (logoLinks) Link(logo string) string { return logo }
+
+func (logoLinks) Store(*gin.Context, string, string) error { return nil }
+
+func (logoLinks) Show(_ *gin.Context, link string) string { return link }
+
 type organizerBinding struct {
-	Id    uuid.UUID `json:"id"`
-	Name  string    `json:"name"`
-	Logo  string    `json:"logo"`
-	Level uuid.UUID `json:"level"`
+	Id      uuid.UUID `json:"id"`
+	Name    string    `json:"name"`
+	Logo    string    `json:"logo"`
+	Level   uuid.UUID `json:"level"`
+	Version int       `json:"version"`
 }
 
-func GetAllOrganizersHandler(svc services.OrganizerService) func(c *gin.Context) {
+func GetAllOrganizersHandler(svc services.OrganizerService, logos Logos) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		objects, err := svc.GetAll(c)
 		if err != nil {
@@ -76,14 +104,14 @@ func GetAllOrganizersHandler(svc services.OrganizerService) func(c *gin.Context)
 		}
 		result := make([]organizerBinding, len(objects))
 		for i, v := range objects {
-			result[i] = organizerBinding{v.ID, v.Name, v.Logo, v.Level}
+			result[i] = organizerBinding{v.ID, v.Name, logos.Show(c, v.Logo), v.Level, v.Version}
 		}
 
 		c.JSON(http.StatusOK, result)
 	}
 }
 
-func GetByIDOrganizerHandler(svc services.OrganizerService) func(c *gin.Context) {
+func GetByIDOrganizerHandler(svc services.OrganizerService, logos Logos) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		stringId := c.Param("id")
 
@@ -100,11 +128,13 @@ func GetByIDOrganizerHandler(svc services.OrganizerService) func(c *gin.Context)
 			c.Status(http.StatusInternalServerError)
 			return
 		}
+		setETag(c, organizer.Version)
 		c.JSON(http.StatusOK, organizerBinding{
 			organizer.ID,
 			organizer.Name,
-			organizer.Logo,
+			logos.Show(c, organizer.Logo),
 			organizer.Level,
+			organizer.Version,
 		})
 	}
 }
@@ -115,7 +145,7 @@ type createOrganizerRequest struct {
 	Level uuid.UUID `json:"level"`
 }
 
-func CreateOrganizerHandler(svc services.OrganizerService) func(c *gin.Context) {
+func CreateOrganizerHandler(svc services.OrganizerService, logos Logos) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		var organizer createOrganizerRequest
 		if err := c.ShouldBindJSON(&organizer); err != nil {
@@ -123,18 +153,31 @@ func CreateOrganizerHandler(svc services.OrganizerService) func(c *gin.Context)
 			c.Status(http.StatusBadRequest)
 			return
 		}
-		created, err := svc.Create(c, organizer.Name, organizer.Logo, organizer.Level)
+		link := logos.Link(organizer.Logo)
+		if validation.WriteError(c, services.ValidateOrganizer(organizer.Name, link, organizer.Level)) {
+			return
+		}
+
+		if err := logos.Store(c, link, organizer.Logo); err != nil {
+			log.Println(err)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		created, err := svc.Create(c, organizer.Name, link, organizer.Level)
 		if err != nil {
 			log.Println(err)
 			c.Status(http.StatusInternalServerError)
 			return
 		}
 
+		setETag(c, created.Version)
 		c.JSON(http.StatusCreated, organizerBinding{
 			created.ID,
 			created.Name,
-			created.Logo,
+			logos.Show(c, created.Logo),
 			created.Level,
+			created.Version,
 		})
 	}
 }
@@ -149,6 +192,11 @@ func UpdateOrganizerHandler(svc services.OrganizerService) func(c *gin.Context)
 			return
 		}
 
+		version, ok := ifMatchVersion(c)
+		if !ok {
+			return
+		}
+
 		var info createOrganizerRequest
 
 		if err := c.ShouldBindJSON(&info); err != nil {
@@ -157,18 +205,94 @@ func UpdateOrganizerHandler(svc services.OrganizerService) func(c *gin.Context)
 			return
 		}
 
-		result, err := svc.Update(c, organizerId, info.Name, info.Logo, info.Level)
+		if validation.WriteError(c, services.ValidateOrganizer(info.Name, info.Logo, info.Level)) {
+			return
+		}
+
+		result, err := svc.Update(c, organizerId, version, info.Name, info.Logo, info.Level)
+		if err != nil {
+			log.Println(err)
+			if writeVersionMismatch(c, err) {
+				return
+			}
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		setETag(c, result.Version)
+		c.JSON(http.StatusOK, organizerBinding{
+			Id:      result.ID,
+			Name:    result.Name,
+			Logo:    result.Logo,
+			Level:   result.Level,
+			Version: result.Version,
+		})
+	}
+}
+
+// PatchOrganizerHandler - updates only the fields of organizer given in a JSON Merge Patch(RFC 7396)
+func PatchOrganizerHandler(svc services.OrganizerService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		organizerId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		version, ok := ifMatchVersion(c)
+		if !ok {
+			return
+		}
+
+		patch, err := readMergePatch(c)
+		if err != nil {
+			log.Println(err)
+			return
+		}
+
+		organizer, err := svc.GetByID(c, organizerId)
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusNotFound)
+			return
+		}
+
+		// the patch is applied to the state the client has seen
+		if !services.VersionMatches(version, organizer.Version) {
+			writeVersionMismatch(c, services.ErrVersionMismatch)
+			return
+		}
+		version = organizer.Version
+
+		info := createOrganizerRequest{Name: organizer.Name, Logo: organizer.Logo, Level: organizer.Level}
+		if err := mergepatch.ApplyTo(&info, patch); err != nil {
+			log.Println(err)
+			c.JSON(http.StatusBadRequest, gin.H{"msg": err.Error()})
+			return
+		}
+
+		if validation.WriteError(c, services.ValidateOrganizer(info.Name, info.Logo, info.Level)) {
+			return
+		}
+
+		result, err := svc.Update(c, organizerId, version, info.Name, info.Logo, info.Level)
 		if err != nil {
 			log.Println(err)
+			if writeVersionMismatch(c, err) {
+				return
+			}
 			c.Status(http.StatusInternalServerError)
 			return
 		}
 
+		setETag(c, result.Version)
 		c.JSON(http.StatusOK, organizerBinding{
-			Id:    result.ID,
-			Name:  result.Name,
-			Logo:  result.Logo,
-			Level: result.Level,
+			Id:      result.ID,
+			Name:    result.Name,
+			Logo:    result.Logo,
+			Level:   result.Level,
+			Version: result.Version,
 		})
 	}
 }
@@ -183,9 +307,17 @@ func DeleteOrganizerHandler(svc services.OrganizerService) gin.HandlerFunc {
 			return
 		}
 
-		err = svc.Delete(c, id)
+		version, ok := ifMatchVersion(c)
+		if !ok {
+			return
+		}
+
+		err = svc.Delete(c, id, version)
 		if err != nil {
 			log.Println(err)
+			if writeVersionMismatch(c, err) {
+				return
+			}
 			c.Status(http.StatusNotFound)
 			return
 		}
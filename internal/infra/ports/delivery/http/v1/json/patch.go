@@ -0,0 +1,31 @@
+package json
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/indigowar/map-of-events/pkg/mergepatch"
+)
+
+var errUnsupportedPatchType = errors.New("unsupported patch content type")
+
+// readMergePatch - reads a body of JSON Merge Patch request,
+// if the request is not acceptable, it responds and returns an error
+func readMergePatch(c *gin.Context) ([]byte, error) {
+	contentType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if contentType != mergepatch.ContentType && contentType != "application/json" {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"msg": "expected " + mergepatch.ContentType})
+		return nil, errUnsupportedPatchType
+	}
+
+	patch, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return nil, err
+	}
+	return patch, nil
+}
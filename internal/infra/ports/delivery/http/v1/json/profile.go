@@ -0,0 +1,237 @@
+package json
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/validation"
+)
+
+const (
+	defaultRecommendationLimit = 20
+	maxRecommendationLimit     = 100
+)
+
+type profileView struct {
+	ID                uuid.UUID            `json:"id"`
+	Name              string               `json:"name"`
+	Applicant         applicantProfileView `json:"applicant"`
+	TRL               int                  `json:"trl"`
+	Subjects          []uuid.UUID          `json:"subjects"`
+	NeededFunding     int                  `json:"neededFunding"`
+	CoFundingCapacity int                  `json:"coFundingCapacity"`
+}
+
+type profileRequest struct {
+	Name              string               `json:"name"`
+	Applicant         applicantProfileView `json:"applicant"`
+	TRL               int                  `json:"trl"`
+	Subjects          []uuid.UUID          `json:"subjects"`
+	NeededFunding     int                  `json:"neededFunding"`
+	CoFundingCapacity int                  `json:"coFundingCapacity"`
+}
+
+func (r profileRequest) info() services.ProfileInfo {
+	return services.ProfileInfo{
+		Name:              r.Name,
+		Applicant:         r.Applicant.profile(),
+		TRL:               r.TRL,
+		Subjects:          r.Subjects,
+		NeededFunding:     r.NeededFunding,
+		CoFundingCapacity: r.CoFundingCapacity,
+	}
+}
+
+type scoreComponentView struct {
+	Criterion string  `json:"criterion"`
+	Weight    float64 `json:"weight"`
+	Score     float64 `json:"score"`
+	Reason    string  `json:"reason"`
+}
+
+type recommendationView struct {
+	EventID     uuid.UUID            `json:"eventId"`
+	Title       string               `json:"title"`
+	Score       float64              `json:"score"`
+	Explanation []scoreComponentView `json:"explanation"`
+}
+
+func buildProfileView(p models.Profile) profileView {
+	return profileView{
+		ID:   p.ID,
+		Name: p.Name,
+		Applicant: applicantProfileView{
+			Category:         p.Applicant.Category,
+			Age:              p.Applicant.Age,
+			Degree:           p.Applicant.Degree,
+			Region:           p.Applicant.Region,
+			OrganizationType: p.Applicant.OrganizationType,
+		},
+		TRL:               p.TRL,
+		Subjects:          p.Subjects,
+		NeededFunding:     p.NeededFunding,
+		CoFundingCapacity: p.CoFundingCapacity,
+	}
+}
+
+func GetAllProfilesHandler(svc services.ProfileService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		profiles, err := svc.GetAll(c)
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		result := make([]profileView, len(profiles))
+		for i, v := range profiles {
+			result[i] = buildProfileView(v)
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func GetProfileByIDHandler(svc services.ProfileService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		profile, err := svc.GetByID(c, id)
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusOK, buildProfileView(profile))
+	}
+}
+
+func CreateProfileHandler(svc services.ProfileService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request profileRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		profile, err := svc.Create(c, request.info())
+		if err != nil {
+			log.Println(err)
+			if validation.WriteError(c, err) {
+				return
+			}
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.JSON(http.StatusCreated, buildProfileView(profile))
+	}
+}
+
+func UpdateProfileHandler(svc services.ProfileService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		var request profileRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		profile, err := svc.Update(c, id, request.info())
+		if err != nil {
+			log.Println(err)
+			if validation.WriteError(c, err) {
+				return
+			}
+			if errors.Is(err, adapters.ErrNotFound) {
+				c.Status(http.StatusNotFound)
+				return
+			}
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.JSON(http.StatusOK, buildProfileView(profile))
+	}
+}
+
+func DeleteProfileHandler(svc services.ProfileService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		if err := svc.Delete(c, id); err != nil {
+			log.Println(err)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+}
+
+// RecommendationsHandler - returns events the profile can apply to, the best fitting first
+func RecommendationsHandler(profiles services.ProfileService, svc services.RecommendationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		limit, ok := parseLimit(c, defaultRecommendationLimit, maxRecommendationLimit)
+		if !ok {
+			return
+		}
+
+		profile, err := profiles.GetByID(c, id)
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusNotFound)
+			return
+		}
+
+		recommendations, err := svc.Recommend(c, profile, limit)
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		result := make([]recommendationView, len(recommendations))
+		for i, v := range recommendations {
+			explanation := make([]scoreComponentView, len(v.Explanation))
+			for j, component := range v.Explanation {
+				explanation[j] = scoreComponentView{
+					Criterion: component.Criterion,
+					Weight:    component.Weight,
+					Score:     component.Score,
+					Reason:    component.Reason,
+				}
+			}
+			result[i] = recommendationView{EventID: v.EventID, Title: v.Title, Score: v.Score, Explanation: explanation}
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,116 @@
+package json
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/validation"
+)
+
+// parseLimit - reads "limit" query parameter, if it's invalid responds and returns false
+func parseLimit(c *gin.Context, defaultLimit, maxLimit int) (int, bool) {
+	value := c.Query("limit")
+	if value == "" {
+		return defaultLimit, true
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 || limit > maxLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("limit should be between 1 and %d", maxLimit)})
+		return 0, false
+	}
+	return limit, true
+}
+
+// eventFilterQuery - names of the query parameters of the event filter
+var eventFilterQuery = []string{"organizers", "competitors", "subjects", "minTrl", "maxTrl", "minFunding", "maxFunding"}
+
+// eventListQuery - names of the query parameters of the event lists, ?sort=funding or ?sort=-funding orders
+// the events by the converted founding ranges
+var eventListQuery = append([]string{"sort"}, eventFilterQuery...)
+
+// parseEventFilter - reads the event filter from the query, ids are given by repeated parameters
+// (?subjects=...&subjects=...), if the filter is invalid responds and returns false
+func parseEventFilter(c *gin.Context) (models.EventFilter, bool) {
+	violations := make(validators.Violations, 0)
+
+	ids := func(name string) []uuid.UUID {
+		values := c.QueryArray(name)
+		if len(values) == 0 {
+			return nil
+		}
+		result := make([]uuid.UUID, 0, len(values))
+		for i, v := range values {
+			id, err := uuid.Parse(v)
+			if err != nil {
+				violations = append(violations, validators.Violation{Field: fmt.Sprintf("%s[%d]", name, i), Message: "is not a valid id"})
+				continue
+			}
+			result = append(result, id)
+		}
+		return result
+	}
+	number := func(name string) int {
+		value := c.Query(name)
+		if value == "" {
+			return 0
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			violations = append(violations, validators.Violation{Field: name, Message: "is not a number"})
+		}
+		return n
+	}
+
+	filter := models.EventFilter{
+		Organizers:  ids("organizers"),
+		Competitors: ids("competitors"),
+		Subjects:    ids("subjects"),
+		MinTRL:      number("minTrl"),
+		MaxTRL:      number("maxTrl"),
+		MinFunding:  number("minFunding"),
+		MaxFunding:  number("maxFunding"),
+	}
+	if len(violations) != 0 {
+		validation.WriteError(c, violations)
+		return models.EventFilter{}, false
+	}
+
+	if validation.WriteError(c, services.ValidateEventFilter(filter)) {
+		return models.EventFilter{}, false
+	}
+	return filter, true
+}
+
+// findSortedEvents - the events satisfying the filter ordered by ?sort=, the found order is kept without it,
+// responds if the query is invalid or the events can't be found
+func findSortedEvents(c *gin.Context, svc services.EventService) ([]models.Event, bool) {
+	filter, ok := parseEventFilter(c)
+	if !ok {
+		return nil, false
+	}
+
+	sort := c.Query("sort")
+	if validation.WriteError(c, services.ValidateEventSort(sort)) {
+		return nil, false
+	}
+
+	events, err := svc.Find(c, filter)
+	if err == nil && sort != "" {
+		events, err = svc.SortByFunding(c, events, sort == services.EventSortFundingDescending)
+	}
+	if err != nil {
+		log.Println(err)
+		c.Status(http.StatusInternalServerError)
+		return nil, false
+	}
+	return events, true
+}
@@ -1,22 +1,34 @@
 package json
 
 import (
+	"errors"
 	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
 	"github.com/indigowar/map-of-events/internal/domain/services"
 )
 
+// rangeType - the bounds are in the major units of the currency, the maximum founding range is
+// in the reference currency, the co-founding ranges have no currency.
+// Id is the id of the event the range belongs to, it's empty for the maximum range
 type rangeType struct {
-	Id   uuid.UUID `json:"id"`
-	Low  int       `json:"low"`
-	High int       `json:"high"`
+	Id       uuid.UUID `json:"id"`
+	Low      int64     `json:"low"`
+	High     int64     `json:"high"`
+	Currency string    `json:"currency,omitempty"`
 }
 
-func GetByIDRangeHandler(srv services.RangeService) func(c *gin.Context) {
+func newRangeType(event uuid.UUID, r models.RangeModel) rangeType {
+	return rangeType{event, r.LowMoney().Major(), r.HighMoney().Major(), r.Currency}
+}
+
+// GetEventRangeHandler - returns the range of the event with the id
+func GetEventRangeHandler(srv services.RangeService) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		stringId := c.Param("id")
 
@@ -28,13 +40,26 @@ func GetByIDRangeHandler(srv services.RangeService) func(c *gin.Context) {
 			return
 		}
 
-		r, err := srv.GetByID(c, id)
+		r, err := srv.GetByEvent(c, id)
 		if err != nil {
 			log.Println(err)
-			c.Status(http.StatusNotFound)
+			if errors.Is(err, adapters.ErrNotFound) {
+				c.Status(http.StatusNotFound)
+			} else {
+				c.Status(http.StatusInternalServerError)
+			}
+			return
 		}
 
-		c.JSON(http.StatusOK, rangeType{r.ID, r.Low, r.High})
+		c.JSON(http.StatusOK, newRangeType(id, r))
+	}
+}
+
+// RangeGoneHandler - responds to the routes of the ranges by their own ids with 410,
+// the ranges have no ids since they're a part of the event, so the clients are sent to the route of the event's range
+func RangeGoneHandler(successor string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusGone, gin.H{"msg": "the ranges have no ids anymore, use GET /api/v1/event/{id}/" + successor})
 	}
 }
 
@@ -46,6 +71,6 @@ func GetMaximumRangeHandler(srv services.RangeService) func(c *gin.Context) {
 			c.Status(http.StatusInternalServerError)
 			return
 		}
-		c.JSON(http.StatusOK, rangeType{result.ID, result.Low, result.High})
+		c.JSON(http.StatusOK, newRangeType(uuid.Nil, result))
 	}
 }
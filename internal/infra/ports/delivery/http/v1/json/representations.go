@@ -17,13 +17,9 @@ They're specifies a json  serialisation of structure in different ways.
 
 type (
 	rangeRepresentation struct {
-		Low  int `json:"low"`
-		High int `json:"high"`
-	}
-
-	rangeRepresentationWithID struct {
-		ID uuid.UUID `json:"id"`
-		rangeRepresentation
+		Low      int64  `json:"low"`
+		High     int64  `json:"high"`
+		Currency string `json:"currency,omitempty"`
 	}
 
 	organizerLevelRepresentation struct {
@@ -50,29 +46,16 @@ type (
 
 func (rr *rangeRepresentation) ToModel() models.RangeModel {
 	return models.RangeModel{
-		ID:   uuid.UUID{},
-		Low:  rr.Low,
-		High: rr.High,
+		Low:      models.MoneyFromMajor(rr.Low, rr.Currency).Amount,
+		High:     models.MoneyFromMajor(rr.High, rr.Currency).Amount,
+		Currency: rr.Currency,
 	}
 }
 
 func (rr *rangeRepresentation) FromModel(m models.RangeModel) {
-	rr.Low = m.Low
-	rr.High = m.High
-}
-
-func (rr *rangeRepresentationWithID) ToModel() models.RangeModel {
-	return models.RangeModel{
-		ID:   rr.ID,
-		Low:  rr.Low,
-		High: rr.High,
-	}
-}
-
-func (rr *rangeRepresentationWithID) FromModel(m models.RangeModel) {
-	rr.Low = m.Low
-	rr.High = m.High
-	rr.ID = m.ID
+	rr.Low = m.LowMoney().Major()
+	rr.High = m.HighMoney().Major()
+	rr.Currency = m.Currency
 }
 
 func (olr *organizerLevelRepresentation) ToModel() models.OrganizerLevel {
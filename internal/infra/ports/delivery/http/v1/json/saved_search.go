@@ -0,0 +1,313 @@
+package json
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/auth"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/validation"
+)
+
+type eventFilterView struct {
+	Organizers  []uuid.UUID `json:"organizers"`
+	Competitors []uuid.UUID `json:"competitors"`
+	Subjects    []uuid.UUID `json:"subjects"`
+	MinTRL      int         `json:"minTrl"`
+	MaxTRL      int         `json:"maxTrl"`
+	MinFunding  int         `json:"minFunding"`
+	MaxFunding  int         `json:"maxFunding"`
+}
+
+func (v eventFilterView) filter() models.EventFilter {
+	return models.EventFilter{
+		Organizers:  v.Organizers,
+		Competitors: v.Competitors,
+		Subjects:    v.Subjects,
+		MinTRL:      v.MinTRL,
+		MaxTRL:      v.MaxTRL,
+		MinFunding:  v.MinFunding,
+		MaxFunding:  v.MaxFunding,
+	}
+}
+
+func buildEventFilterView(f models.EventFilter) eventFilterView {
+	orEmpty := func(ids []uuid.UUID) []uuid.UUID {
+		if ids == nil {
+			return []uuid.UUID{}
+		}
+		return ids
+	}
+	return eventFilterView{
+		Organizers:  orEmpty(f.Organizers),
+		Competitors: orEmpty(f.Competitors),
+		Subjects:    orEmpty(f.Subjects),
+		MinTRL:      f.MinTRL,
+		MaxTRL:      f.MaxTRL,
+		MinFunding:  f.MinFunding,
+		MaxFunding:  f.MaxFunding,
+	}
+}
+
+type savedSearchView struct {
+	ID              uuid.UUID       `json:"id"`
+	Name            string          `json:"name"`
+	Filter          eventFilterView `json:"filter"`
+	Delivery        string          `json:"delivery"`
+	Frequency       string          `json:"frequency"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	LastDeliveredAt *time.Time      `json:"lastDeliveredAt"`
+}
+
+type savedSearchRequest struct {
+	Name      string          `json:"name"`
+	Filter    eventFilterView `json:"filter"`
+	Delivery  string          `json:"delivery"`
+	Frequency string          `json:"frequency"`
+}
+
+func (r savedSearchRequest) info() services.SavedSearchInfo {
+	return services.SavedSearchInfo{
+		Name:      r.Name,
+		Filter:    r.Filter.filter(),
+		Delivery:  r.Delivery,
+		Frequency: r.Frequency,
+	}
+}
+
+type searchAlertView struct {
+	ID          uuid.UUID  `json:"id"`
+	Search      uuid.UUID  `json:"search"`
+	Event       uuid.UUID  `json:"event"`
+	Title       string     `json:"title"`
+	Reason      string     `json:"reason"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	DeliveredAt time.Time  `json:"deliveredAt"`
+	ReadAt      *time.Time `json:"readAt"`
+}
+
+type markAlertsReadRequest struct {
+	// IDs - alerts to mark, all alerts of the user are marked if it's empty
+	IDs []uuid.UUID `json:"ids"`
+}
+
+func buildSavedSearchView(s models.SavedSearch) savedSearchView {
+	view := savedSearchView{
+		ID:        s.ID,
+		Name:      s.Name,
+		Filter:    buildEventFilterView(s.Filter),
+		Delivery:  s.Delivery,
+		Frequency: s.Frequency,
+		CreatedAt: s.CreatedAt,
+	}
+	if !s.LastDeliveredAt.IsZero() {
+		view.LastDeliveredAt = &s.LastDeliveredAt
+	}
+	return view
+}
+
+func buildSearchAlertView(a models.SearchAlert) searchAlertView {
+	view := searchAlertView{
+		ID:          a.ID,
+		Search:      a.Search,
+		Event:       a.Event,
+		Title:       a.Title,
+		Reason:      a.Reason,
+		CreatedAt:   a.CreatedAt,
+		DeliveredAt: a.DeliveredAt,
+	}
+	if !a.ReadAt.IsZero() {
+		view.ReadAt = &a.ReadAt
+	}
+	return view
+}
+
+// writeSavedSearchError - responds with the status of the service's error
+func writeSavedSearchError(c *gin.Context, err error) {
+	log.Println(err)
+	if validation.WriteError(c, err) {
+		return
+	}
+	if errors.Is(err, adapters.ErrNotFound) {
+		c.Status(http.StatusNotFound)
+		return
+	}
+	c.Status(http.StatusInternalServerError)
+}
+
+func GetAllSavedSearchesHandler(svc services.SavedSearchService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := auth.User(c)
+		if !ok {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+
+		searches, err := svc.GetAll(c, user)
+		if err != nil {
+			writeSavedSearchError(c, err)
+			return
+		}
+
+		result := make([]savedSearchView, len(searches))
+		for i, v := range searches {
+			result[i] = buildSavedSearchView(v)
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func GetSavedSearchByIDHandler(svc services.SavedSearchService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := auth.User(c)
+		if !ok {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		search, err := svc.GetByID(c, user, id)
+		if err != nil {
+			writeSavedSearchError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, buildSavedSearchView(search))
+	}
+}
+
+func CreateSavedSearchHandler(svc services.SavedSearchService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := auth.User(c)
+		if !ok {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+
+		var request savedSearchRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		search, err := svc.Create(c, user, request.info())
+		if err != nil {
+			writeSavedSearchError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, buildSavedSearchView(search))
+	}
+}
+
+func UpdateSavedSearchHandler(svc services.SavedSearchService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := auth.User(c)
+		if !ok {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		var request savedSearchRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		search, err := svc.Update(c, user, id, request.info())
+		if err != nil {
+			writeSavedSearchError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, buildSavedSearchView(search))
+	}
+}
+
+func DeleteSavedSearchHandler(svc services.SavedSearchService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := auth.User(c)
+		if !ok {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		if err := svc.Delete(c, user, id); err != nil {
+			writeSavedSearchError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// GetSearchAlertsHandler - returns in-app alerts of the user, only unread ones with "unread=true"
+func GetSearchAlertsHandler(svc services.SavedSearchService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := auth.User(c)
+		if !ok {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+
+		alerts, err := svc.GetAlerts(c, user, c.Query("unread") == "true")
+		if err != nil {
+			writeSavedSearchError(c, err)
+			return
+		}
+
+		result := make([]searchAlertView, len(alerts))
+		for i, v := range alerts {
+			result[i] = buildSearchAlertView(v)
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func MarkSearchAlertsReadHandler(svc services.SavedSearchService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := auth.User(c)
+		if !ok {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+
+		var request markAlertsReadRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		if err := svc.MarkAlertsRead(c, user, request.IDs); err != nil {
+			writeSavedSearchError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,73 @@
+package json
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/validation"
+)
+
+type credentialsRequest struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+type sessionView struct {
+	// Token - token of the session for the "Authorization: Bearer <token>" header
+	Token string `json:"token"`
+}
+
+// LoginHandler - starts a session of the user with given name and password
+func LoginHandler(svc services.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request credentialsRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		token, err := svc.Login(c, request.Name, request.Password)
+		if err != nil {
+			if errors.Is(err, services.AuthErrFailedToLogin) {
+				c.Status(http.StatusUnauthorized)
+				return
+			}
+			log.Println(err)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.JSON(http.StatusOK, sessionView{Token: token})
+	}
+}
+
+// RegisterHandler - creates a user with given name and password and starts their session
+func RegisterHandler(svc services.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request credentialsRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		token, err := svc.CreateUser(c, request.Name, request.Password)
+		if err != nil {
+			if validation.WriteError(c, err) {
+				return
+			}
+			if errors.Is(err, services.AuthErrUserExists) {
+				c.Status(http.StatusConflict)
+				return
+			}
+			log.Println(err)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.JSON(http.StatusCreated, sessionView{Token: token})
+	}
+}
@@ -0,0 +1,217 @@
+package json
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/validation"
+)
+
+type subjectCodeView struct {
+	Scheme string `json:"scheme"`
+	Code   string `json:"code"`
+}
+
+type subjectView struct {
+	ID       uuid.UUID         `json:"id"`
+	Name     string            `json:"name"`
+	Parent   *uuid.UUID        `json:"parent"`
+	Synonyms []string          `json:"synonyms"`
+	Codes    []subjectCodeView `json:"codes"`
+}
+
+// subjectTreeView - a subject with all its descendants
+type subjectTreeView struct {
+	subjectView
+	Children []subjectTreeView `json:"children"`
+}
+
+type subjectRequest struct {
+	Name     string            `json:"name"`
+	Parent   uuid.UUID         `json:"parent"`
+	Synonyms []string          `json:"synonyms"`
+	Codes    []subjectCodeView `json:"codes"`
+}
+
+func (r subjectRequest) info() services.SubjectInfo {
+	codes := make([]models.SubjectCode, len(r.Codes))
+	for i, c := range r.Codes {
+		codes[i] = models.SubjectCode{Scheme: c.Scheme, Code: c.Code}
+	}
+	synonyms := r.Synonyms
+	if synonyms == nil {
+		synonyms = make([]string, 0)
+	}
+	return services.SubjectInfo{Name: r.Name, Parent: r.Parent, Synonyms: synonyms, Codes: codes}
+}
+
+func buildSubjectView(s models.Subject) subjectView {
+	view := subjectView{
+		ID:       s.ID,
+		Name:     s.Name,
+		Synonyms: s.Synonyms,
+		Codes:    make([]subjectCodeView, len(s.Codes)),
+	}
+	if s.Parent != uuid.Nil {
+		parent := s.Parent
+		view.Parent = &parent
+	}
+	if view.Synonyms == nil {
+		view.Synonyms = make([]string, 0)
+	}
+	for i, c := range s.Codes {
+		view.Codes[i] = subjectCodeView{Scheme: c.Scheme, Code: c.Code}
+	}
+	return view
+}
+
+func buildSubjectTree(subjects []models.Subject, parent uuid.UUID) []subjectTreeView {
+	result := make([]subjectTreeView, 0)
+	for _, s := range subjects {
+		if s.Parent == parent {
+			result = append(result, subjectTreeView{
+				subjectView: buildSubjectView(s),
+				Children:    buildSubjectTree(subjects, s.ID),
+			})
+		}
+	}
+	return result
+}
+
+func GetAllSubjectsHandler(svc services.SubjectService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subjects, err := svc.GetAllExisting(c)
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		result := make([]subjectView, len(subjects))
+		for i, v := range subjects {
+			result[i] = buildSubjectView(v)
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetSubjectTreeHandler - returns the whole catalogue as a tree, starting from the root subjects
+func GetSubjectTreeHandler(svc services.SubjectService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subjects, err := svc.GetAllExisting(c)
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, buildSubjectTree(subjects, uuid.Nil))
+	}
+}
+
+func GetSubjectByIDHandler(svc services.SubjectService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		subject, err := svc.GetByID(c, id)
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, buildSubjectView(subject))
+	}
+}
+
+func CreateSubjectHandler(svc services.SubjectService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request subjectRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		subject, err := svc.Create(c, request.info())
+		if err != nil {
+			log.Println(err)
+			if validation.WriteError(c, err) {
+				return
+			}
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusCreated, buildSubjectView(subject))
+	}
+}
+
+func UpdateSubjectHandler(svc services.SubjectService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		var request subjectRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		subject, err := svc.Update(c, id, request.info())
+		if err != nil {
+			log.Println(err)
+			if validation.WriteError(c, err) {
+				return
+			}
+			if errors.Is(err, adapters.ErrNotFound) {
+				c.Status(http.StatusNotFound)
+				return
+			}
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, buildSubjectView(subject))
+	}
+}
+
+func DeleteSubjectHandler(svc services.SubjectService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		if err := svc.Delete(c, id); err != nil {
+			log.Println(err)
+			if errors.Is(err, services.ErrSubjectHasChildren) {
+				c.JSON(http.StatusConflict, gin.H{"msg": err.Error()})
+				return
+			}
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		c.Status(http.StatusOK)
+	}
+}
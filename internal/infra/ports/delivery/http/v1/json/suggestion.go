@@ -0,0 +1,82 @@
+package json
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+const (
+	defaultSuggestionLimit = 10
+	maxSuggestionLimit     = 50
+)
+
+var errSuggestionFailed = errors.New("failed to suggest")
+
+type suggestionView struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Score float64   `json:"score"`
+}
+
+type suggestFunc func(ctx context.Context, text string, limit int) ([]models.Suggestion, error)
+
+func OrganizerSuggestionHandler(svc services.OrganizerService) gin.HandlerFunc {
+	return suggestionHandler(func(ctx context.Context, text string, limit int) ([]models.Suggestion, error) {
+		return svc.Suggest(ctx, text, limit)
+	})
+}
+
+func SubjectSuggestionHandler(svc services.SubjectService) gin.HandlerFunc {
+	return suggestionHandler(func(ctx context.Context, text string, limit int) ([]models.Suggestion, error) {
+		return svc.Suggest(ctx, text, limit)
+	})
+}
+
+func CompetitorSuggestionHandler(svc services.CompetitorService) gin.HandlerFunc {
+	return suggestionHandler(func(ctx context.Context, text string, limit int) ([]models.Suggestion, error) {
+		result, err := svc.Suggest(ctx, text, limit)
+		if err != nil {
+			log.Println(err.LongErr())
+			return nil, errSuggestionFailed
+		}
+		return result, nil
+	})
+}
+
+// suggestionHandler - returns entities with names similar to "q", so the UI can offer them before creating new ones
+func suggestionHandler(suggest suggestFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		text := strings.TrimSpace(c.Query("q"))
+		if text == "" {
+			c.JSON(http.StatusOK, make([]suggestionView, 0))
+			return
+		}
+
+		limit, ok := parseLimit(c, defaultSuggestionLimit, maxSuggestionLimit)
+		if !ok {
+			return
+		}
+
+		suggestions, err := suggest(c, text, limit)
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+
+		result := make([]suggestionView, len(suggestions))
+		for i, v := range suggestions {
+			result[i] = suggestionView{ID: v.ID, Name: v.Name, Score: v.Score}
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
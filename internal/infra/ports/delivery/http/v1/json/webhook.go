@@ -0,0 +1,272 @@
+package json
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/validation"
+)
+
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 500
+)
+
+type webhookView struct {
+	ID    uuid.UUID `json:"id"`
+	URL   string    `json:"url"`
+	Types []string  `json:"types"`
+	// Secret - returned only when the webhook is created
+	Secret    string    `json:"secret,omitempty"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type webhookRequest struct {
+	URL string `json:"url"`
+	// Secret - generated on creation and kept on update if it's empty
+	Secret string   `json:"secret"`
+	Types  []string `json:"types"`
+	Active *bool    `json:"active"`
+}
+
+func (r webhookRequest) info() services.WebhookInfo {
+	active := true
+	if r.Active != nil {
+		active = *r.Active
+	}
+	return services.WebhookInfo{URL: r.URL, Secret: r.Secret, Types: r.Types, Active: active}
+}
+
+type webhookAttemptView struct {
+	At         time.Time `json:"at"`
+	StatusCode int       `json:"statusCode"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"durationMs"`
+}
+
+type webhookDeliveryView struct {
+	ID            uuid.UUID            `json:"id"`
+	Webhook       uuid.UUID            `json:"webhook"`
+	Type          string               `json:"type"`
+	Status        string               `json:"status"`
+	Attempts      int                  `json:"attempts"`
+	NextAttemptAt *time.Time           `json:"nextAttemptAt"`
+	CreatedAt     time.Time            `json:"createdAt"`
+	DeliveredAt   *time.Time           `json:"deliveredAt"`
+	History       []webhookAttemptView `json:"history"`
+}
+
+func buildWebhookView(w models.Webhook) webhookView {
+	return webhookView{ID: w.ID, URL: w.URL, Types: w.Types, Active: w.Active, CreatedAt: w.CreatedAt}
+}
+
+func buildWebhookDeliveryView(d models.WebhookDelivery) webhookDeliveryView {
+	view := webhookDeliveryView{
+		ID:        d.ID,
+		Webhook:   d.Webhook,
+		Type:      d.Type,
+		Status:    d.Status,
+		Attempts:  d.Attempts,
+		CreatedAt: d.CreatedAt,
+		History:   make([]webhookAttemptView, len(d.History)),
+	}
+	if d.Status == models.WebhookDeliveryPending {
+		view.NextAttemptAt = &d.NextAttemptAt
+	}
+	if !d.DeliveredAt.IsZero() {
+		view.DeliveredAt = &d.DeliveredAt
+	}
+	for i, a := range d.History {
+		view.History[i] = webhookAttemptView{At: a.At, StatusCode: a.StatusCode, Error: a.Error, DurationMs: a.Duration.Milliseconds()}
+	}
+	return view
+}
+
+func buildWebhookDeliveryViews(deliveries []models.WebhookDelivery) []webhookDeliveryView {
+	result := make([]webhookDeliveryView, len(deliveries))
+	for i, v := range deliveries {
+		result[i] = buildWebhookDeliveryView(v)
+	}
+	return result
+}
+
+// writeWebhookError - responds with the status of the service's error
+func writeWebhookError(c *gin.Context, err error) {
+	log.Println(err)
+	if validation.WriteError(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, adapters.ErrNotFound):
+		c.Status(http.StatusNotFound)
+	case errors.Is(err, services.ErrDeliveryIsNotDead):
+		c.JSON(http.StatusConflict, gin.H{"msg": err.Error()})
+	default:
+		c.Status(http.StatusInternalServerError)
+	}
+}
+
+func GetAllWebhooksHandler(svc services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		webhooks, err := svc.GetAll(c)
+		if err != nil {
+			writeWebhookError(c, err)
+			return
+		}
+
+		result := make([]webhookView, len(webhooks))
+		for i, v := range webhooks {
+			result[i] = buildWebhookView(v)
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func GetWebhookByIDHandler(svc services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		webhook, err := svc.GetByID(c, id)
+		if err != nil {
+			writeWebhookError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, buildWebhookView(webhook))
+	}
+}
+
+// CreateWebhookHandler - creates a subscription, the response has the secret of the signatures
+func CreateWebhookHandler(svc services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request webhookRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		webhook, err := svc.Create(c, request.info())
+		if err != nil {
+			writeWebhookError(c, err)
+			return
+		}
+
+		view := buildWebhookView(webhook)
+		view.Secret = webhook.Secret
+		c.JSON(http.StatusCreated, view)
+	}
+}
+
+func UpdateWebhookHandler(svc services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		var request webhookRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		webhook, err := svc.Update(c, id, request.info())
+		if err != nil {
+			writeWebhookError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, buildWebhookView(webhook))
+	}
+}
+
+func DeleteWebhookHandler(svc services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		if err := svc.Delete(c, id); err != nil {
+			writeWebhookError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// GetWebhookDeliveriesHandler - returns the delivery log of the webhook, "status" filters the deliveries
+func GetWebhookDeliveriesHandler(svc services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		limit, ok := parseLimit(c, defaultDeliveryLimit, maxDeliveryLimit)
+		if !ok {
+			return
+		}
+
+		deliveries, err := svc.GetDeliveries(c, id, c.Query("status"), limit)
+		if err != nil {
+			writeWebhookError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, buildWebhookDeliveryViews(deliveries))
+	}
+}
+
+func GetWebhookDeadLettersHandler(svc services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, ok := parseLimit(c, defaultDeliveryLimit, maxDeliveryLimit)
+		if !ok {
+			return
+		}
+
+		deliveries, err := svc.GetDeadLetters(c, limit)
+		if err != nil {
+			writeWebhookError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, buildWebhookDeliveryViews(deliveries))
+	}
+}
+
+// RetryWebhookDeliveryHandler - queues a dead delivery again, other deliveries are 409
+func RetryWebhookDeliveryHandler(svc services.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		if err := svc.Retry(c, id); err != nil {
+			writeWebhookError(c, err)
+			return
+		}
+		c.Status(http.StatusAccepted)
+	}
+}
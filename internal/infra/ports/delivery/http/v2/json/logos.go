@@ -0,0 +1,40 @@
+package json
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	v1 "github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/v1/json"
+	"github.com/indigowar/map-of-events/pkg/random"
+)
+
+// InlineLogos - the logos of v2 are the contents of the images, the organizer handlers of v1 are served with them
+func InlineLogos(svc services.ImageService) v1.Logos {
+	return inlineLogos{svc: svc}
+}
+
+type inlineLogos struct {
+	svc services.ImageService
+}
+
+// Link - a new image is created for every logo
+func (inlineLogos) Link(string) string {
+	return random.RandStringRunes(10)
+}
+
+func (l inlineLogos) Store(c *gin.Context, link, logo string) error {
+	_, err := l.svc.Create(c, link, []byte(logo))
+	return err
+}
+
+// Show - the logo is empty if its image can not be read
+func (l inlineLogos) Show(c *gin.Context, link string) string {
+	image, err := l.svc.Get(c, link)
+	if err != nil {
+		log.Println(err)
+		return ""
+	}
+	return string(image.Value)
+}
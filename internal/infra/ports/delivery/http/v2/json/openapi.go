@@ -0,0 +1,35 @@
+package json
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/openapi"
+)
+
+// organizerBinding - the organizer of v1 with the inlined logo, it's used only by the documentation
+type organizerBinding struct {
+	Id      uuid.UUID `json:"id"`
+	Name    string    `json:"name"`
+	Logo    string    `json:"logo"`
+	Level   uuid.UUID `json:"level"`
+	Version int       `json:"version"`
+}
+
+type createOrganizerInfo struct {
+	Name  string    `json:"name"`
+	Logo  string    `json:"logo"`
+	Level uuid.UUID `json:"level"`
+}
+
+// Routes - describes routes of this package for the OpenAPI document
+func Routes() []openapi.Route {
+	organizer := []string{"organizer"}
+
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/organizer", Summary: "Returns all organizers with nested logos", Tags: organizer, Response: []organizerBinding{}},
+		{Method: http.MethodPost, Path: "/organizer", Summary: "Creates an organizer with nested logo", Tags: organizer, Request: createOrganizerInfo{}, Response: organizerBinding{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/organizer/:id", Summary: "Returns an organizer with nested logo", Tags: organizer, Response: organizerBinding{}},
+	}
+}
@@ -0,0 +1,96 @@
+package json
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+	pkgerrors "github.com/indigowar/map-of-events/pkg/errors"
+)
+
+// basePath - the prefix of the links to the resources
+const basePath = "/api/v3"
+
+// Codes of the errors, they're the same as the codes of the GraphQL errors
+const (
+	codeValidationFailed = "VALIDATION_FAILED"
+	codeNotFound         = "NOT_FOUND"
+	codeInternal         = "INTERNAL"
+)
+
+// document - the envelope of every successful response, Data is a resource or a list of resources
+type document struct {
+	Data  interface{}       `json:"data"`
+	Meta  *meta             `json:"meta,omitempty"`
+	Links map[string]string `json:"links"`
+}
+
+// meta - given for the lists
+type meta struct {
+	Count int `json:"count"`
+}
+
+// errorDocument - the envelope of every failed response
+type errorDocument struct {
+	Error apiError `json:"error"`
+}
+
+type apiError struct {
+	Status     int                   `json:"status"`
+	Code       string                `json:"code"`
+	Message    string                `json:"message"`
+	Violations validators.Violations `json:"violations,omitempty"`
+}
+
+// writeResource - responds with a resource in the envelope, the fields are applied to it
+func writeResource(c *gin.Context, resource interface{}, q query) {
+	data, err := sparse(resource, q.fields)
+	if err != nil {
+		writeInternalError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, document{Data: data, Links: map[string]string{"self": c.Request.URL.RequestURI()}})
+}
+
+// writeList - responds with a list of resources in the envelope, the fields are applied to every resource
+func writeList(c *gin.Context, resources []interface{}, q query) {
+	data, err := sparse(resources, q.fields)
+	if err != nil {
+		writeInternalError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, document{Data: data, Meta: &meta{Count: len(resources)}, Links: map[string]string{"self": c.Request.URL.RequestURI()}})
+}
+
+// writeValidationError - if err is a validation error, responds with all violations and returns true
+func writeValidationError(c *gin.Context, err error) bool {
+	var violations validators.Violations
+	if !errors.As(err, &violations) {
+		return false
+	}
+	c.JSON(http.StatusBadRequest, errorDocument{Error: apiError{
+		Status: http.StatusBadRequest, Code: codeValidationFailed, Message: "validation failed", Violations: violations,
+	}})
+	return true
+}
+
+func writeNotFound(c *gin.Context, message string) {
+	c.JSON(http.StatusNotFound, errorDocument{Error: apiError{Status: http.StatusNotFound, Code: codeNotFound, Message: message}})
+}
+
+// writeInternalError - logs the error and responds without its details
+func writeInternalError(c *gin.Context, err error) {
+	log.Println(err)
+	c.JSON(http.StatusInternalServerError, errorDocument{Error: apiError{
+		Status: http.StatusInternalServerError, Code: codeInternal, Message: "internal error",
+	}})
+}
+
+// competitorError - the errors of the competitor service do not implement error,
+// the read API gets only the internal ones from it
+func competitorError(err pkgerrors.Error) error {
+	return errors.New(err.LongErr())
+}
@@ -0,0 +1,277 @@
+package json
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+// Handler - the read API of version 3, every response is an envelope, the related resources
+// are included by ?include= and the fields are selected by ?fields=
+type Handler struct {
+	svc services.Services
+}
+
+func NewHandler(s services.Services) Handler {
+	return Handler{svc: s}
+}
+
+// GetEvents - returns the events matching the filter, ?sort=funding or ?sort=-funding orders them
+// by the founding ranges converted to the reference currency
+func (h *Handler) GetEvents(c *gin.Context) {
+	q, ok := parseQuery(c, eventType)
+	if !ok {
+		return
+	}
+	filter, ok := parseEventFilter(c)
+	if !ok {
+		return
+	}
+	sort := c.Query("sort")
+	if writeValidationError(c, services.ValidateEventSort(sort)) {
+		return
+	}
+
+	events, err := h.svc.Event.Find(c, filter)
+	if err == nil && sort != "" {
+		events, err = h.svc.Event.SortByFunding(c, events, sort == services.EventSortFundingDescending)
+	}
+	if err != nil {
+		writeInternalError(c, err)
+		return
+	}
+
+	resources, err := newLoader(c, h.svc).events(events, q.include)
+	if err != nil {
+		writeInternalError(c, err)
+		return
+	}
+	writeList(c, resources, q)
+}
+
+func (h *Handler) GetEvent(c *gin.Context) {
+	q, ok := parseQuery(c, eventType)
+	if !ok {
+		return
+	}
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	events, err := h.svc.Event.GetByIDs(c, []uuid.UUID{id})
+	if err != nil {
+		writeInternalError(c, err)
+		return
+	}
+	if len(events) == 0 {
+		writeNotFound(c, "event was not found")
+		return
+	}
+
+	resources, err := newLoader(c, h.svc).events(events, q.include)
+	if err != nil {
+		writeInternalError(c, err)
+		return
+	}
+	writeResource(c, resources[0], q)
+}
+
+func (h *Handler) GetOrganizers(c *gin.Context) {
+	q, ok := parseQuery(c, organizerType)
+	if !ok {
+		return
+	}
+
+	organizers, err := h.svc.Organizer.GetAll(c)
+	if err != nil {
+		writeInternalError(c, err)
+		return
+	}
+
+	l := newLoader(c, h.svc)
+	resources := make([]interface{}, len(organizers))
+	for i, o := range organizers {
+		if resources[i], err = l.buildOrganizer(o, q.include); err != nil {
+			writeInternalError(c, err)
+			return
+		}
+	}
+	writeList(c, resources, q)
+}
+
+func (h *Handler) GetOrganizer(c *gin.Context) {
+	q, ok := parseQuery(c, organizerType)
+	if !ok {
+		return
+	}
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	l := newLoader(c, h.svc)
+	o, ok, err := l.organizer(id)
+	if err != nil {
+		writeInternalError(c, err)
+		return
+	}
+	if !ok {
+		writeNotFound(c, "organizer was not found")
+		return
+	}
+
+	resource, err := l.buildOrganizer(o, q.include)
+	if err != nil {
+		writeInternalError(c, err)
+		return
+	}
+	writeResource(c, resource, q)
+}
+
+func (h *Handler) GetOrganizerLevels(c *gin.Context) {
+	q, ok := parseQuery(c, levelType)
+	if !ok {
+		return
+	}
+
+	levels, err := h.svc.Organizer.GetAllLevels(c)
+	if err != nil {
+		writeInternalError(c, err)
+		return
+	}
+
+	resources := make([]interface{}, len(levels))
+	for i, v := range levels {
+		resources[i] = buildLevel(v)
+	}
+	writeList(c, resources, q)
+}
+
+func (h *Handler) GetOrganizerLevel(c *gin.Context) {
+	q, ok := parseQuery(c, levelType)
+	if !ok {
+		return
+	}
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	level, ok, err := newLoader(c, h.svc).level(id)
+	if err != nil {
+		writeInternalError(c, err)
+		return
+	}
+	if !ok {
+		writeNotFound(c, "organizer level was not found")
+		return
+	}
+	writeResource(c, buildLevel(level), q)
+}
+
+func (h *Handler) GetCompetitors(c *gin.Context) {
+	q, ok := parseQuery(c, competitorType)
+	if !ok {
+		return
+	}
+
+	competitors, err := h.svc.Competitor.GetAll(c)
+	if err != nil {
+		writeInternalError(c, competitorError(err))
+		return
+	}
+
+	resources := make([]interface{}, len(competitors))
+	for i, v := range competitors {
+		resources[i] = buildCompetitor(v)
+	}
+	writeList(c, resources, q)
+}
+
+func (h *Handler) GetCompetitor(c *gin.Context) {
+	q, ok := parseQuery(c, competitorType)
+	if !ok {
+		return
+	}
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	competitor, ok, err := newLoader(c, h.svc).competitor(id)
+	if err != nil {
+		writeInternalError(c, err)
+		return
+	}
+	if !ok {
+		writeNotFound(c, "competitor was not found")
+		return
+	}
+	writeResource(c, buildCompetitor(competitor), q)
+}
+
+func (h *Handler) GetSubjects(c *gin.Context) {
+	q, ok := parseQuery(c, subjectType)
+	if !ok {
+		return
+	}
+
+	subjects, err := h.svc.Subject.GetAllExisting(c)
+	if err != nil {
+		writeInternalError(c, err)
+		return
+	}
+
+	l := newLoader(c, h.svc)
+	resources := make([]interface{}, len(subjects))
+	for i, s := range subjects {
+		if resources[i], err = l.buildSubject(s, q.include); err != nil {
+			writeInternalError(c, err)
+			return
+		}
+	}
+	writeList(c, resources, q)
+}
+
+func (h *Handler) GetSubject(c *gin.Context) {
+	q, ok := parseQuery(c, subjectType)
+	if !ok {
+		return
+	}
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	l := newLoader(c, h.svc)
+	s, ok, err := l.subject(id)
+	if err != nil {
+		writeInternalError(c, err)
+		return
+	}
+	if !ok {
+		writeNotFound(c, "subject was not found")
+		return
+	}
+
+	resource, err := l.buildSubject(s, q.include)
+	if err != nil {
+		writeInternalError(c, err)
+		return
+	}
+	writeResource(c, resource, q)
+}
+
+// GetImage - returns the image itself, not wrapped in the envelope, the links to it are given by the resources
+func (h *Handler) GetImage(c *gin.Context) {
+	image, err := h.svc.Image.Get(c, c.Param("link"))
+	if err != nil {
+		writeNotFound(c, "image was not found")
+		return
+	}
+	c.Data(http.StatusOK, http.DetectContentType(image.Value), image.Value)
+}
@@ -0,0 +1,87 @@
+package json
+
+import (
+	"net/http"
+
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/openapi"
+)
+
+// The documents of the routes, they're used only to describe the responses,
+// the handlers respond with document, whose Data depends on ?include= and ?fields=
+type (
+	eventDocument struct {
+		Data  eventResource     `json:"data"`
+		Links map[string]string `json:"links"`
+	}
+	eventListDocument struct {
+		Data  []eventResource   `json:"data"`
+		Meta  meta              `json:"meta"`
+		Links map[string]string `json:"links"`
+	}
+	organizerDocument struct {
+		Data  organizerResource `json:"data"`
+		Links map[string]string `json:"links"`
+	}
+	organizerListDocument struct {
+		Data  []organizerResource `json:"data"`
+		Meta  meta                `json:"meta"`
+		Links map[string]string   `json:"links"`
+	}
+	levelDocument struct {
+		Data  levelResource     `json:"data"`
+		Links map[string]string `json:"links"`
+	}
+	levelListDocument struct {
+		Data  []levelResource   `json:"data"`
+		Meta  meta              `json:"meta"`
+		Links map[string]string `json:"links"`
+	}
+	competitorDocument struct {
+		Data  competitorResource `json:"data"`
+		Links map[string]string  `json:"links"`
+	}
+	competitorListDocument struct {
+		Data  []competitorResource `json:"data"`
+		Meta  meta                 `json:"meta"`
+		Links map[string]string    `json:"links"`
+	}
+	subjectDocument struct {
+		Data  subjectResource   `json:"data"`
+		Links map[string]string `json:"links"`
+	}
+	subjectListDocument struct {
+		Data  []subjectResource `json:"data"`
+		Meta  meta              `json:"meta"`
+		Links map[string]string `json:"links"`
+	}
+)
+
+// Routes - describes routes of this package for the OpenAPI document
+func Routes() []openapi.Route {
+	event := []string{"v3 event"}
+	organizer := []string{"v3 organizer"}
+	competitor := []string{"v3 competitor"}
+	subject := []string{"v3 subject"}
+	image := []string{"v3 image"}
+
+	query := []string{"include", "fields"}
+	eventQuery := append(append([]string{"sort"}, query...), eventFilterQuery...)
+
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: eventsPath, Summary: "Returns the events matching the filter(include: organizer, organizer.level, competitors, subjects)", Tags: event, Query: eventQuery, Response: eventListDocument{}},
+		{Method: http.MethodGet, Path: eventsPath + "/:id", Summary: "Returns an event(include: organizer, organizer.level, competitors, subjects)", Tags: event, Query: query, Response: eventDocument{}},
+
+		{Method: http.MethodGet, Path: organizersPath, Summary: "Returns all organizers(include: level)", Tags: organizer, Query: query, Response: organizerListDocument{}},
+		{Method: http.MethodGet, Path: organizersPath + "/:id", Summary: "Returns an organizer(include: level)", Tags: organizer, Query: query, Response: organizerDocument{}},
+		{Method: http.MethodGet, Path: organizerLevelsPath, Summary: "Returns all organizer levels", Tags: organizer, Query: query, Response: levelListDocument{}},
+		{Method: http.MethodGet, Path: organizerLevelsPath + "/:id", Summary: "Returns an organizer level", Tags: organizer, Query: query, Response: levelDocument{}},
+
+		{Method: http.MethodGet, Path: competitorsPath, Summary: "Returns all competitors", Tags: competitor, Query: query, Response: competitorListDocument{}},
+		{Method: http.MethodGet, Path: competitorsPath + "/:id", Summary: "Returns a competitor", Tags: competitor, Query: query, Response: competitorDocument{}},
+
+		{Method: http.MethodGet, Path: subjectsPath, Summary: "Returns all subjects(include: parent, parent.parent, ...)", Tags: subject, Query: query, Response: subjectListDocument{}},
+		{Method: http.MethodGet, Path: subjectsPath + "/:id", Summary: "Returns a subject(include: parent, parent.parent, ...)", Tags: subject, Query: query, Response: subjectDocument{}},
+
+		{Method: http.MethodGet, Path: imagesPath + "/:link", Summary: "Returns an image, the organizers link to their logos", Tags: image, Response: "", ResponseContentType: openapi.ContentTypeBinary},
+	}
+}
@@ -0,0 +1,261 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+)
+
+// resourceType - the fields of a resource, which can be selected by ?fields=,
+// and its relationships, which can be included by ?include=
+type resourceType struct {
+	name          string
+	fields        []string
+	relationships map[string]*resourceType
+}
+
+var (
+	levelType = &resourceType{name: "organizerLevel", fields: []string{"name", "code"}}
+
+	organizerType = &resourceType{name: "organizer", fields: []string{"name", "level", "version"},
+		relationships: map[string]*resourceType{"level": levelType}}
+
+	competitorType = &resourceType{name: "competitor",
+		fields: []string{"name", "category", "minAge", "maxAge", "minDegree", "regions", "organizationTypes"}}
+
+	subjectType = &resourceType{name: "subject", fields: []string{"name", "parent", "synonyms", "codes"}}
+
+	eventType = &resourceType{name: "event",
+		fields: []string{"title", "organizer", "foundingType", "foundingRange", "coFoundingRange", "submissionDeadline",
+			"considerationPeriod", "realisationPeriod", "result", "site", "document", "internalContacts", "trl",
+			"competitors", "subjects", "version"},
+		relationships: map[string]*resourceType{"organizer": organizerType, "competitors": competitorType, "subjects": subjectType}}
+)
+
+func init() {
+	// the parent of a subject is a subject
+	subjectType.relationships = map[string]*resourceType{"parent": subjectType}
+}
+
+// alwaysSelected - the fields of every resource, which are returned with any ?fields=
+var alwaysSelected = []string{"id", "type", "links"}
+
+// pathSet - a tree of the dotted paths(organizer.level), a nil subtree means the whole object
+type pathSet map[string]pathSet
+
+func (s pathSet) has(name string) bool {
+	_, ok := s[name]
+	return ok
+}
+
+// add - adds a path, if whole is set a nil subtree means the whole object, so it replaces the paths to the fields
+// of the object(?fields=), otherwise it means only the object itself and the longer paths are kept(?include=)
+func (s pathSet) add(path []string, whole bool) {
+	sub, ok := s[path[0]]
+	if len(path) == 1 {
+		if whole || !ok {
+			s[path[0]] = nil
+		}
+		return
+	}
+	if ok && sub == nil && whole {
+		// the whole object is selected already
+		return
+	}
+	if sub == nil {
+		sub = pathSet{}
+		s[path[0]] = sub
+	}
+	sub.add(path[1:], whole)
+}
+
+// query - the parameters shared by all resources: ?include=organizer,organizer.level and ?fields=title,organizer.name
+type query struct {
+	include pathSet
+	// fields - nil if all fields are selected
+	fields pathSet
+}
+
+// parseQuery - reads include and fields of the resource type, if they're invalid responds and returns false
+func parseQuery(c *gin.Context, t *resourceType) (query, bool) {
+	violations := make(validators.Violations, 0)
+	q := query{include: pathSet{}}
+
+	for _, path := range splitList(c.Query("include")) {
+		current := t
+		segments := strings.Split(path, ".")
+		for _, segment := range segments {
+			next, ok := current.relationships[segment]
+			if !ok {
+				violations = append(violations, validators.Violation{Field: "include",
+					Message: fmt.Sprintf("%q is not a relationship of %s", path, current.name)})
+				break
+			}
+			current = next
+		}
+		q.include.add(segments, false)
+	}
+
+	if fields := splitList(c.Query("fields")); len(fields) != 0 {
+		q.fields = pathSet{}
+		for _, path := range fields {
+			current := t
+			segments := strings.Split(path, ".")
+			for i, segment := range segments {
+				if !isField(current, segment) {
+					violations = append(violations, validators.Violation{Field: "fields",
+						Message: fmt.Sprintf("%q is not a field of %s", path, current.name)})
+					break
+				}
+				if i < len(segments)-1 {
+					next, ok := current.relationships[segment]
+					if !ok {
+						violations = append(violations, validators.Violation{Field: "fields",
+							Message: fmt.Sprintf("%q is not a relationship of %s", path, current.name)})
+						break
+					}
+					current = next
+				}
+			}
+			q.fields.add(segments, true)
+		}
+	}
+
+	if writeValidationError(c, validators.Validate(func() validators.Violations { return violations })) {
+		return query{}, false
+	}
+	return q, true
+}
+
+func isField(t *resourceType, name string) bool {
+	return validators.StringExists(alwaysSelected, name) || validators.StringExists(t.fields, name)
+}
+
+func splitList(value string) []string {
+	result := make([]string, 0)
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// sparse - leaves only the selected fields of the resources, the resources are converted to JSON objects for it
+func sparse(resources interface{}, fields pathSet) (interface{}, error) {
+	if fields == nil {
+		return resources, nil
+	}
+
+	data, err := json.Marshal(resources)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// large amounts should not be converted to floats
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return prune(value, fields), nil
+}
+
+func prune(value interface{}, fields pathSet) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		for i := range v {
+			v[i] = prune(v[i], fields)
+		}
+	case map[string]interface{}:
+		for key, field := range v {
+			if validators.StringExists(alwaysSelected, key) {
+				continue
+			}
+			sub, ok := fields[key]
+			if !ok {
+				delete(v, key)
+				continue
+			}
+			if sub != nil {
+				v[key] = prune(field, sub)
+			}
+		}
+	}
+	return value
+}
+
+// eventFilterQuery - names of the query parameters of the event filter, they're the same as in v1
+var eventFilterQuery = []string{"organizers", "competitors", "subjects", "minTrl", "maxTrl", "minFunding", "maxFunding"}
+
+// parseEventFilter - reads the event filter from the query, ids are given by repeated parameters
+// (?subjects=...&subjects=...), if the filter is invalid responds and returns false
+func parseEventFilter(c *gin.Context) (models.EventFilter, bool) {
+	violations := make(validators.Violations, 0)
+
+	ids := func(name string) []uuid.UUID {
+		values := c.QueryArray(name)
+		if len(values) == 0 {
+			return nil
+		}
+		result := make([]uuid.UUID, 0, len(values))
+		for i, v := range values {
+			id, err := uuid.Parse(v)
+			if err != nil {
+				violations = append(violations, validators.Violation{Field: fmt.Sprintf("%s[%d]", name, i), Message: "is not a valid id"})
+				continue
+			}
+			result = append(result, id)
+		}
+		return result
+	}
+	number := func(name string) int {
+		value := c.Query(name)
+		if value == "" {
+			return 0
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			violations = append(violations, validators.Violation{Field: name, Message: "is not a number"})
+		}
+		return n
+	}
+
+	filter := models.EventFilter{
+		Organizers:  ids("organizers"),
+		Competitors: ids("competitors"),
+		Subjects:    ids("subjects"),
+		MinTRL:      number("minTrl"),
+		MaxTRL:      number("maxTrl"),
+		MinFunding:  number("minFunding"),
+		MaxFunding:  number("maxFunding"),
+	}
+	if len(violations) != 0 {
+		writeValidationError(c, violations)
+		return models.EventFilter{}, false
+	}
+
+	if writeValidationError(c, services.ValidateEventFilter(filter)) {
+		return models.EventFilter{}, false
+	}
+	return filter, true
+}
+
+// parseID - reads the id from the path, if it's invalid responds and returns false
+func parseID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		writeValidationError(c, validators.Violations{{Field: "id", Message: "is not a valid id"}})
+		return uuid.Nil, false
+	}
+	return id, true
+}
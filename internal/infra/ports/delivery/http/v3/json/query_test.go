@@ -0,0 +1,136 @@
+package json
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		query   string
+		include pathSet
+		fields  pathSet
+		valid   bool
+	}{
+		{name: "nothing", query: "", include: pathSet{}, valid: true},
+		{
+			name:    "nested include",
+			query:   "include=organizer.level,subjects.parent.parent",
+			include: pathSet{"organizer": {"level": nil}, "subjects": {"parent": {"parent": nil}}},
+			valid:   true,
+		},
+		{
+			name:    "include of a whole object and its part",
+			query:   "include=organizer,organizer.level",
+			include: pathSet{"organizer": {"level": nil}},
+			valid:   true,
+		},
+		{
+			name:    "fields of an included object",
+			query:   "include=organizer&fields=title,organizer.name",
+			include: pathSet{"organizer": nil},
+			fields:  pathSet{"title": nil, "organizer": {"name": nil}},
+			valid:   true,
+		},
+		{
+			name:    "whole object replaces its fields",
+			query:   "fields=organizer.name,%20organizer",
+			include: pathSet{},
+			fields:  pathSet{"organizer": nil},
+			valid:   true,
+		},
+		{name: "unknown relationship", query: "include=site"},
+		{name: "unknown relationship of a relationship", query: "include=organizer.competitors"},
+		{name: "unknown field", query: "fields=pages"},
+		{name: "field of a field, which is not a relationship", query: "fields=title.name"},
+	}
+
+	for _, tt := range tests {
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		c.Request = httptest.NewRequest(http.MethodGet, "/event?"+tt.query, nil)
+
+		q, ok := parseQuery(c, eventType)
+		if ok != tt.valid {
+			t.Errorf("%s: parseQuery = %v, want %v, response %d %s", tt.name, ok, tt.valid, recorder.Code, recorder.Body)
+			continue
+		}
+		if !ok {
+			if recorder.Code != http.StatusBadRequest {
+				t.Errorf("%s: status = %d, want %d", tt.name, recorder.Code, http.StatusBadRequest)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(q.include, tt.include) || !reflect.DeepEqual(q.fields, tt.fields) {
+			t.Errorf("%s: include = %v, fields = %v, want %v, %v", tt.name, q.include, q.fields, tt.include, tt.fields)
+		}
+	}
+}
+
+func TestSparse(t *testing.T) {
+	type level struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Code string `json:"code"`
+	}
+	type organizer struct {
+		ID    string `json:"id"`
+		Type  string `json:"type"`
+		Name  string `json:"name"`
+		Level level  `json:"level"`
+	}
+	type event struct {
+		ID        string    `json:"id"`
+		Type      string    `json:"type"`
+		Title     string    `json:"title"`
+		Amount    int64     `json:"amount"`
+		Organizer organizer `json:"organizer"`
+	}
+
+	events := []event{{
+		ID: "1", Type: "event", Title: "Grant", Amount: 9007199254740993,
+		Organizer: organizer{ID: "2", Type: "organizer", Name: "Fund", Level: level{ID: "3", Name: "Federal", Code: "F"}},
+	}}
+
+	tests := []struct {
+		name   string
+		fields pathSet
+		want   string
+	}{
+		{
+			name: "all fields",
+			want: `[{"id":"1","type":"event","title":"Grant","amount":9007199254740993,"organizer":{"id":"2","type":"organizer","name":"Fund","level":{"id":"3","name":"Federal","code":"F"}}}]`,
+		},
+		{
+			// large amounts are kept as they are
+			name:   "top fields",
+			fields: pathSet{"amount": nil},
+			want:   `[{"amount":9007199254740993,"id":"1","type":"event"}]`,
+		},
+		{
+			name:   "nested fields",
+			fields: pathSet{"organizer": {"level": {"code": nil}}},
+			want:   `[{"id":"1","organizer":{"id":"2","level":{"code":"F","id":"3"},"type":"organizer"},"type":"event"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		result, err := sparse(events, tt.fields)
+		if err != nil {
+			t.Errorf("%s: sparse: %v", tt.name, err)
+			continue
+		}
+		got, _ := json.Marshal(result)
+		if string(got) != tt.want {
+			t.Errorf("%s: sparse = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
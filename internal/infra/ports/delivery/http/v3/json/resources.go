@@ -0,0 +1,387 @@
+package json
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+// reference - a related resource, which is not included
+type reference struct {
+	ID    uuid.UUID         `json:"id"`
+	Type  string            `json:"type"`
+	Links map[string]string `json:"links"`
+}
+
+// rangeView - the co-founding range in percents
+type rangeView struct {
+	Low  int64 `json:"low"`
+	High int64 `json:"high"`
+}
+
+// moneyView - the amount is in the minor units of the currency, e.g. kopecks of RUB
+type moneyView struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// foundingRangeView - Reference is the range converted to the reference currency, it's omitted
+// if there is no rate of the range's currency
+type foundingRangeView struct {
+	Low       moneyView          `json:"low"`
+	High      moneyView          `json:"high"`
+	Reference *foundingRangeView `json:"reference,omitempty"`
+}
+
+func newFoundingRangeView(r models.RangeModel, currency services.CurrencyService) foundingRangeView {
+	view := foundingRangeView{
+		Low:  moneyView{Amount: r.Low, Currency: r.Currency},
+		High: moneyView{Amount: r.High, Currency: r.Currency},
+	}
+	if converted := services.ReferenceRange(currency, r); converted.Currency != "" {
+		view.Reference = &foundingRangeView{
+			Low:  moneyView{Amount: converted.Low, Currency: converted.Currency},
+			High: moneyView{Amount: converted.High, Currency: converted.Currency},
+		}
+	}
+	return view
+}
+
+type subjectCodeView struct {
+	Scheme string `json:"scheme"`
+	Code   string `json:"code"`
+}
+
+// The relationships are references or, if they're included, the resources themselves
+
+type eventResource struct {
+	ID                  uuid.UUID         `json:"id"`
+	Type                string            `json:"type"`
+	Title               string            `json:"title"`
+	Organizer           interface{}       `json:"organizer"`
+	FoundingType        string            `json:"foundingType"`
+	FoundingRange       foundingRangeView `json:"foundingRange"`
+	CoFoundingRange     rangeView         `json:"coFoundingRange"`
+	SubmissionDeadline  time.Time         `json:"submissionDeadline"`
+	ConsiderationPeriod string            `json:"considerationPeriod"`
+	RealisationPeriod   string            `json:"realisationPeriod"`
+	Result              string            `json:"result"`
+	Site                string            `json:"site"`
+	Document            string            `json:"document"`
+	InternalContacts    string            `json:"internalContacts"`
+	TRL                 int               `json:"trl"`
+	Competitors         []interface{}     `json:"competitors"`
+	Subjects            []interface{}     `json:"subjects"`
+	Version             int               `json:"version"`
+	Links               map[string]string `json:"links"`
+}
+
+// organizerResource - links.logo is the image of the logo, it's not given if the organizer has no logo
+type organizerResource struct {
+	ID      uuid.UUID         `json:"id"`
+	Type    string            `json:"type"`
+	Name    string            `json:"name"`
+	Level   interface{}       `json:"level"`
+	Version int               `json:"version"`
+	Links   map[string]string `json:"links"`
+}
+
+type levelResource struct {
+	ID    uuid.UUID         `json:"id"`
+	Type  string            `json:"type"`
+	Name  string            `json:"name"`
+	Code  string            `json:"code"`
+	Links map[string]string `json:"links"`
+}
+
+type competitorResource struct {
+	ID                uuid.UUID         `json:"id"`
+	Type              string            `json:"type"`
+	Name              string            `json:"name"`
+	Category          string            `json:"category"`
+	MinAge            int               `json:"minAge"`
+	MaxAge            int               `json:"maxAge"`
+	MinDegree         string            `json:"minDegree"`
+	Regions           []string          `json:"regions"`
+	OrganizationTypes []string          `json:"organizationTypes"`
+	Links             map[string]string `json:"links"`
+}
+
+// subjectResource - Parent is null for the root subjects
+type subjectResource struct {
+	ID       uuid.UUID         `json:"id"`
+	Type     string            `json:"type"`
+	Name     string            `json:"name"`
+	Parent   interface{}       `json:"parent"`
+	Synonyms []string          `json:"synonyms"`
+	Codes    []subjectCodeView `json:"codes"`
+	Links    map[string]string `json:"links"`
+}
+
+// Paths of the resources
+const (
+	eventsPath          = "/events"
+	organizersPath      = "/organizers"
+	organizerLevelsPath = "/organizer_levels"
+	competitorsPath     = "/competitors"
+	subjectsPath        = "/subjects"
+	imagesPath          = "/images"
+)
+
+func selfLinks(path string, id uuid.UUID) map[string]string {
+	return map[string]string{"self": basePath + path + "/" + id.String()}
+}
+
+func newReference(t *resourceType, path string, id uuid.UUID) reference {
+	return reference{ID: id, Type: t.name, Links: selfLinks(path, id)}
+}
+
+// loader - loads the related resources of a request once, the catalogues are small,
+// so they're loaded whole instead of one query per resource
+type loader struct {
+	ctx context.Context
+	svc services.Services
+
+	organizers  map[uuid.UUID]models.Organizer
+	levels      map[uuid.UUID]models.OrganizerLevel
+	competitors map[uuid.UUID]models.Competitor
+	subjects    map[uuid.UUID]models.Subject
+}
+
+func newLoader(ctx context.Context, svc services.Services) *loader {
+	return &loader{ctx: ctx, svc: svc}
+}
+
+func (l *loader) organizer(id uuid.UUID) (models.Organizer, bool, error) {
+	if l.organizers == nil {
+		organizers, err := l.svc.Organizer.GetAll(l.ctx)
+		if err != nil {
+			return models.Organizer{}, false, err
+		}
+		l.organizers = make(map[uuid.UUID]models.Organizer, len(organizers))
+		for _, o := range organizers {
+			l.organizers[o.ID] = o
+		}
+	}
+	o, ok := l.organizers[id]
+	return o, ok, nil
+}
+
+func (l *loader) level(id uuid.UUID) (models.OrganizerLevel, bool, error) {
+	if l.levels == nil {
+		levels, err := l.svc.Organizer.GetAllLevels(l.ctx)
+		if err != nil {
+			return models.OrganizerLevel{}, false, err
+		}
+		l.levels = make(map[uuid.UUID]models.OrganizerLevel, len(levels))
+		for _, v := range levels {
+			l.levels[v.ID] = v
+		}
+	}
+	v, ok := l.levels[id]
+	return v, ok, nil
+}
+
+func (l *loader) competitor(id uuid.UUID) (models.Competitor, bool, error) {
+	if l.competitors == nil {
+		competitors, err := l.svc.Competitor.GetAll(l.ctx)
+		if err != nil {
+			return models.Competitor{}, false, competitorError(err)
+		}
+		l.competitors = make(map[uuid.UUID]models.Competitor, len(competitors))
+		for _, v := range competitors {
+			l.competitors[v.ID] = v
+		}
+	}
+	v, ok := l.competitors[id]
+	return v, ok, nil
+}
+
+func (l *loader) subject(id uuid.UUID) (models.Subject, bool, error) {
+	if l.subjects == nil {
+		subjects, err := l.svc.Subject.GetAllExisting(l.ctx)
+		if err != nil {
+			return models.Subject{}, false, err
+		}
+		l.subjects = make(map[uuid.UUID]models.Subject, len(subjects))
+		for _, v := range subjects {
+			l.subjects[v.ID] = v
+		}
+	}
+	v, ok := l.subjects[id]
+	return v, ok, nil
+}
+
+// events - builds the resources of the events, the subjects are loaded for all events at once
+func (l *loader) events(events []models.Event, include pathSet) ([]interface{}, error) {
+	ids := make([]uuid.UUID, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+	}
+
+	subjects, err := l.svc.Subject.GetAllForEvents(l.ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]interface{}, len(events))
+	for i, e := range events {
+		resource := eventResource{
+			ID:                  e.ID,
+			Type:                eventType.name,
+			Title:               e.Title,
+			FoundingType:        e.FoundingType,
+			FoundingRange:       newFoundingRangeView(e.FoundingRange, l.svc.Currency),
+			CoFoundingRange:     rangeView{Low: e.CoFoundingRange.Low, High: e.CoFoundingRange.High},
+			SubmissionDeadline:  e.SubmissionDeadline,
+			ConsiderationPeriod: e.ConsiderationPeriod,
+			RealisationPeriod:   e.RealisationPeriod,
+			Result:              e.Result,
+			Site:                e.Site,
+			Document:            e.Document,
+			InternalContacts:    e.InternalContacts,
+			TRL:                 e.TRL,
+			Competitors:         make([]interface{}, 0, len(e.Competitors)),
+			Subjects:            make([]interface{}, 0, len(subjects[e.ID])),
+			Version:             e.Version,
+			Links:               selfLinks(eventsPath, e.ID),
+		}
+
+		if resource.Organizer, err = l.organizerRelationship(e.Organizer, include); err != nil {
+			return nil, err
+		}
+		for _, id := range e.Competitors {
+			competitor, err := l.competitorRelationship(id, include)
+			if err != nil {
+				return nil, err
+			}
+			resource.Competitors = append(resource.Competitors, competitor)
+		}
+		for _, s := range subjects[e.ID] {
+			var subject interface{} = newReference(subjectType, subjectsPath, s.ID)
+			if include.has("subjects") {
+				if subject, err = l.buildSubject(s, include["subjects"]); err != nil {
+					return nil, err
+				}
+			}
+			resource.Subjects = append(resource.Subjects, subject)
+		}
+		result[i] = resource
+	}
+	return result, nil
+}
+
+// organizerRelationship - the organizer of an event, it's a reference, if it's not included or does not exist anymore
+func (l *loader) organizerRelationship(id uuid.UUID, include pathSet) (interface{}, error) {
+	if !include.has("organizer") {
+		return newReference(organizerType, organizersPath, id), nil
+	}
+	o, ok, err := l.organizer(id)
+	if err != nil || !ok {
+		return newReference(organizerType, organizersPath, id), err
+	}
+	return l.buildOrganizer(o, include["organizer"])
+}
+
+func (l *loader) competitorRelationship(id uuid.UUID, include pathSet) (interface{}, error) {
+	if !include.has("competitors") {
+		return newReference(competitorType, competitorsPath, id), nil
+	}
+	c, ok, err := l.competitor(id)
+	if err != nil || !ok {
+		return newReference(competitorType, competitorsPath, id), err
+	}
+	return buildCompetitor(c), nil
+}
+
+func (l *loader) buildOrganizer(o models.Organizer, include pathSet) (interface{}, error) {
+	resource := organizerResource{
+		ID:      o.ID,
+		Type:    organizerType.name,
+		Name:    o.Name,
+		Level:   newReference(levelType, organizerLevelsPath, o.Level),
+		Version: o.Version,
+		Links:   selfLinks(organizersPath, o.ID),
+	}
+	if o.Logo != "" {
+		resource.Links["logo"] = basePath + imagesPath + "/" + url.PathEscape(o.Logo)
+	}
+
+	if include.has("level") {
+		level, ok, err := l.level(o.Level)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			resource.Level = buildLevel(level)
+		}
+	}
+	return resource, nil
+}
+
+func buildLevel(level models.OrganizerLevel) levelResource {
+	return levelResource{
+		ID:    level.ID,
+		Type:  levelType.name,
+		Name:  level.Name,
+		Code:  level.Code,
+		Links: selfLinks(organizerLevelsPath, level.ID),
+	}
+}
+
+func buildCompetitor(c models.Competitor) competitorResource {
+	return competitorResource{
+		ID:                c.ID,
+		Type:              competitorType.name,
+		Name:              c.Name,
+		Category:          c.Category,
+		MinAge:            c.MinAge,
+		MaxAge:            c.MaxAge,
+		MinDegree:         c.MinDegree,
+		Regions:           nonNil(c.Regions),
+		OrganizationTypes: nonNil(c.OrganizationTypes),
+		Links:             selfLinks(competitorsPath, c.ID),
+	}
+}
+
+func (l *loader) buildSubject(s models.Subject, include pathSet) (interface{}, error) {
+	resource := subjectResource{
+		ID:       s.ID,
+		Type:     subjectType.name,
+		Name:     s.Name,
+		Synonyms: nonNil(s.Synonyms),
+		Codes:    make([]subjectCodeView, len(s.Codes)),
+		Links:    selfLinks(subjectsPath, s.ID),
+	}
+	for i, c := range s.Codes {
+		resource.Codes[i] = subjectCodeView{Scheme: c.Scheme, Code: c.Code}
+	}
+
+	if s.Parent != uuid.Nil {
+		resource.Parent = newReference(subjectType, subjectsPath, s.Parent)
+		if include.has("parent") {
+			parent, ok, err := l.subject(s.Parent)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				if resource.Parent, err = l.buildSubject(parent, include["parent"]); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return resource, nil
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,26 @@
+package validation
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+)
+
+// ErrorResponse - the body of a response to a request, that failed the validation
+type ErrorResponse struct {
+	Msg    string                `json:"msg"`
+	Errors validators.Violations `json:"errors"`
+}
+
+// WriteError - if err is a validation error, responds with all violations and returns true
+func WriteError(c *gin.Context, err error) bool {
+	var violations validators.Violations
+	if !errors.As(err, &violations) {
+		return false
+	}
+	c.JSON(http.StatusBadRequest, ErrorResponse{Msg: "validation failed", Errors: violations})
+	return true
+}
@@ -0,0 +1,83 @@
+package versioning
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/indigowar/map-of-events/internal/infra/ports/delivery/http/auth"
+)
+
+// maxUsageEntries - when there are more entries, the ones without unlogged requests are dropped
+const maxUsageEntries = 10000
+
+// usageLog - logs the requests to the deprecated routes per client, the first request of a client
+// to a route is logged at once, the next ones are counted and logged at most once per interval
+type usageLog struct {
+	interval time.Duration
+
+	mu      sync.Mutex
+	entries map[usageKey]*usageEntry
+}
+
+type usageKey struct {
+	client string
+	method string
+	path   string
+}
+
+type usageEntry struct {
+	loggedAt time.Time
+	// count - the requests since loggedAt
+	count int
+}
+
+func newUsageLog(interval time.Duration) *usageLog {
+	return &usageLog{interval: interval, entries: make(map[usageKey]*usageEntry)}
+}
+
+func (l *usageLog) record(c *gin.Context, path string) {
+	key := usageKey{client: client(c), method: c.Request.Method, path: path}
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.entries[key]
+	if !ok {
+		if len(l.entries) >= maxUsageEntries {
+			l.prune()
+		}
+		l.entries[key] = &usageEntry{loggedAt: now}
+		log.Printf("deprecated route %s %s is used by %s(%s)", key.method, key.path, key.client, c.Request.UserAgent())
+		return
+	}
+
+	entry.count++
+	if now.Sub(entry.loggedAt) < l.interval {
+		return
+	}
+	log.Printf("deprecated route %s %s is used by %s(%s) %d times since %s",
+		key.method, key.path, key.client, c.Request.UserAgent(), entry.count, entry.loggedAt.Format(time.RFC3339))
+	entry.loggedAt = now
+	entry.count = 0
+}
+
+// prune - drops the entries without unlogged requests, their clients are logged again at the next request
+func (l *usageLog) prune() {
+	for key, entry := range l.entries {
+		if entry.count == 0 {
+			delete(l.entries, key)
+		}
+	}
+}
+
+// client - the authenticated user or the address of an anonymous one
+func client(c *gin.Context) string {
+	if user, ok := auth.User(c); ok {
+		return "user " + user.String()
+	}
+	return "address " + c.ClientIP()
+}
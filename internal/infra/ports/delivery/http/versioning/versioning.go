@@ -0,0 +1,168 @@
+package versioning
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+
+This package serves the generations of the HTTP API.
+
+Every version declares its routes like a gin.RouterGroup, a version serves only the routes it declares,
+so a new version never publishes the routes of an older one by accident. The handlers shared by
+the versions are written once and registered in every version serving them:
+
+	api := versioning.New(policies, time.Hour)
+	v1 := api.Version("v1")
+	v1.GET("/organizer", json.GetAllOrganizersHandler(svc, json.LogoLinks))
+	v2 := api.Version("v2")
+	v2.GET("/organizer", json.GetAllOrganizersHandler(svc, json2.InlineLogos(images)))
+	api.Register(r)
+
+The policies of the versions come from the configuration, a deprecated version responds with
+Deprecation, Sunset and Link headers and its usage is logged per client, a disabled version
+responds to everything with 410.
+
+*/
+
+// Policy - how a version is served, the zero value is an enabled and not deprecated version
+type Policy struct {
+	Disabled bool
+	// Deprecated - the moment the version is deprecated since, zero if it's not deprecated
+	Deprecated time.Time
+	// Sunset - the moment the version will be turned off, zero if it's not planned
+	Sunset time.Time
+	// Successor - path of the version replacing this one
+	Successor string
+}
+
+// API - the versions served under /api/<name>
+type API struct {
+	versions []*Version
+	policies map[string]Policy
+	usage    *usageLog
+}
+
+// New - policies are given by the names of the versions, usage of the deprecated routes by a client
+// is logged at the first request and then at most once per usageLogInterval
+func New(policies map[string]Policy, usageLogInterval time.Duration) *API {
+	return &API{policies: policies, usage: newUsageLog(usageLogInterval)}
+}
+
+// Version - declares a version
+func (a *API) Version(name string) *Version {
+	v := &Version{name: name, prefix: "/api/" + name}
+	v.RouterGroup = &RouterGroup{version: v}
+	a.versions = append(a.versions, v)
+	return v
+}
+
+// Register - registers the routes of all versions
+func (a *API) Register(r gin.IRoutes) {
+	for _, v := range a.versions {
+		policy := a.policies[v.name]
+		if policy.Disabled {
+			r.Any(v.prefix+"/*path", disabledHandler(v, policy))
+			continue
+		}
+
+		for _, route := range v.own {
+			handlers := route.handlers
+			if !policy.Deprecated.IsZero() {
+				handlers = append([]gin.HandlerFunc{a.deprecationHandler(v.prefix+route.path, policy)}, handlers...)
+			}
+			r.Handle(route.method, v.prefix+route.path, handlers...)
+		}
+	}
+}
+
+// Version - a generation of the API, its routes are declared with the methods of RouterGroup
+type Version struct {
+	*RouterGroup
+	name   string
+	prefix string
+	own    []route
+}
+
+type route struct {
+	method   string
+	path     string
+	handlers []gin.HandlerFunc
+}
+
+// RouterGroup - declares routes of a version like gin.RouterGroup, the middlewares are
+// added to the routes declared after them
+type RouterGroup struct {
+	version  *Version
+	path     string
+	handlers []gin.HandlerFunc
+}
+
+func (g *RouterGroup) Use(middleware ...gin.HandlerFunc) {
+	g.handlers = append(g.handlers, middleware...)
+}
+
+func (g *RouterGroup) Group(path string, handlers ...gin.HandlerFunc) *RouterGroup {
+	return &RouterGroup{version: g.version, path: g.path + path, handlers: g.combine(handlers)}
+}
+
+func (g *RouterGroup) Handle(method, path string, handlers ...gin.HandlerFunc) {
+	g.version.own = append(g.version.own, route{method: method, path: g.path + path, handlers: g.combine(handlers)})
+}
+
+func (g *RouterGroup) GET(path string, handlers ...gin.HandlerFunc) {
+	g.Handle(http.MethodGet, path, handlers...)
+}
+
+func (g *RouterGroup) POST(path string, handlers ...gin.HandlerFunc) {
+	g.Handle(http.MethodPost, path, handlers...)
+}
+
+func (g *RouterGroup) PUT(path string, handlers ...gin.HandlerFunc) {
+	g.Handle(http.MethodPut, path, handlers...)
+}
+
+func (g *RouterGroup) PATCH(path string, handlers ...gin.HandlerFunc) {
+	g.Handle(http.MethodPatch, path, handlers...)
+}
+
+func (g *RouterGroup) DELETE(path string, handlers ...gin.HandlerFunc) {
+	g.Handle(http.MethodDelete, path, handlers...)
+}
+
+func (g *RouterGroup) combine(handlers []gin.HandlerFunc) []gin.HandlerFunc {
+	return append(append([]gin.HandlerFunc{}, g.handlers...), handlers...)
+}
+
+// deprecationHandler - sets the headers of RFC 9745(Deprecation) and RFC 8594(Sunset),
+// the usage is recorded after the request, so the authenticated user is known
+func (a *API) deprecationHandler(path string, policy Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		setPolicyHeaders(c, policy)
+		c.Next()
+		a.usage.record(c, path)
+	}
+}
+
+func disabledHandler(v *Version, policy Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		setPolicyHeaders(c, policy)
+		c.JSON(http.StatusGone, gin.H{"msg": "API " + v.name + " is turned off"})
+	}
+}
+
+func setPolicyHeaders(c *gin.Context, policy Policy) {
+	if !policy.Deprecated.IsZero() {
+		c.Header("Deprecation", "@"+strconv.FormatInt(policy.Deprecated.Unix(), 10))
+	}
+	if !policy.Sunset.IsZero() {
+		c.Header("Sunset", policy.Sunset.UTC().Format(http.TimeFormat))
+	}
+	if policy.Successor != "" {
+		c.Header("Link", "<"+policy.Successor+`>; rel="successor-version"`)
+	}
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+type analyticsService struct {
+	storage  adapters.AnalyticsStorage
+	currency services.CurrencyService
+}
+
+func (svc analyticsService) Aggregate(ctx context.Context, dimension string, filter models.AnalyticsFilter) ([]models.AnalyticsGroup, error) {
+	if err := services.ValidateAnalyticsQuery(dimension, filter); err != nil {
+		return nil, err
+	}
+
+	groups, err := svc.storage.Aggregate(ctx, dimension, filter, svc.currency.Factors())
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("internal error")
+	}
+	for i := range groups {
+		groups[i].Currency = svc.currency.Reference()
+	}
+	return groups, nil
+}
+
+func NewAnalyticsService(storage adapters.AnalyticsStorage, currency services.CurrencyService) services.AnalyticsService {
+	return &analyticsService{
+		storage:  storage,
+		currency: currency,
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+)
+
+type applicationService struct {
+	storage adapters.ApplicationStorage
+
+	events   services.EventService
+	profiles services.ProfileService
+	images   services.ImageService
+}
+
+func (svc applicationService) GetByID(ctx context.Context, id uuid.UUID) (models.Application, error) {
+	return svc.storage.GetByID(ctx, id)
+}
+
+func (svc applicationService) GetAllForEvent(ctx context.Context, eventId uuid.UUID) ([]models.Application, error) {
+	applications, err := svc.storage.GetByEvent(ctx, eventId)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("internal error")
+	}
+	return applications, nil
+}
+
+func (svc applicationService) GetAllForTeam(ctx context.Context, teamId uuid.UUID) ([]models.Application, error) {
+	applications, err := svc.storage.GetByTeam(ctx, teamId)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("internal error")
+	}
+	return applications, nil
+}
+
+func (svc applicationService) Create(ctx context.Context, eventId, teamId uuid.UUID) (models.Application, error) {
+	violations := make(validators.Violations, 0)
+	if _, err := svc.events.GetByID(ctx, eventId); err != nil {
+		violations = append(violations, validators.Violation{Field: "event", Message: "does not exist"})
+	}
+	if _, err := svc.profiles.GetByID(ctx, teamId); err != nil {
+		violations = append(violations, validators.Violation{Field: "team", Message: "does not exist"})
+	}
+	if len(violations) != 0 {
+		return models.Application{}, violations
+	}
+
+	existing, err := svc.GetAllForTeam(ctx, teamId)
+	if err != nil {
+		return models.Application{}, err
+	}
+	for _, v := range existing {
+		if v.Event == eventId {
+			return models.Application{}, services.ErrApplicationExists
+		}
+	}
+
+	now := time.Now().UTC()
+	application := models.Application{
+		ID:        uuid.New(),
+		Event:     eventId,
+		Team:      teamId,
+		Stage:     models.ApplicationStageIdea,
+		CreatedAt: now,
+		History:   []models.StageChange{{Stage: models.ApplicationStageIdea, ChangedAt: now}},
+		Notes:     make([]models.ApplicationNote, 0),
+		Files:     make([]models.ApplicationFile, 0),
+	}
+
+	if err := svc.storage.Add(ctx, application); err != nil {
+		log.Println(err)
+		return models.Application{}, errors.New("failed to add")
+	}
+	return application, nil
+}
+
+func (svc applicationService) ChangeStage(ctx context.Context, id uuid.UUID, stage string) (models.Application, error) {
+	if err := services.ValidateApplicationStage(stage); err != nil {
+		return models.Application{}, err
+	}
+
+	application, err := svc.storage.GetByID(ctx, id)
+	if err != nil {
+		return models.Application{}, err
+	}
+
+	if application.Stage == stage {
+		return application, nil
+	}
+
+	change := models.StageChange{Stage: stage, ChangedAt: time.Now().UTC()}
+	if err := svc.storage.ChangeStage(ctx, id, change); err != nil {
+		log.Println(err)
+		return models.Application{}, errors.New("failed to change stage")
+	}
+
+	application.Stage = stage
+	application.History = append(application.History, change)
+	return application, nil
+}
+
+func (svc applicationService) AddNote(ctx context.Context, id uuid.UUID, text string) (models.ApplicationNote, error) {
+	if err := services.ValidateApplicationNote(text); err != nil {
+		return models.ApplicationNote{}, err
+	}
+
+	if _, err := svc.storage.GetByID(ctx, id); err != nil {
+		return models.ApplicationNote{}, err
+	}
+
+	note := models.ApplicationNote{ID: uuid.New(), Text: text, CreatedAt: time.Now().UTC()}
+	if err := svc.storage.AddNote(ctx, id, note); err != nil {
+		log.Println(err)
+		return models.ApplicationNote{}, errors.New("failed to add note")
+	}
+	return note, nil
+}
+
+func (svc applicationService) AttachFile(ctx context.Context, id uuid.UUID, name, link string) (models.ApplicationFile, error) {
+	if err := services.ValidateApplicationFile(name, link); err != nil {
+		return models.ApplicationFile{}, err
+	}
+
+	if _, err := svc.storage.GetByID(ctx, id); err != nil {
+		return models.ApplicationFile{}, err
+	}
+
+	if _, err := svc.images.Get(ctx, link); err != nil {
+		return models.ApplicationFile{}, validators.Violations{{Field: "link", Message: "does not exist"}}
+	}
+
+	file := models.ApplicationFile{Link: link, Name: name, AttachedAt: time.Now().UTC()}
+	if err := svc.storage.AddFile(ctx, id, file); err != nil {
+		log.Println(err)
+		return models.ApplicationFile{}, errors.New("failed to attach file")
+	}
+	return file, nil
+}
+
+func (svc applicationService) Delete(ctx context.Context, id uuid.UUID) error {
+	return svc.storage.Delete(ctx, id)
+}
+
+func NewApplicationService(storage adapters.ApplicationStorage,
+	events services.EventService,
+	profiles services.ProfileService,
+	images services.ImageService) services.ApplicationService {
+	return &applicationService{
+		storage:  storage,
+		events:   events,
+		profiles: profiles,
+		images:   images,
+	}
+}
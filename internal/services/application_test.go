@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+)
+
+// fakeApplications - keeps the applications in memory like the postgres storage
+type fakeApplications struct {
+	adapters.ApplicationStorage
+	applications map[uuid.UUID]models.Application
+}
+
+func (s *fakeApplications) GetByID(_ context.Context, id uuid.UUID) (models.Application, error) {
+	a, ok := s.applications[id]
+	if !ok {
+		return models.Application{}, adapters.ErrNotFound
+	}
+	return a, nil
+}
+
+func (s *fakeApplications) GetByTeam(_ context.Context, teamId uuid.UUID) ([]models.Application, error) {
+	result := make([]models.Application, 0)
+	for _, a := range s.applications {
+		if a.Team == teamId {
+			result = append(result, a)
+		}
+	}
+	return result, nil
+}
+
+func (s *fakeApplications) Add(_ context.Context, application models.Application) error {
+	s.applications[application.ID] = application
+	return nil
+}
+
+func (s *fakeApplications) ChangeStage(_ context.Context, id uuid.UUID, change models.StageChange) error {
+	a := s.applications[id]
+	a.Stage = change.Stage
+	a.History = append(a.History, change)
+	s.applications[id] = a
+	return nil
+}
+
+func (s *fakeApplications) AddNote(_ context.Context, id uuid.UUID, note models.ApplicationNote) error {
+	a := s.applications[id]
+	a.Notes = append(a.Notes, note)
+	s.applications[id] = a
+	return nil
+}
+
+func (s *fakeApplications) AddFile(_ context.Context, id uuid.UUID, file models.ApplicationFile) error {
+	a := s.applications[id]
+	a.Files = append(a.Files, file)
+	s.applications[id] = a
+	return nil
+}
+
+type fakeProfiles struct {
+	services.ProfileService
+	profile models.Profile
+}
+
+func (f fakeProfiles) GetByID(_ context.Context, id uuid.UUID) (models.Profile, error) {
+	if id != f.profile.ID {
+		return models.Profile{}, adapters.ErrNotFound
+	}
+	return f.profile, nil
+}
+
+type fakeImages struct {
+	services.ImageService
+	links []string
+}
+
+func (f fakeImages) Get(_ context.Context, link string) (models.StoredImage, error) {
+	if !validators.StringExists(f.links, link) {
+		return models.StoredImage{}, adapters.ErrNotFound
+	}
+	return models.StoredImage{}, nil
+}
+
+func TestApplicationFlow(t *testing.T) {
+	ctx := context.Background()
+	event := models.Event{ID: uuid.New()}
+	team := models.Profile{ID: uuid.New()}
+	storage := &fakeApplications{applications: make(map[uuid.UUID]models.Application)}
+	svc := NewApplicationService(storage, fakeEvents{events: []models.Event{event}}, fakeProfiles{profile: team}, fakeImages{links: []string{"budget"}})
+
+	var violations validators.Violations
+	if _, err := svc.Create(ctx, uuid.New(), uuid.New()); !errors.As(err, &violations) || len(violations) != 2 {
+		t.Errorf("Create with unknown event and team = %v, want violations of both", err)
+	}
+
+	application, err := svc.Create(ctx, event.ID, team.ID)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if application.Stage != models.ApplicationStageIdea || len(application.History) != 1 {
+		t.Errorf("new application is in %s with history %v, want the idea stage", application.Stage, application.History)
+	}
+
+	if _, err := svc.Create(ctx, event.ID, team.ID); !errors.Is(err, services.ErrApplicationExists) {
+		t.Errorf("second Create = %v, want %v", err, services.ErrApplicationExists)
+	}
+
+	// the stages may be changed in any order, a change to the current stage is not recorded
+	for _, stage := range []string{models.ApplicationStagePreparing, models.ApplicationStageSubmitted, models.ApplicationStageSubmitted, models.ApplicationStageRejected} {
+		if application, err = svc.ChangeStage(ctx, application.ID, stage); err != nil {
+			t.Fatalf("ChangeStage(%s): %v", stage, err)
+		}
+	}
+	if _, err := svc.ChangeStage(ctx, application.ID, "lost"); !errors.As(err, &violations) {
+		t.Errorf("ChangeStage to an unknown stage = %v, want violations", err)
+	}
+	if _, err := svc.ChangeStage(ctx, uuid.New(), models.ApplicationStageWon); !errors.Is(err, adapters.ErrNotFound) {
+		t.Errorf("ChangeStage of an unknown application = %v, want %v", err, adapters.ErrNotFound)
+	}
+
+	stored, _ := storage.GetByID(ctx, application.ID)
+	history := make([]string, len(stored.History))
+	for i, v := range stored.History {
+		history[i] = v.Stage
+	}
+	want := []string{models.ApplicationStageIdea, models.ApplicationStagePreparing, models.ApplicationStageSubmitted, models.ApplicationStageRejected}
+	if stored.Stage != models.ApplicationStageRejected || !reflect.DeepEqual(history, want) {
+		t.Errorf("stored application is in %s with history %v, want %s with %v", stored.Stage, history, models.ApplicationStageRejected, want)
+	}
+
+	if _, err := svc.AddNote(ctx, application.ID, "the budget is too small"); err != nil {
+		t.Errorf("AddNote: %v", err)
+	}
+	if _, err := svc.AddNote(ctx, application.ID, ""); !errors.As(err, &violations) {
+		t.Errorf("AddNote of an empty note = %v, want violations", err)
+	}
+
+	if _, err := svc.AttachFile(ctx, application.ID, "budget.xlsx", "budget"); err != nil {
+		t.Errorf("AttachFile: %v", err)
+	}
+	if _, err := svc.AttachFile(ctx, application.ID, "plan.docx", "plan"); !errors.As(err, &violations) || violations[0].Field != "link" {
+		t.Errorf("AttachFile of an unknown upload = %v, want a violation of link", err)
+	}
+
+	stored, _ = storage.GetByID(ctx, application.ID)
+	if len(stored.Notes) != 1 || len(stored.Files) != 1 {
+		t.Errorf("stored application has %d notes and %d files, want 1 and 1", len(stored.Notes), len(stored.Files))
+	}
+}
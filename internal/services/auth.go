@@ -2,20 +2,47 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/indigowar/map-of-events/internal/config"
 	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
 	"github.com/indigowar/map-of-events/internal/domain/services"
 )
 
+// sessionTokenBytes - length of the random part of a session token
+const sessionTokenBytes = 32
+
 type authService struct {
-	userStorage adapters.UserStorage
-	config      config.AuthConfig
+	userStorage    adapters.UserStorage
+	sessionStorage adapters.SessionStorage
+	config         config.AuthConfig
+
+	admins map[uuid.UUID]bool
 }
 
 func (svc authService) Login(ctx context.Context, name, password string) (string, error) {
-	//TODO implement me
-	panic("implement me")
+	user, err := svc.userStorage.GetByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, adapters.ErrNotFound) {
+			return "", services.AuthErrFailedToLogin
+		}
+		log.Println(err)
+		return "", services.AuthErrInternalError
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return "", services.AuthErrFailedToLogin
+	}
+
+	return svc.createSession(ctx, user.ID)
 }
 
 func (svc authService) GetRefresh(ctx context.Context, rt string) (string, error) {
@@ -29,13 +56,97 @@ func (svc authService) GetAccess(ctx context.Context, rt string) (string, error)
 }
 
 func (svc authService) CreateUser(ctx context.Context, name, password string) (string, error) {
-	//TODO implement me
-	panic("implement me")
+	if err := services.ValidateCredentials(name, password); err != nil {
+		return "", err
+	}
+
+	if _, err := svc.userStorage.GetByName(ctx, name); err == nil {
+		return "", services.AuthErrUserExists
+	} else if !errors.Is(err, adapters.ErrNotFound) {
+		log.Println(err)
+		return "", services.AuthErrInternalError
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Println(err)
+		return "", services.AuthErrInternalError
+	}
+
+	user := models.User{
+		ID:       uuid.New(),
+		Name:     name,
+		Password: string(hash),
+	}
+	if err := svc.userStorage.Create(ctx, user); err != nil {
+		if errors.Is(err, adapters.ErrAlreadyExists) {
+			return "", services.AuthErrUserExists
+		}
+		log.Println(err)
+		return "", services.AuthErrInternalError
+	}
+
+	return svc.createSession(ctx, user.ID)
+}
+
+func (svc authService) Authenticate(ctx context.Context, token string) (uuid.UUID, error) {
+	session, err := svc.sessionStorage.GetByToken(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, adapters.ErrNotFound) {
+			return uuid.Nil, services.AuthErrTokenIsInvalid
+		}
+		log.Println(err)
+		return uuid.Nil, services.AuthErrInternalError
+	}
+	return session.User, nil
 }
 
-func NewAuthService(userStorage adapters.UserStorage, cfg config.AuthConfig) services.AuthService {
+// createSession - starts a new session of the user with a random token, the previous session ends,
+// only the hash of the token is stored, so the sessions can't be taken over by reading the storage
+func (svc authService) createSession(ctx context.Context, user uuid.UUID) (string, error) {
+	random := make([]byte, sessionTokenBytes)
+	if _, err := rand.Read(random); err != nil {
+		log.Println(err)
+		return "", services.AuthErrInternalError
+	}
+	token := hex.EncodeToString(random)
+
+	session := models.TokenSession{
+		Token: hashToken(token),
+		User:  user,
+	}
+	if err := svc.sessionStorage.Create(ctx, session); err != nil {
+		log.Println(err)
+		return "", services.AuthErrInternalError
+	}
+	return token, nil
+}
+
+// hashToken - the token is random enough, so a hash without a salt can't be reversed
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func (svc authService) IsAdmin(user uuid.UUID) bool {
+	return svc.admins[user]
+}
+
+func NewAuthService(userStorage adapters.UserStorage, sessionStorage adapters.SessionStorage, cfg config.AuthConfig) services.AuthService {
+	admins := make(map[uuid.UUID]bool, len(cfg.Admins))
+	for _, v := range cfg.Admins {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			log.Println("invalid id of admin in the config: ", v)
+			continue
+		}
+		admins[id] = true
+	}
+
 	return &authService{
-		userStorage: userStorage,
-		config:      cfg,
+		userStorage:    userStorage,
+		sessionStorage: sessionStorage,
+		config:         cfg,
+		admins:         admins,
 	}
 }
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/config"
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+// fakeUsers - keeps the users by their names like the unique column of postgres,
+// a sign-up racing with another one is simulated by hiding the users from GetByName
+type fakeUsers struct {
+	adapters.UserStorage
+
+	mu     sync.Mutex
+	users  map[string]models.User
+	racing bool
+}
+
+func (s *fakeUsers) GetByName(_ context.Context, name string) (models.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[name]
+	if !ok || s.racing {
+		return models.User{}, adapters.ErrNotFound
+	}
+	return user, nil
+}
+
+func (s *fakeUsers) Create(_ context.Context, user models.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[user.Name]; ok {
+		return adapters.ErrAlreadyExists
+	}
+	s.users[user.Name] = user
+	return nil
+}
+
+type fakeSessions struct {
+	adapters.SessionStorage
+
+	mu       sync.Mutex
+	sessions map[string]uuid.UUID
+}
+
+func (s *fakeSessions) GetByToken(_ context.Context, token string) (models.TokenSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.sessions[token]
+	if !ok {
+		return models.TokenSession{}, adapters.ErrNotFound
+	}
+	return models.TokenSession{Token: token, User: user}, nil
+}
+
+func (s *fakeSessions) Create(_ context.Context, session models.TokenSession) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for token, user := range s.sessions {
+		if user == session.User {
+			delete(s.sessions, token)
+		}
+	}
+	s.sessions[session.Token] = session.User
+	return nil
+}
+
+func TestCreateUserWithTakenName(t *testing.T) {
+	users := &fakeUsers{users: make(map[string]models.User)}
+	svc := NewAuthService(users, &fakeSessions{sessions: make(map[string]uuid.UUID)}, config.AuthConfig{})
+
+	if _, err := svc.CreateUser(context.Background(), "alice", "password"); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	if _, err := svc.CreateUser(context.Background(), "alice", "password"); !errors.Is(err, services.AuthErrUserExists) {
+		t.Errorf("CreateUser with a taken name = %v, want %v", err, services.AuthErrUserExists)
+	}
+
+	// the name is taken between the check and the insert
+	users.racing = true
+	if _, err := svc.CreateUser(context.Background(), "alice", "password"); !errors.Is(err, services.AuthErrUserExists) {
+		t.Errorf("CreateUser racing with another sign-up = %v, want %v", err, services.AuthErrUserExists)
+	}
+}
+
+func TestSessionTokenIsStoredHashed(t *testing.T) {
+	sessions := &fakeSessions{sessions: make(map[string]uuid.UUID)}
+	svc := NewAuthService(&fakeUsers{users: make(map[string]models.User)}, sessions, config.AuthConfig{})
+
+	token, err := svc.CreateUser(context.Background(), "alice", "password")
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	if _, ok := sessions.sessions[token]; ok || len(sessions.sessions) != 1 {
+		t.Errorf("sessions = %v, want only the hash of %s", sessions.sessions, token)
+	}
+
+	user, err := svc.Authenticate(context.Background(), token)
+	if err != nil || user == uuid.Nil {
+		t.Errorf("Authenticate(token) = %v, %v, want the user", user, err)
+	}
+
+	// the stored hash is not a token
+	for hash := range sessions.sessions {
+		if _, err := svc.Authenticate(context.Background(), hash); !errors.Is(err, services.AuthErrTokenIsInvalid) {
+			t.Errorf("Authenticate(hash) = %v, want %v", err, services.AuthErrTokenIsInvalid)
+		}
+	}
+}
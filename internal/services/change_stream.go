@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/config"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+)
+
+// subscriberBuffer - the number of changes a subscriber may lag behind, after that its subscription ends
+const subscriberBuffer = 64
+
+// streamEntry - a change in the replay buffer with parents of the subjects it's matched with
+type streamEntry struct {
+	change  services.StreamedChange
+	parents map[uuid.UUID]uuid.UUID
+}
+
+func (e streamEntry) matches(f models.EventFilter) bool {
+	switch e.change.Object.(type) {
+	case models.Organizer:
+		return len(f.Organizers) == 0 || validators.IDExists(f.Organizers, e.change.ObjectID)
+	case models.Event:
+		if e.change.Type == models.ChangeEventDeleted {
+			f.Subjects = nil
+			f.MinFunding = 0
+			f.MaxFunding = 0
+		}
+		return services.MatchEvent(f, e.change.Facts, e.parents)
+	}
+	return false
+}
+
+type streamSubscriber struct {
+	filter  models.EventFilter
+	changes chan services.StreamedChange
+}
+
+type changeStreamService struct {
+	subjects services.SubjectService
+	currency services.CurrencyService
+	config   config.StreamConfig
+
+	mu          sync.Mutex
+	seq         uint64
+	buffer      []streamEntry
+	subscribers map[*streamSubscriber]struct{}
+}
+
+func (svc *changeStreamService) Changed(ctx context.Context, change models.Change) {
+	entry := streamEntry{change: services.StreamedChange{Change: change}}
+	if event, ok := change.Object.(models.Event); ok {
+		entry.change.Facts.Event = event
+		// the related objects of a deleted event are deleted with it
+		if change.Type != models.ChangeEventDeleted {
+			var err error
+			if entry.change.Facts, entry.parents, err = svc.eventFacts(ctx, event); err != nil {
+				// the change is streamed anyway, it may be filtered out by mistake, but it's not lost for the others
+				log.Println("failed to load related objects of event ", event.ID.String(), ": ", err)
+			}
+		}
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	svc.seq++
+	entry.change.Seq = svc.seq
+
+	svc.buffer = append(svc.buffer, entry)
+	if len(svc.buffer) > svc.config.BufferSize {
+		svc.buffer = svc.buffer[len(svc.buffer)-svc.config.BufferSize:]
+	}
+
+	for s := range svc.subscribers {
+		if !entry.matches(s.filter) {
+			continue
+		}
+		select {
+		case s.changes <- entry.change:
+		default:
+			// the subscriber resumes from the buffer after it reconnects
+			svc.unsubscribe(s)
+		}
+	}
+}
+
+func (svc *changeStreamService) eventFacts(ctx context.Context, event models.Event) (services.EventFacts, map[uuid.UUID]uuid.UUID, error) {
+	facts := services.EventFacts{Event: event, ReferenceFunding: services.ReferenceRange(svc.currency, event.FoundingRange)}
+
+	var err error
+	if facts.Subjects, err = svc.subjects.GetAllForEvent(ctx, event.ID); err != nil {
+		return facts, nil, err
+	}
+
+	parents, err := subjectParents(ctx, svc.subjects)
+	return facts, parents, err
+}
+
+func (svc *changeStreamService) Subscribe(_ context.Context, filter models.EventFilter, lastSeq uint64) services.ChangeSubscription {
+	s := &streamSubscriber{filter: filter, changes: make(chan services.StreamedChange, subscriberBuffer)}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	subscription := services.ChangeSubscription{Changes: s.changes, Last: svc.seq}
+	if lastSeq != 0 {
+		// the changes after lastSeq are in the buffer, if the next one is its first one or later
+		first := svc.seq + 1
+		if len(svc.buffer) != 0 {
+			first = svc.buffer[0].change.Seq
+		}
+		subscription.Missed = lastSeq+1 < first || lastSeq > svc.seq
+
+		for _, e := range svc.buffer {
+			if e.change.Seq > lastSeq && e.matches(filter) {
+				subscription.Replay = append(subscription.Replay, e.change)
+			}
+		}
+	}
+
+	svc.subscribers[s] = struct{}{}
+
+	timer := time.AfterFunc(svc.config.MaxDuration, func() {
+		svc.cancel(s)
+	})
+	subscription.Cancel = func() {
+		timer.Stop()
+		svc.cancel(s)
+	}
+	return subscription
+}
+
+func (svc *changeStreamService) cancel(s *streamSubscriber) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	svc.unsubscribe(s)
+}
+
+// unsubscribe - should be called under the lock
+func (svc *changeStreamService) unsubscribe(s *streamSubscriber) {
+	if _, ok := svc.subscribers[s]; ok {
+		delete(svc.subscribers, s)
+		close(s.changes)
+	}
+}
+
+func NewChangeStreamService(subjects services.SubjectService,
+	currency services.CurrencyService,
+	cfg config.StreamConfig) services.ChangeStreamService {
+	return &changeStreamService{
+		subjects: subjects,
+		currency: currency,
+		config:   cfg,
+		// the positions continue after a restart, so a position seen before it is not mistaken for a buffered one
+		seq:         uint64(time.Now().UnixNano()),
+		subscribers: make(map[*streamSubscriber]struct{}),
+	}
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/config"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+// organizerChange - the changes of organizers are streamed without loading the related objects
+func organizerChange(organizer models.Organizer) models.Change {
+	return models.Change{ID: uuid.New(), Type: models.ChangeOrganizerUpdated, ObjectID: organizer.ID, Object: organizer}
+}
+
+func TestSubscribeReplay(t *testing.T) {
+	ctx := context.Background()
+	svc := NewChangeStreamService(nil, nil, config.StreamConfig{BufferSize: 3, MaxDuration: time.Minute})
+
+	// the stream starts at an arbitrary position, the positions are counted from it
+	start := svc.Subscribe(ctx, models.EventFilter{}, 0)
+	start.Cancel()
+	base := start.Last
+
+	empty := svc.Subscribe(ctx, models.EventFilter{}, base)
+	empty.Cancel()
+	if empty.Missed || len(empty.Replay) != 0 {
+		t.Errorf("empty stream: missed = %v, replay = %v, want nothing", empty.Missed, empty.Replay)
+	}
+
+	first := models.Organizer{ID: uuid.New()}
+	second := models.Organizer{ID: uuid.New()}
+	// base+1 - base+5, the buffer keeps base+3 - base+5
+	for _, o := range []models.Organizer{first, second, first, second, first} {
+		svc.Changed(ctx, organizerChange(o))
+	}
+
+	tests := []struct {
+		name    string
+		filter  models.EventFilter
+		lastSeq uint64
+		replay  []uint64
+		missed  bool
+	}{
+		{name: "new subscriber", lastSeq: 0},
+		{name: "up to date", lastSeq: base + 5},
+		{name: "within the buffer", lastSeq: base + 3, replay: []uint64{base + 4, base + 5}},
+		{name: "right before the buffer", lastSeq: base + 2, replay: []uint64{base + 3, base + 4, base + 5}},
+		{name: "out of the buffer", lastSeq: base + 1, replay: []uint64{base + 3, base + 4, base + 5}, missed: true},
+		{name: "position of another run", lastSeq: base + 100, missed: true},
+		{name: "filtered", filter: models.EventFilter{Organizers: []uuid.UUID{second.ID}}, lastSeq: base + 2, replay: []uint64{base + 4}},
+	}
+
+	for _, tt := range tests {
+		subscription := svc.Subscribe(ctx, tt.filter, tt.lastSeq)
+		subscription.Cancel()
+
+		replay := make([]uint64, 0)
+		for _, c := range subscription.Replay {
+			replay = append(replay, c.Seq)
+		}
+		if tt.replay == nil {
+			tt.replay = make([]uint64, 0)
+		}
+
+		if subscription.Last != base+5 {
+			t.Errorf("%s: last = base+%d, want base+5", tt.name, subscription.Last-base)
+		}
+		if subscription.Missed != tt.missed || !reflect.DeepEqual(replay, tt.replay) {
+			t.Errorf("%s: missed = %v, replay = %v, want %v, %v", tt.name, subscription.Missed, replay, tt.missed, tt.replay)
+		}
+	}
+}
+
+func TestSubscribeLiveChanges(t *testing.T) {
+	ctx := context.Background()
+	svc := NewChangeStreamService(nil, nil, config.StreamConfig{BufferSize: 10, MaxDuration: time.Minute})
+
+	watched := models.Organizer{ID: uuid.New()}
+	subscription := svc.Subscribe(ctx, models.EventFilter{Organizers: []uuid.UUID{watched.ID}}, 0)
+
+	svc.Changed(ctx, organizerChange(models.Organizer{ID: uuid.New()}))
+	svc.Changed(ctx, organizerChange(watched))
+
+	select {
+	case c := <-subscription.Changes:
+		if c.ObjectID != watched.ID || c.Seq != subscription.Last+2 {
+			t.Errorf("change = %d of %s, want %d of the watched organizer", c.Seq-subscription.Last, c.ObjectID, 2)
+		}
+	default:
+		t.Fatalf("the change of the watched organizer is not sent")
+	}
+
+	subscription.Cancel()
+	if _, ok := <-subscription.Changes; ok {
+		t.Errorf("the changes are not closed after Cancel")
+	}
+}
+
+// a subscriber lagging behind more than its buffer is unsubscribed, it resumes from the replay buffer
+func TestSlowSubscriberIsUnsubscribed(t *testing.T) {
+	ctx := context.Background()
+	svc := NewChangeStreamService(nil, nil, config.StreamConfig{BufferSize: 2 * subscriberBuffer, MaxDuration: time.Minute})
+
+	subscription := svc.Subscribe(ctx, models.EventFilter{}, 0)
+	defer subscription.Cancel()
+
+	organizer := models.Organizer{ID: uuid.New()}
+	for i := 0; i <= subscriberBuffer; i++ {
+		svc.Changed(ctx, organizerChange(organizer))
+	}
+
+	var last services.StreamedChange
+	received := 0
+	for c := range subscription.Changes {
+		last = c
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Errorf("received %d changes, want %d", received, subscriberBuffer)
+	}
+
+	resumed := svc.Subscribe(ctx, models.EventFilter{}, last.Seq)
+	resumed.Cancel()
+	if resumed.Missed || len(resumed.Replay) != 1 {
+		t.Errorf("resumed: missed = %v, replay of %d changes, want the one not received", resumed.Missed, len(resumed.Replay))
+	}
+}
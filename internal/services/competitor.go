@@ -43,11 +43,21 @@ func (svc competitorService) GetAll(ctx context.Context) ([]models.Competitor, e
 	return competitors, nil
 }
 
-func (svc competitorService) Create(ctx context.Context, name string) (models.Competitor, errors.Error) {
-	c := models.Competitor{
-		ID:   uuid.New(),
-		Name: name,
+func (svc competitorService) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Competitor, errors.Error) {
+	competitors, err := svc.storage.GetByIDs(ctx, ids)
+	if err != nil {
+		log.Println(err)
+		return nil, failedToReadDatabaseErr(err, "get competitors by ids")
 	}
+	return competitors, nil
+}
+
+func (svc competitorService) Create(ctx context.Context, info services.CompetitorInfo) (models.Competitor, errors.Error) {
+	if err := info.Validate(); err != nil {
+		return models.Competitor{}, errors.CreateError(services.ErrReasonValidationFailed, err.Error(), err.Error())
+	}
+
+	c := buildCompetitor(uuid.New(), info)
 
 	if err := svc.storage.Create(ctx, c); err != nil {
 		log.Println(err)
@@ -56,6 +66,47 @@ func (svc competitorService) Create(ctx context.Context, name string) (models.Co
 	return c, nil
 }
 
+func (svc competitorService) Update(ctx context.Context, id uuid.UUID, info services.CompetitorInfo) (models.Competitor, errors.Error) {
+	if err := info.Validate(); err != nil {
+		return models.Competitor{}, errors.CreateError(services.ErrReasonValidationFailed, err.Error(), err.Error())
+	}
+
+	if _, err := svc.storage.Get(ctx, id); err != nil {
+		log.Println(err.LongErr())
+		return models.Competitor{}, errors.CreateError(services.ErrReasonNotFound, "competitor was not found", err.LongErr())
+	}
+
+	c := buildCompetitor(id, info)
+
+	if err := svc.storage.Update(ctx, c); err != nil {
+		log.Println(err.LongErr())
+		return models.Competitor{}, failedToReadDatabaseErr(err, "update a competitor")
+	}
+	return c, nil
+}
+
+func buildCompetitor(id uuid.UUID, info services.CompetitorInfo) models.Competitor {
+	regions := info.Regions
+	if regions == nil {
+		regions = make([]string, 0)
+	}
+	organizationTypes := info.OrganizationTypes
+	if organizationTypes == nil {
+		organizationTypes = make([]string, 0)
+	}
+
+	return models.Competitor{
+		ID:                id,
+		Name:              info.Name,
+		Category:          info.Category,
+		MinAge:            info.MinAge,
+		MaxAge:            info.MaxAge,
+		MinDegree:         info.MinDegree,
+		Regions:           regions,
+		OrganizationTypes: organizationTypes,
+	}
+}
+
 func (svc competitorService) Delete(ctx context.Context, id uuid.UUID) errors.Error {
 	if err := svc.storage.Delete(ctx, id); err != nil {
 		log.Println(err)
@@ -64,6 +115,15 @@ func (svc competitorService) Delete(ctx context.Context, id uuid.UUID) errors.Er
 	return nil
 }
 
+func (svc competitorService) Suggest(ctx context.Context, text string, limit int) ([]models.Suggestion, errors.Error) {
+	result, err := svc.storage.Suggest(ctx, text, limit)
+	if err != nil {
+		log.Println(err.LongErr())
+		return nil, failedToReadDatabaseErr(err, "suggest competitors")
+	}
+	return result, nil
+}
+
 func NewCompetitorService(storage adapters.CompetitorStorage) services.CompetitorService {
 	return &competitorService{
 		storage: storage,
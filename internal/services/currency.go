@@ -0,0 +1,84 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/indigowar/map-of-events/internal/config"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+// currencyService - the rates are units of the reference currency for one unit of the currency
+type currencyService struct {
+	reference string
+	rates     map[string]float64
+}
+
+func (svc currencyService) Reference() string {
+	return svc.reference
+}
+
+// Convert - the amount is saturated at the bounds of int64, the validated amounts can exceed them
+// after the conversion by a large rate
+func (svc currencyService) Convert(m models.Money) (models.Money, bool) {
+	factor, ok := svc.factor(m.Currency)
+	if !ok {
+		return models.Money{}, false
+	}
+	return models.Money{Amount: saturate(math.Round(float64(m.Amount) * factor)), Currency: svc.reference}, true
+}
+
+// saturate - the amount as int64, the amounts out of its range are replaced by the nearest bound
+func saturate(amount float64) int64 {
+	switch {
+	// float64(math.MaxInt64) is 2^63, which doesn't fit into int64 itself
+	case amount >= float64(math.MaxInt64):
+		return math.MaxInt64
+	case amount <= float64(math.MinInt64):
+		return math.MinInt64
+	}
+	return int64(amount)
+}
+
+func (svc currencyService) Factors() map[string]float64 {
+	result := make(map[string]float64, len(svc.rates))
+	for currency := range svc.rates {
+		result[currency], _ = svc.factor(currency)
+	}
+	return result
+}
+
+// factor - the multiplier of the minor units of the currency to the minor units of the reference one
+func (svc currencyService) factor(currency string) (float64, bool) {
+	rate, ok := svc.rates[currency]
+	if !ok {
+		return 0, false
+	}
+	return rate * float64(models.MinorUnits(svc.reference)) / float64(models.MinorUnits(currency)), true
+}
+
+func NewCurrencyService(cfg config.CurrencyConfig) (services.CurrencyService, error) {
+	reference := strings.ToUpper(cfg.Reference)
+	if _, ok := models.CurrencyExponents[reference]; !ok {
+		return nil, fmt.Errorf("unknown reference currency %q", cfg.Reference)
+	}
+
+	rates := map[string]float64{reference: 1}
+	for currency, rate := range cfg.Rates {
+		// the keys are lowercased by the configuration
+		currency = strings.ToUpper(currency)
+		if _, ok := models.CurrencyExponents[currency]; !ok {
+			return nil, fmt.Errorf("unknown currency %q in the rates", currency)
+		}
+		if rate <= 0 {
+			return nil, fmt.Errorf("rate of %s should be positive", currency)
+		}
+		if currency != reference {
+			rates[currency] = rate
+		}
+	}
+
+	return &currencyService{reference: reference, rates: rates}, nil
+}
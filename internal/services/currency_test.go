@@ -0,0 +1,48 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/indigowar/map-of-events/internal/config"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+)
+
+func TestConvert(t *testing.T) {
+	svc, err := NewCurrencyService(config.CurrencyConfig{
+		Reference: "RUB",
+		Rates:     map[string]float64{"usd": 92.5, "jpy": 0.6},
+	})
+	if err != nil {
+		t.Fatalf("NewCurrencyService: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		money models.Money
+		want  int64
+	}{
+		{"reference currency", models.MoneyFromMajor(100, "RUB"), 10000},
+		{"with minor units", models.MoneyFromMajor(2, "USD"), 18500},
+		{"without minor units", models.MoneyFromMajor(1000, "JPY"), 60000},
+		{"zero", models.Money{Currency: "USD"}, 0},
+		// the largest valid amount is out of int64 after the conversion, so it's saturated instead of wrapped
+		{"largest valid amount", models.MoneyFromMajor(validators.MaxMajorAmount, "USD"), math.MaxInt64},
+	}
+
+	for _, tt := range tests {
+		got, ok := svc.Convert(tt.money)
+		if !ok {
+			t.Errorf("%s: Convert has no rate for %s", tt.name, tt.money.Currency)
+			continue
+		}
+		if got.Amount != tt.want || got.Currency != "RUB" {
+			t.Errorf("%s: Convert(%+v) = %+v, want %d RUB", tt.name, tt.money, got, tt.want)
+		}
+	}
+
+	if _, ok := svc.Convert(models.MoneyFromMajor(1, "EUR")); ok {
+		t.Errorf("Convert of a currency without a rate has succeeded")
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+type eligibilityService struct {
+	events      services.EventService
+	competitors services.CompetitorService
+}
+
+func (svc eligibilityService) Check(ctx context.Context, profile models.ApplicantProfile) ([]models.Eligibility, error) {
+	if err := services.ValidateApplicantProfile(profile); err != nil {
+		return nil, err
+	}
+
+	events, err := svc.events.GetAll(ctx)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("internal error")
+	}
+
+	competitors, e := svc.competitors.GetAll(ctx)
+	if e != nil {
+		log.Println(e.LongErr())
+		return nil, errors.New("internal error")
+	}
+
+	byID := make(map[uuid.UUID]models.Competitor, len(competitors))
+	for _, c := range competitors {
+		byID[c.ID] = c
+	}
+
+	now := time.Now()
+
+	result := make([]models.Eligibility, len(events))
+	for i, event := range events {
+		result[i] = checkEvent(event, byID, profile, now)
+	}
+	return result, nil
+}
+
+func checkEvent(event models.Event, competitors map[uuid.UUID]models.Competitor, profile models.ApplicantProfile, now time.Time) models.Eligibility {
+	result := models.Eligibility{EventID: event.ID, Title: event.Title, Reasons: make([]string, 0)}
+
+	if !event.SubmissionDeadline.IsZero() && event.SubmissionDeadline.Before(now) {
+		result.Reasons = append(result.Reasons, "submission deadline has passed")
+		return result
+	}
+
+	if len(event.Competitors) == 0 {
+		result.Eligible = true
+		return result
+	}
+
+	// the applicant should match any of the competitors,
+	// if it matches none of them, reasons for all competitors are given,
+	// deleted competitors are skipped, an event left without any of them has no restrictions
+	known := 0
+	for _, id := range event.Competitors {
+		competitor, ok := competitors[id]
+		if !ok {
+			continue
+		}
+		known++
+
+		reasons := services.CheckCompetitor(competitor, profile)
+		if len(reasons) == 0 {
+			result.Eligible = true
+			result.Reasons = result.Reasons[:0]
+			return result
+		}
+		result.Reasons = append(result.Reasons, fmt.Sprintf("%s: %s", competitor.Name, strings.Join(reasons, "; ")))
+	}
+
+	result.Eligible = known == 0
+	return result
+}
+
+func NewEligibilityService(events services.EventService, competitors services.CompetitorService) services.EligibilityService {
+	return &eligibilityService{
+		events:      events,
+		competitors: competitors,
+	}
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+)
+
+func TestCheckEvent(t *testing.T) {
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	students := models.Competitor{ID: uuid.New(), Name: "Students", Category: models.CompetitorCategoryIndividual, MaxAge: 25}
+	companies := models.Competitor{ID: uuid.New(), Name: "Companies", Category: models.CompetitorCategoryOrganization}
+	deleted := uuid.New()
+
+	competitors := map[uuid.UUID]models.Competitor{students.ID: students, companies.ID: companies}
+	student := models.ApplicantProfile{Category: models.CompetitorCategoryIndividual, Age: 20}
+	veteran := models.ApplicantProfile{Category: models.CompetitorCategoryIndividual, Age: 60}
+
+	tests := []struct {
+		name     string
+		event    models.Event
+		profile  models.ApplicantProfile
+		eligible bool
+		reasons  []string
+	}{
+		{"no competitors", models.Event{}, veteran, true, []string{}},
+		{"matches one of the competitors", models.Event{Competitors: []uuid.UUID{companies.ID, students.ID}}, student, true, []string{}},
+		{
+			"matches none of the competitors",
+			models.Event{Competitors: []uuid.UUID{students.ID, companies.ID}},
+			veteran,
+			false,
+			[]string{"Students: age should be at most 25", "Companies: category should be organization"},
+		},
+		{"only deleted competitors", models.Event{Competitors: []uuid.UUID{deleted}}, veteran, true, []string{}},
+		{
+			"deleted competitors are skipped",
+			models.Event{Competitors: []uuid.UUID{deleted, students.ID}},
+			veteran,
+			false,
+			[]string{"Students: age should be at most 25"},
+		},
+		{
+			"submission deadline has passed",
+			models.Event{SubmissionDeadline: now.Add(-time.Hour)},
+			student,
+			false,
+			[]string{"submission deadline has passed"},
+		},
+		{"submission deadline is ahead", models.Event{SubmissionDeadline: now.Add(time.Hour)}, student, true, []string{}},
+	}
+
+	for _, tt := range tests {
+		got := checkEvent(tt.event, competitors, tt.profile, now)
+		if got.Eligible != tt.eligible || !reflect.DeepEqual(got.Reasons, tt.reasons) {
+			t.Errorf("%s: checkEvent = %v %q, want %v %q", tt.name, got.Eligible, got.Reasons, tt.eligible, tt.reasons)
+		}
+	}
+}
@@ -3,7 +3,9 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"sort"
 
 	"github.com/google/uuid"
 
@@ -14,13 +16,16 @@ import (
 )
 
 type eventService struct {
-	organizer        services.OrganizerService
-	foundingRanges   services.RangeService
-	coFoundingRanges services.RangeService
-	competitors      services.CompetitorService
-	subjects         services.SubjectService
+	organizer   services.OrganizerService
+	competitors services.CompetitorService
+	subjects    services.SubjectService
+	currency    services.CurrencyService
 
 	eventStorage adapters.EventStorage
+	search       adapters.EventSearchStorage
+
+	// outbox - receives the domain events about created, updated and deleted events in the transactions of the changes
+	outbox adapters.OutboxStorage
 }
 
 func (svc eventService) AllIDs(ctx context.Context) ([]uuid.UUID, error) {
@@ -33,16 +38,80 @@ func (svc eventService) GetAll(ctx context.Context) ([]models.Event, error) {
 		log.Println(err)
 		return nil, errors.New("internal error")
 	}
-	events := make([]models.Event, len(ids))
-	for i := range ids {
-		events[i], err = svc.GetByID(ctx, ids[i])
-		if err != nil {
-			log.Println(err)
-		}
+	return svc.GetByIDs(ctx, ids)
+}
+
+func (svc eventService) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Event, error) {
+	events, err := svc.eventStorage.GetByIDs(ctx, ids)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("internal error")
 	}
 	return events, nil
 }
 
+func (svc eventService) Find(ctx context.Context, filter models.EventFilter) ([]models.Event, error) {
+	events, err := svc.GetAll(ctx)
+	if err != nil || services.IsEmptyFilter(filter) {
+		return events, err
+	}
+
+	ids := make([]uuid.UUID, len(events))
+	for i, v := range events {
+		ids[i] = v.ID
+	}
+
+	eventSubjects, err := svc.subjects.GetAllForEvents(ctx, ids)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("internal error")
+	}
+
+	parents, err := subjectParents(ctx, svc.subjects)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("internal error")
+	}
+
+	result := make([]models.Event, 0)
+	for _, e := range events {
+		facts := services.EventFacts{
+			Event:            e,
+			ReferenceFunding: services.ReferenceRange(svc.currency, e.FoundingRange),
+			Subjects:         eventSubjects[e.ID],
+		}
+		if services.MatchEvent(filter, facts, parents) {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (svc eventService) SortByFunding(_ context.Context, events []models.Event, descending bool) ([]models.Event, error) {
+	converted := make(map[uuid.UUID]models.RangeModel, len(events))
+	for _, e := range events {
+		converted[e.ID] = services.ReferenceRange(svc.currency, e.FoundingRange)
+	}
+
+	result := make([]models.Event, len(events))
+	copy(result, events)
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := converted[result[i].ID], converted[result[j].ID]
+		// the events without a rate are the last in both orders
+		if (a.Currency == "") != (b.Currency == "") {
+			return a.Currency != ""
+		}
+		if a.High != b.High {
+			return (a.High < b.High) != descending
+		}
+		if a.Low != b.Low {
+			return (a.Low < b.Low) != descending
+		}
+		return false
+	})
+	return result, nil
+}
+
 func (svc eventService) GetByID(ctx context.Context, id uuid.UUID) (models.Event, error) {
 	event, err := svc.eventStorage.GetByID(ctx, id)
 	if err != nil {
@@ -53,35 +122,24 @@ func (svc eventService) GetByID(ctx context.Context, id uuid.UUID) (models.Event
 }
 
 func (svc eventService) validateCreationInfo(ctx context.Context, info services.EventCreateInfo) error {
+	// the existence of the referenced objects is reported with the other violations
+	violations := make(validators.Violations, 0)
+	if err := info.Validate(); err != nil {
+		if !errors.As(err, &violations) {
+			return err
+		}
+	}
+
 	// The organizer should already exist
 	// in the moment of creation it's event
-	{
+	if info.Organizer != uuid.Nil {
 		existedOrganizers, err := svc.organizer.GetAllIDs(ctx)
 		if err != nil {
 			log.Println(err)
 			return errors.New("internal error")
 		}
 		if !validators.IDExists(existedOrganizers, info.Organizer) {
-			return errors.New("organizer does not exist")
-		}
-	}
-
-	// Validate that both Founding Range and CoFoundingRange are valid
-	{
-		if err := validators.ValidateRange(models.RangeModel{Low: info.FoundingRangeLow, High: info.FoundingRangeHigh}); err != nil {
-			return err
-		}
-
-		if err := validators.ValidatePercentRange(models.RangeModel{Low: info.CoFoundingRangeLow, High: info.CoFoundingRangeHigh}); err != nil {
-			return err
-		}
-	}
-
-	// Validate TRL
-	// TRL should be between 0 and 9
-	{
-		if info.TRL < 0 || info.TRL >= 10 {
-			return errors.New("invalid trl")
+			violations = append(violations, validators.Violation{Field: "organizer", Message: "does not exist"})
 		}
 	}
 
@@ -94,32 +152,113 @@ func (svc eventService) validateCreationInfo(ctx context.Context, info services.
 			return errors.New("internal error")
 		}
 
-		for _, competitor := range info.Competitors {
+		for i, competitor := range info.Competitors {
 			if !validators.IDExists(competitors, competitor) {
-				return errors.New("competitor does not exist")
+				violations = append(violations, validators.Violation{Field: fmt.Sprintf("competitors[%d]", i), Message: "does not exist"})
 			}
 		}
 	}
 
+	if len(violations) != 0 {
+		return violations
+	}
 	return nil
 }
 
-func (svc eventService) Create(ctx context.Context, info services.EventCreateInfo) (models.Event, error) {
-	if err := svc.validateCreationInfo(ctx, info); err != nil {
-		return models.Event{}, err
+// eventWrite - an event written in a transaction, it's indexed(or removed from the index, if it's deleted)
+// after the transaction is committed
+type eventWrite struct {
+	event    models.Event
+	subjects []models.Subject
+	deleted  bool
+}
+
+// inTransaction - runs write in a transaction, the transaction is committed if write succeeds
+func (svc eventService) inTransaction(ctx context.Context, write func(ctx context.Context) error) error {
+	tx, err := svc.eventStorage.BeginTransaction(ctx)
+	if err != nil {
+		log.Println(err)
+		return errors.New("failed to start a transaction")
+	}
+	defer func(eventStorage adapters.EventStorage, ctx context.Context, transaction interface{}) {
+		_ = eventStorage.CloseTransaction(ctx, transaction)
+	}(svc.eventStorage, ctx, tx)
+
+	if err := write(context.WithValue(ctx, "connection", tx)); err != nil {
+		return err
+	}
+
+	if err := svc.eventStorage.CommitTransaction(ctx, tx); err != nil {
+		log.Println(err)
+		return errors.New("failed to commit a transaction")
+	}
+	return nil
+}
+
+// index - updates the search index after the write is committed, the index is eventually consistent,
+// so the failures are only logged
+func (svc eventService) index(ctx context.Context, written eventWrite) {
+	if written.deleted {
+		if err := svc.search.Remove(ctx, written.event.ID); err != nil {
+			log.Println("failed to remove event ", written.event.ID.String(), " from index: ", err)
+		}
+		return
 	}
 
-	serviceCtx := ctx
+	if err := svc.search.Index(ctx, written.event, subjectTerms(written.subjects)); err != nil {
+		log.Println("failed to index event ", written.event.ID.String(), ": ", err)
+	}
+}
 
-	founding, err := svc.foundingRanges.Create(serviceCtx, info.FoundingRangeLow, info.FoundingRangeHigh)
+// apply - applies the operation in its own transaction
+func (svc eventService) apply(ctx context.Context, operation services.EventOperation) (eventWrite, error) {
+	var written eventWrite
+	err := svc.inTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		written, err = svc.write(ctx, operation)
+		return err
+	})
 	if err != nil {
-		log.Println(err)
-		return models.Event{}, errors.New("failed to create - founding range")
+		return eventWrite{}, err
 	}
 
-	coFounding, err := svc.coFoundingRanges.Create(serviceCtx, info.CoFoundingRangeLow, info.CoFoundingRangeHigh)
+	svc.index(ctx, written)
+	return written, nil
+}
+
+// write - applies the operation, ctx should have a transaction
+func (svc eventService) write(ctx context.Context, operation services.EventOperation) (eventWrite, error) {
+	switch operation.Type {
+	case services.EventOperationCreate:
+		return svc.create(ctx, operation.Info)
+	case services.EventOperationUpdate:
+		return svc.update(ctx, operation.ID, operation.Version, operation.Info)
+	case services.EventOperationDelete:
+		return svc.delete(ctx, operation.ID, operation.Version)
+	}
+	return eventWrite{}, fmt.Errorf("unknown operation %q", operation.Type)
+}
+
+// stored - returns the stored event, adapters.ErrNotFound if there is no such event
+func (svc eventService) stored(ctx context.Context, id uuid.UUID) (models.Event, error) {
+	events, err := svc.GetByIDs(ctx, []uuid.UUID{id})
 	if err != nil {
-		return models.Event{}, errors.New("failed to create - co founding range")
+		return models.Event{}, err
+	}
+	if len(events) == 0 {
+		return models.Event{}, fmt.Errorf("event %s: %w", id, adapters.ErrNotFound)
+	}
+	return events[0], nil
+}
+
+func (svc eventService) Create(ctx context.Context, info services.EventCreateInfo) (models.Event, error) {
+	written, err := svc.apply(ctx, services.EventOperation{Type: services.EventOperationCreate, Info: info})
+	return written.event, err
+}
+
+func (svc eventService) create(ctx context.Context, info services.EventCreateInfo) (eventWrite, error) {
+	if err := svc.validateCreationInfo(ctx, info); err != nil {
+		return eventWrite{}, err
 	}
 
 	eventId := uuid.New()
@@ -128,8 +267,8 @@ func (svc eventService) Create(ctx context.Context, info services.EventCreateInf
 		Title:               info.Title,
 		Organizer:           info.Organizer,
 		FoundingType:        info.FoundingType,
-		FoundingRange:       founding.ID,
-		CoFoundingRange:     coFounding.ID,
+		FoundingRange:       svc.foundingRange(info),
+		CoFoundingRange:     models.CoFoundingRange{Low: info.CoFoundingRangeLow, High: info.CoFoundingRangeHigh},
 		SubmissionDeadline:  info.SubmissionDeadline,
 		ConsiderationPeriod: info.ConsiderationPeriod,
 		RealisationPeriod:   info.RealisationPeriod,
@@ -139,63 +278,136 @@ func (svc eventService) Create(ctx context.Context, info services.EventCreateInf
 		InternalContacts:    info.InternalContacts,
 		TRL:                 info.TRL,
 		Competitors:         info.Competitors,
+		Version:             1,
 	}
 
-	if err := svc.eventStorage.Add(serviceCtx, event); err != nil {
+	if err := svc.eventStorage.Add(ctx, event); err != nil {
 		log.Println(err)
-		return models.Event{}, errors.New("failed to create - event")
+		return eventWrite{}, errors.New("failed to create - event")
 	}
 
 	for _, v := range info.Competitors {
-		err := svc.eventStorage.AddCompetitor(serviceCtx, eventId, v)
+		err := svc.eventStorage.AddCompetitor(ctx, eventId, v)
 		if err != nil {
 			log.Println(err)
 		}
 	}
 
-	subjects := make([]uuid.UUID, len(info.Subjects))
-	for i, v := range info.Subjects {
-		s, _ := svc.subjects.Create(serviceCtx, eventId, v)
-		subjects[i] = s.ID
+	subjects, err := svc.linkSubjects(ctx, eventId, info.Subjects)
+	if err != nil {
+		log.Println(err)
+		return eventWrite{}, errors.New("failed to create - subjects")
 	}
-	return event, nil
+
+	if err := recordChange(ctx, svc.outbox, models.ChangeEventCreated, eventId, event); err != nil {
+		log.Println(err)
+		return eventWrite{}, errors.New("failed to create - domain event")
+	}
+
+	return eventWrite{event: event, subjects: subjects}, nil
 }
 
-func (svc eventService) Delete(ctx context.Context, id uuid.UUID) error {
-	event, err := svc.GetByID(ctx, id)
+func (svc eventService) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	_, err := svc.apply(ctx, services.EventOperation{Type: services.EventOperationDelete, ID: id, Version: version})
+	return err
+}
+
+func (svc eventService) delete(ctx context.Context, id uuid.UUID, version int) (eventWrite, error) {
+	event, err := svc.stored(ctx, id)
 	if err != nil {
 		log.Println(err)
-		return errors.New("not found - event")
+		return eventWrite{}, err
 	}
 
-	if svc.foundingRanges.Delete(ctx, event.FoundingRange) != nil {
-		return errors.New("failed to delete - founding range")
+	if !services.VersionMatches(version, event.Version) {
+		return eventWrite{}, services.ErrVersionMismatch
 	}
 
-	if svc.coFoundingRanges.Delete(ctx, event.CoFoundingRange) != nil {
-		return errors.New("failed to delete - co founding range")
+	// subjects stay in the catalogue, only the links to them are deleted
+	if err := svc.subjects.SetForEvent(ctx, event.ID, nil); err != nil {
+		log.Println(err)
+		return eventWrite{}, errors.New("failed to delete - subjects")
 	}
 
-	subjects, err := svc.subjects.GetAllForEvent(ctx, event.ID)
+	err = svc.eventStorage.Remove(ctx, event.ID, event.Version)
 	if err != nil {
 		log.Println(err)
-		return errors.New("failed to get - subjects")
+		if errors.Is(err, adapters.ErrStaleVersion) {
+			return eventWrite{}, services.ErrVersionMismatch
+		}
+		return eventWrite{}, errors.New("failed to delete event")
 	}
 
-	for _, v := range subjects {
-		err := svc.subjects.Delete(ctx, v.ID)
-		if err != nil {
-			log.Println(err)
-			return errors.New("failed to delete subject")
+	if err := recordChange(ctx, svc.outbox, models.ChangeEventDeleted, event.ID, event); err != nil {
+		log.Println(err)
+		return eventWrite{}, errors.New("failed to delete - domain event")
+	}
+
+	return eventWrite{event: event, deleted: true}, nil
+}
+
+func (svc eventService) Batch(ctx context.Context, operations []services.EventOperation, allOrNothing bool) ([]services.EventOperationResult, error) {
+	if err := services.ValidateEventOperations(operations); err != nil {
+		return nil, err
+	}
+
+	results := make([]services.EventOperationResult, len(operations))
+	if !allOrNothing {
+		for i, operation := range operations {
+			written, err := svc.apply(ctx, operation)
+			results[i] = services.EventOperationResult{Event: written.event, Err: err}
+		}
+		return results, nil
+	}
+
+	written := make([]eventWrite, 0, len(operations))
+	failed := -1
+	err := svc.inTransaction(ctx, func(ctx context.Context) error {
+		for i, operation := range operations {
+			w, err := svc.write(ctx, operation)
+			if err != nil {
+				failed = i
+				results[i] = services.EventOperationResult{Err: err}
+				return err
+			}
+			written = append(written, w)
+			results[i] = services.EventOperationResult{Event: w.event}
+		}
+		return nil
+	})
+	if err != nil {
+		failBatch(results, failed, err)
+		return results, nil
+	}
+
+	for _, w := range written {
+		svc.index(ctx, w)
+	}
+	return results, nil
+}
+
+// failBatch - sets the results of a failed all-or-nothing batch, the operations before the failed one are rolled back,
+// the ones after it are skipped, failed is -1 if the transaction itself has failed with err
+func failBatch(results []services.EventOperationResult, failed int, err error) {
+	for i := range results {
+		switch {
+		case failed == -1:
+			results[i] = services.EventOperationResult{Err: err}
+		case i < failed:
+			results[i] = services.EventOperationResult{Err: services.ErrBatchRolledBack}
+		case i > failed:
+			results[i] = services.EventOperationResult{Err: services.ErrBatchSkipped}
 		}
 	}
+}
 
-	err = svc.eventStorage.Remove(ctx, event.ID)
+func (svc eventService) Search(ctx context.Context, query string, limit int) ([]models.EventSearchHit, error) {
+	hits, err := svc.search.Search(ctx, query, limit)
 	if err != nil {
 		log.Println(err)
-		return errors.New("failed to delete event")
+		return nil, errors.New("internal error")
 	}
-	return nil
+	return hits, nil
 }
 
 func (svc eventService) GetAllAsMinimal(ctx context.Context) ([]services.EventMinimal, error) {
@@ -233,6 +445,9 @@ func (svc eventService) GetByIDAsMinimal(ctx context.Context, id uuid.UUID) (ser
 func (svc eventService) updateEventModel(e models.Event, i services.EventCreateInfo) models.Event {
 	e.Title = i.Title
 	e.Organizer = i.Organizer
+	e.FoundingType = i.FoundingType
+	e.FoundingRange = svc.foundingRange(i)
+	e.CoFoundingRange = models.CoFoundingRange{Low: i.CoFoundingRangeLow, High: i.CoFoundingRangeHigh}
 	e.SubmissionDeadline = i.SubmissionDeadline
 	e.ConsiderationPeriod = i.ConsiderationPeriod
 	e.RealisationPeriod = i.RealisationPeriod
@@ -245,91 +460,152 @@ func (svc eventService) updateEventModel(e models.Event, i services.EventCreateI
 	return e
 }
 
-func (svc eventService) Update(ctx context.Context, id uuid.UUID, info services.EventCreateInfo) (models.Event, error) {
-	storedEvent, err := svc.GetByID(ctx, id)
+func (svc eventService) Update(ctx context.Context, id uuid.UUID, version int, info services.EventCreateInfo) (models.Event, error) {
+	written, err := svc.apply(ctx, services.EventOperation{Type: services.EventOperationUpdate, ID: id, Version: version, Info: info})
+	return written.event, err
+}
+
+func (svc eventService) update(ctx context.Context, id uuid.UUID, version int, info services.EventCreateInfo) (eventWrite, error) {
+	storedEvent, err := svc.stored(ctx, id)
 	if err != nil {
 		log.Println(err)
-		return models.Event{}, errors.New("event was not found")
+		return eventWrite{}, err
 	}
 
-	if err := svc.validateCreationInfo(ctx, info); err != nil {
-		log.Println(err)
-		return models.Event{}, err
+	if !services.VersionMatches(version, storedEvent.Version) {
+		return eventWrite{}, services.ErrVersionMismatch
 	}
 
-	tx, err := svc.eventStorage.BeginTransaction(ctx)
-	if err != nil {
+	if err := svc.validateCreationInfo(ctx, info); err != nil {
 		log.Println(err)
+		return eventWrite{}, err
 	}
-	defer func(eventStorage adapters.EventStorage, ctx context.Context, transaction interface{}) {
-		_ = eventStorage.CloseTransaction(ctx, transaction)
-	}(svc.eventStorage, ctx, tx)
-
-	serviceCtx := context.WithValue(ctx, "connection", tx)
 
 	storedEvent = svc.updateEventModel(storedEvent, info)
 
-	if err := svc.eventStorage.Update(serviceCtx, storedEvent); err != nil {
+	if err := svc.eventStorage.Update(ctx, storedEvent); err != nil {
 		log.Println(err)
-		return models.Event{}, errors.New("failed to update event")
+		if errors.Is(err, adapters.ErrStaleVersion) {
+			return eventWrite{}, services.ErrVersionMismatch
+		}
+		return eventWrite{}, errors.New("failed to update event")
 	}
 
-	foundingRange := models.FoundingRange{ID: storedEvent.FoundingRange, Low: info.FoundingRangeLow, High: info.FoundingRangeHigh}
-	if _, err := svc.foundingRanges.Update(ctx, foundingRange); err != nil {
+	if err := svc.updateAllCompetitors(ctx, storedEvent.ID, info.Competitors); err != nil {
 		log.Println(err)
-		return models.Event{}, errors.New("failed to update founding range")
+		return eventWrite{}, errors.New("failed to update competitors")
 	}
 
-	coFoundingRange := models.FoundingRange{ID: storedEvent.FoundingRange, Low: info.CoFoundingRangeLow, High: info.CoFoundingRangeHigh}
-	if _, err := svc.coFoundingRanges.Update(ctx, coFoundingRange); err != nil {
+	subjects, err := svc.linkSubjects(ctx, storedEvent.ID, info.Subjects)
+	if err != nil {
 		log.Println(err)
-		return models.Event{}, errors.New("failed to update co-founding range")
+		return eventWrite{}, errors.New("failed to update subjects")
 	}
 
-	err = svc.updateAllSubjects(ctx, storedEvent.ID, info.Subjects)
-	if err != nil {
+	storedEvent.Version++
+
+	if err := recordChange(ctx, svc.outbox, models.ChangeEventUpdated, storedEvent.ID, storedEvent); err != nil {
 		log.Println(err)
+		return eventWrite{}, errors.New("failed to update - domain event")
 	}
 
-	return storedEvent, nil
+	return eventWrite{event: storedEvent, subjects: subjects}, nil
+}
+
+// foundingRange - the founding range of the info in the minor units, the bounds without a currency
+// are in the reference currency
+func (svc eventService) foundingRange(info services.EventCreateInfo) models.FoundingRange {
+	currency := info.FoundingCurrency
+	if currency == "" {
+		currency = svc.currency.Reference()
+	}
+	return models.FoundingRange{
+		Low:      models.MoneyFromMajor(info.FoundingRangeLow, currency).Amount,
+		High:     models.MoneyFromMajor(info.FoundingRangeHigh, currency).Amount,
+		Currency: currency,
+	}
 }
 
-func (svc eventService) updateAllSubjects(ctx context.Context, id uuid.UUID, subjects []string) error {
-	existedSubjects, err := svc.subjects.GetAllForEvent(ctx, id)
+func (svc eventService) updateAllCompetitors(ctx context.Context, id uuid.UUID, competitors []uuid.UUID) error {
+	existedCompetitors, err := svc.eventStorage.GetCompetitors(ctx, id)
 	if err != nil {
-		log.Println(err)
-		return errors.New("failed to get subjects")
+		return err
 	}
 
-	for _, v := range existedSubjects {
-		err := svc.subjects.Delete(ctx, v.ID)
-		if err != nil {
-			log.Println("failed to delete subject", v.ID.String(), " ", err)
+	for _, v := range existedCompetitors {
+		if !validators.IDExists(competitors, v) {
+			if err := svc.eventStorage.RemoveCompetitor(ctx, id, v); err != nil {
+				return err
+			}
 		}
 	}
 
-	for _, v := range subjects {
-		_, err := svc.subjects.Create(ctx, id, v)
-		if err != nil {
-			log.Println("failed to add subject ", v, " to event ", id.String(), ": ", err.Error())
-			return err
+	for _, v := range competitors {
+		if !validators.IDExists(existedCompetitors, v) {
+			if err := svc.eventStorage.AddCompetitor(ctx, id, v); err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
+// linkSubjects - links the event to the subjects with given names, missing subjects are added to the catalogue
+func (svc eventService) linkSubjects(ctx context.Context, id uuid.UUID, names []string) ([]models.Subject, error) {
+	subjects, err := svc.subjects.Resolve(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(subjects))
+	for i, v := range subjects {
+		ids[i] = v.ID
+	}
+
+	if err := svc.subjects.SetForEvent(ctx, id, ids); err != nil {
+		return nil, err
+	}
+	return subjects, nil
+}
+
+// subjectParents - parents of all subjects of the catalogue by their ids, they're used by services.MatchEvent
+func subjectParents(ctx context.Context, subjects services.SubjectService) (map[uuid.UUID]uuid.UUID, error) {
+	catalogue, err := subjects.GetAllExisting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	parents := make(map[uuid.UUID]uuid.UUID, len(catalogue))
+	for _, s := range catalogue {
+		parents[s.ID] = s.Parent
+	}
+	return parents, nil
+}
+
+// subjectTerms - names and synonyms of the subjects, they're indexed with the event
+func subjectTerms(subjects []models.Subject) []string {
+	terms := make([]string, 0, len(subjects))
+	for _, v := range subjects {
+		terms = append(terms, v.Name)
+		terms = append(terms, v.Synonyms...)
+	}
+	return terms
+}
+
 func NewEventServices(storage adapters.EventStorage,
+	search adapters.EventSearchStorage,
 	subjects services.SubjectService,
 	organizer services.OrganizerService,
-	foundingRange, coFoundingRange services.RangeService,
-	competitors services.CompetitorService) services.EventService {
+	competitors services.CompetitorService,
+	currency services.CurrencyService,
+	outbox adapters.OutboxStorage) services.EventService {
 	return &eventService{
-		organizer:        organizer,
-		foundingRanges:   foundingRange,
-		coFoundingRanges: coFoundingRange,
-		competitors:      competitors,
-		eventStorage:     storage,
-		subjects:         subjects,
+		organizer:    organizer,
+		competitors:  competitors,
+		eventStorage: storage,
+		search:       search,
+		subjects:     subjects,
+		currency:     currency,
+		outbox:       outbox,
 	}
 }
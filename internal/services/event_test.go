@@ -0,0 +1,42 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+func TestFailBatch(t *testing.T) {
+	failure := errors.New("failure")
+
+	tests := []struct {
+		name   string
+		failed int
+		want   []error
+	}{
+		{"first operation has failed", 0, []error{failure, services.ErrBatchSkipped, services.ErrBatchSkipped}},
+		{"middle operation has failed", 1, []error{services.ErrBatchRolledBack, failure, services.ErrBatchSkipped}},
+		{"last operation has failed", 2, []error{services.ErrBatchRolledBack, services.ErrBatchRolledBack, failure}},
+		{"transaction has failed", -1, []error{failure, failure, failure}},
+	}
+
+	for _, tt := range tests {
+		// the results are as they are left by the transaction
+		results := make([]services.EventOperationResult, 3)
+		if tt.failed != -1 {
+			results[tt.failed].Err = failure
+		}
+
+		failBatch(results, tt.failed, failure)
+
+		got := make([]error, len(results))
+		for i, r := range results {
+			got[i] = r.Err
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: errors = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+type favouriteService struct {
+	storage adapters.FavouriteStorage
+	events  services.EventService
+}
+
+func (svc favouriteService) Add(ctx context.Context, user, event uuid.UUID) error {
+	if _, err := svc.events.GetByID(ctx, event); err != nil {
+		return adapters.ErrNotFound
+	}
+
+	favourite := models.Favourite{User: user, Event: event, CreatedAt: time.Now().UTC()}
+	if err := svc.storage.Add(ctx, favourite); err != nil {
+		log.Println(err)
+		return errors.New("failed to add favourite")
+	}
+	return nil
+}
+
+func (svc favouriteService) Remove(ctx context.Context, user, event uuid.UUID) error {
+	if err := svc.storage.Remove(ctx, user, event); err != nil {
+		log.Println(err)
+		return errors.New("failed to remove favourite")
+	}
+	return nil
+}
+
+func (svc favouriteService) GetAll(ctx context.Context, user uuid.UUID) ([]models.Event, error) {
+	favourites, err := svc.storage.GetByUser(ctx, user)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("internal error")
+	}
+
+	ids := make([]uuid.UUID, len(favourites))
+	for i, v := range favourites {
+		ids[i] = v.Event
+	}
+
+	events, err := svc.events.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// events are returned in the order of favourites
+	byID := make(map[uuid.UUID]models.Event, len(events))
+	for _, e := range events {
+		byID[e.ID] = e
+	}
+	result := make([]models.Event, 0, len(events))
+	for _, id := range ids {
+		if e, ok := byID[id]; ok {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (svc favouriteService) AreFavourite(ctx context.Context, user uuid.UUID, events []uuid.UUID) (map[uuid.UUID]bool, error) {
+	favourites, err := svc.storage.Filter(ctx, user, events)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("internal error")
+	}
+
+	result := make(map[uuid.UUID]bool, len(events))
+	for _, id := range events {
+		result[id] = false
+	}
+	for _, id := range favourites {
+		result[id] = true
+	}
+	return result, nil
+}
+
+func NewFavouriteService(storage adapters.FavouriteStorage, events services.EventService) services.FavouriteService {
+	return &favouriteService{
+		storage: storage,
+		events:  events,
+	}
+}
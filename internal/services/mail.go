@@ -0,0 +1,68 @@
+package services
+
+import (
+	"bytes"
+	"embed"
+	"strings"
+	"text/template"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+//go:embed templates/*.tmpl
+var mailTemplateFiles embed.FS
+
+// mailTemplates - templates of a mail in all languages keyed by the language,
+// every template defines "subject" and "body"
+type mailTemplates map[string]*template.Template
+
+// parseMailTemplates - parses templates/<name>.<language>.tmpl files
+func parseMailTemplates(name string) mailTemplates {
+	funcs := template.FuncMap{"plural": plural}
+
+	result := make(mailTemplates)
+	for _, lang := range services.Languages {
+		file := "templates/" + name + "." + lang + ".tmpl"
+		result[lang] = template.Must(template.New(lang).Funcs(funcs).ParseFS(mailTemplateFiles, file))
+	}
+	return result
+}
+
+// render - executes the template in the language, russian is used for unknown languages
+func (t mailTemplates) render(lang, to string, data interface{}) (adapters.Mail, error) {
+	tmpl, ok := t[lang]
+	if !ok {
+		tmpl = t[models.LanguageRussian]
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return adapters.Mail{}, err
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return adapters.Mail{}, err
+	}
+
+	return adapters.Mail{
+		To:      to,
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    strings.TrimSpace(body.String()) + "\n",
+	}, nil
+}
+
+// plural - chooses a form of the word for the number, the forms follow russian rules,
+// for english the second and the third forms are the same
+func plural(n int, one, few, many string) string {
+	n = n % 100
+	switch {
+	case n >= 11 && n <= 14:
+		return many
+	case n%10 == 1:
+		return one
+	case n%10 >= 2 && n%10 <= 4:
+		return few
+	}
+	return many
+}
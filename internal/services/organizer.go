@@ -16,6 +16,26 @@ type organizerSvc struct {
 	storage adapters.OrganizerStorage
 
 	imageSvc services.ImageService
+
+	// outbox - receives the domain events about created, updated and deleted organizers in the transactions of the changes
+	outbox adapters.OutboxStorage
+}
+
+// inTransaction - runs write in a transaction, the transaction is committed if write succeeds
+func (o organizerSvc) inTransaction(ctx context.Context, write func(ctx context.Context) error) error {
+	transaction, err := o.storage.BeginTransaction(ctx)
+	if err != nil {
+		log.Println(err)
+		return errors.New("failed to start a transaction")
+	}
+	defer func(ctx context.Context, transaction interface{}) {
+		_ = o.storage.CloseTransaction(ctx, transaction)
+	}(ctx, transaction)
+
+	if err := write(context.WithValue(ctx, "connection", transaction)); err != nil {
+		return err
+	}
+	return o.storage.CommitTransaction(ctx, transaction)
 }
 
 func (o organizerSvc) GetAllIDs(ctx context.Context) ([]uuid.UUID, error) {
@@ -34,43 +54,70 @@ func (o organizerSvc) GetByID(ctx context.Context, id uuid.UUID) (models.Organiz
 	return o.storage.GetByID(ctx, id)
 }
 
+func (o organizerSvc) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Organizer, error) {
+	return o.storage.GetByIDs(ctx, ids)
+}
+
 func (o organizerSvc) Create(ctx context.Context, name, logo string, level uuid.UUID) (models.Organizer, error) {
-	// TODO: add validation of user input
+	if err := services.ValidateOrganizer(name, logo, level); err != nil {
+		return models.Organizer{}, err
+	}
+
 	organizer :=
-		models.Organizer{ID: uuid.New(), Name: name, Logo: logo, Level: level}
-	err := o.storage.Add(ctx, organizer)
+		models.Organizer{ID: uuid.New(), Name: name, Logo: logo, Level: level, Version: 1}
+	err := o.inTransaction(ctx, func(ctx context.Context) error {
+		if err := o.storage.Add(ctx, organizer); err != nil {
+			return err
+		}
+		return recordChange(ctx, o.outbox, models.ChangeOrganizerCreated, organizer.ID, organizer)
+	})
 	if err != nil {
 		return models.Organizer{}, err
 	}
+
 	return organizer, nil
 }
 
-func (o organizerSvc) Delete(ctx context.Context, id uuid.UUID) error {
+func (o organizerSvc) Delete(ctx context.Context, id uuid.UUID, version int) error {
 	organizer, err := o.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	transaction, err := o.storage.BeginTransaction(ctx)
-	defer func(ctx context.Context, transaction interface{}) {
-		_ = o.storage.CloseTransaction(ctx, transaction)
-	}(ctx, transaction)
-
-	serviceCtx := context.WithValue(ctx, "connection", transaction)
-
-	if err := o.imageSvc.Delete(serviceCtx, organizer.Logo); err != nil {
-		log.Println(err)
-		return errors.New("failed to delete image")
+	if !services.VersionMatches(version, organizer.Version) {
+		return services.ErrVersionMismatch
 	}
-	return o.storage.Remove(serviceCtx, id)
+
+	return o.inTransaction(ctx, func(ctx context.Context) error {
+		if err := o.storage.Remove(ctx, id, organizer.Version); err != nil {
+			if errors.Is(err, adapters.ErrStaleVersion) {
+				return services.ErrVersionMismatch
+			}
+			return err
+		}
+
+		if err := o.imageSvc.Delete(ctx, organizer.Logo); err != nil {
+			log.Println(err)
+			return errors.New("failed to delete image")
+		}
+
+		return recordChange(ctx, o.outbox, models.ChangeOrganizerDeleted, organizer.ID, organizer)
+	})
 }
 
 func (o organizerSvc) GetAllLevels(ctx context.Context) ([]models.OrganizerLevel, error) {
 	return o.storage.GetLevels(ctx)
 }
 
+func (o organizerSvc) GetLevelsByIDs(ctx context.Context, ids []uuid.UUID) ([]models.OrganizerLevel, error) {
+	return o.storage.GetLevelsByIDs(ctx, ids)
+}
+
 func (o organizerSvc) CreateLevel(ctx context.Context, name string, code string) (models.OrganizerLevel, error) {
-	// TODO: add validation
+	if err := services.ValidateOrganizerLevel(name, code); err != nil {
+		return models.OrganizerLevel{}, err
+	}
+
 	level := models.OrganizerLevel{ID: uuid.New(), Name: name, Code: code}
 
 	if err := o.storage.AddLevel(ctx, level); err != nil {
@@ -83,25 +130,56 @@ func (o organizerSvc) UpdateLevel(_ context.Context, _ models.OrganizerLevel) (m
 	panic("unimplemented")
 }
 
-func (o organizerSvc) Update(ctx context.Context, id uuid.UUID, name, logo string, level uuid.UUID) (models.Organizer, error) {
+func (o organizerSvc) Update(ctx context.Context, id uuid.UUID, version int, name, logo string, level uuid.UUID) (models.Organizer, error) {
+	if err := services.ValidateOrganizer(name, logo, level); err != nil {
+		return models.Organizer{}, err
+	}
+
+	stored, err := o.GetByID(ctx, id)
+	if err != nil {
+		return models.Organizer{}, err
+	}
+
+	if !services.VersionMatches(version, stored.Version) {
+		return models.Organizer{}, services.ErrVersionMismatch
+	}
+
 	m := models.Organizer{
-		ID:    id,
-		Name:  name,
-		Logo:  logo,
-		Level: level,
+		ID:      id,
+		Name:    name,
+		Logo:    logo,
+		Level:   level,
+		Version: stored.Version,
 	}
 
-	if err := o.storage.Update(ctx, m); err != nil {
-		log.Println(err)
-		return models.Organizer{}, errors.New("failed to update an organizer")
+	err = o.inTransaction(ctx, func(ctx context.Context) error {
+		if err := o.storage.Update(ctx, m); err != nil {
+			log.Println(err)
+			if errors.Is(err, adapters.ErrStaleVersion) {
+				return services.ErrVersionMismatch
+			}
+			return errors.New("failed to update an organizer")
+		}
+
+		m.Version++
+
+		return recordChange(ctx, o.outbox, models.ChangeOrganizerUpdated, m.ID, m)
+	})
+	if err != nil {
+		return models.Organizer{}, err
 	}
 
 	return m, nil
 }
 
-func NewOrganizerService(storage adapters.OrganizerStorage, imageSvc services.ImageService) (services.OrganizerService, error) {
+func (o organizerSvc) Suggest(ctx context.Context, text string, limit int) ([]models.Suggestion, error) {
+	return o.storage.Suggest(ctx, text, limit)
+}
+
+func NewOrganizerService(storage adapters.OrganizerStorage, imageSvc services.ImageService, outbox adapters.OutboxStorage) (services.OrganizerService, error) {
 	return &organizerSvc{
 		storage:  storage,
 		imageSvc: imageSvc,
+		outbox:   outbox,
 	}, nil
 }
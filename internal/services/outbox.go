@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+// outboxBatch - the maximum number of the events published in one run
+const outboxBatch = 100
+
+type outboxService struct {
+	storage    adapters.OutboxStorage
+	publishers []adapters.DomainEventPublisher
+
+	// retention - how long the published events are kept, they're kept forever if it's zero
+	retention time.Duration
+}
+
+func (svc outboxService) Relay(ctx context.Context, now time.Time) (int, error) {
+	// every publisher has its own record of published events, so a failed one doesn't hold the others
+	failures := make([]error, len(svc.publishers))
+	names := make([]string, len(svc.publishers))
+
+	var wg sync.WaitGroup
+	for i, p := range svc.publishers {
+		names[i] = p.Name()
+
+		wg.Add(1)
+		go func(i int, p adapters.DomainEventPublisher) {
+			defer wg.Done()
+			failures[i] = svc.relayTo(ctx, p)
+		}(i, p)
+	}
+	wg.Wait()
+
+	published, err := svc.storage.MarkPublishedToAll(ctx, names, now)
+	if err != nil {
+		return 0, err
+	}
+
+	if svc.retention > 0 {
+		if _, err := svc.storage.RemovePublished(ctx, now.Add(-svc.retention)); err != nil {
+			log.Println("failed to remove published domain events: ", err)
+		}
+	}
+
+	messages := make([]string, 0)
+	for _, err := range failures {
+		if err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+	if len(messages) > 0 {
+		return published, errors.New(strings.Join(messages, "; "))
+	}
+	return published, nil
+}
+
+// relayTo - publishes the events to the publisher until the first failed one
+func (svc outboxService) relayTo(ctx context.Context, p adapters.DomainEventPublisher) error {
+	events, err := svc.storage.GetUnpublished(ctx, p.Name(), outboxBatch)
+	if err != nil {
+		return fmt.Errorf("%s: %w", p.Name(), err)
+	}
+
+	for _, e := range events {
+		if err := p.Publish(ctx, e); err != nil {
+			return fmt.Errorf("%s: failed to publish %s %s: %w", p.Name(), e.Type, e.ID, err)
+		}
+		if err := svc.storage.MarkPublished(ctx, p.Name(), e.ID); err != nil {
+			return fmt.Errorf("%s: %w", p.Name(), err)
+		}
+	}
+	return nil
+}
+
+// recordChange - adds the domain event about the change to the outbox,
+// ctx should hold the transaction of the change, so the event is stored only with the change
+func recordChange(ctx context.Context, outbox adapters.OutboxStorage, changeType string, id uuid.UUID, object interface{}) error {
+	event, err := services.NewDomainEvent(changeType, id, object, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	return outbox.Add(ctx, event)
+}
+
+func NewOutboxService(storage adapters.OutboxStorage, retention time.Duration, publishers ...adapters.DomainEventPublisher) services.OutboxService {
+	return &outboxService{
+		storage:    storage,
+		publishers: publishers,
+		retention:  retention,
+	}
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+)
+
+// fakeOutboxStorage - keeps the events and the deliveries in memory like the postgres storage
+type fakeOutboxStorage struct {
+	mu         sync.Mutex
+	events     []models.DomainEvent
+	deliveries map[string]map[uuid.UUID]bool
+}
+
+func (s *fakeOutboxStorage) Add(_ context.Context, event models.DomainEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *fakeOutboxStorage) GetUnpublished(_ context.Context, publisher string, limit int) ([]models.DomainEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]models.DomainEvent, 0)
+	for _, e := range s.events {
+		if e.PublishedAt.IsZero() && !s.deliveries[publisher][e.ID] && len(result) < limit {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (s *fakeOutboxStorage) MarkPublished(_ context.Context, publisher string, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.deliveries[publisher] == nil {
+		s.deliveries[publisher] = make(map[uuid.UUID]bool)
+	}
+	s.deliveries[publisher][id] = true
+	return nil
+}
+
+func (s *fakeOutboxStorage) MarkPublishedToAll(_ context.Context, publishers []string, at time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	marked := 0
+	for i, e := range s.events {
+		all := e.PublishedAt.IsZero()
+		for _, p := range publishers {
+			all = all && s.deliveries[p][e.ID]
+		}
+		if all {
+			s.events[i].PublishedAt = at
+			marked++
+		}
+	}
+	return marked, nil
+}
+
+func (s *fakeOutboxStorage) RemovePublished(_ context.Context, _ time.Time) (int, error) {
+	return 0, nil
+}
+
+// fakePublisher - records the types of published events, fails the events of the types in failing
+type fakePublisher struct {
+	name      string
+	failing   map[string]bool
+	published []string
+}
+
+func (p *fakePublisher) Name() string {
+	return p.name
+}
+
+func (p *fakePublisher) Publish(_ context.Context, event models.DomainEvent) error {
+	if p.failing[event.Type] {
+		return errors.New("unavailable")
+	}
+	p.published = append(p.published, event.Type)
+	return nil
+}
+
+func TestRelayIsolatesPublishers(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	storage := &fakeOutboxStorage{deliveries: make(map[string]map[uuid.UUID]bool)}
+	for _, changeType := range []string{"first", "second", "third"} {
+		_ = storage.Add(ctx, models.DomainEvent{ID: uuid.New(), Type: changeType})
+	}
+
+	healthy := &fakePublisher{name: "healthy"}
+	broken := &fakePublisher{name: "broken", failing: map[string]bool{"second": true}}
+	svc := NewOutboxService(storage, 0, healthy, broken)
+
+	published, err := svc.Relay(ctx, now)
+	if err == nil {
+		t.Errorf("Relay with a failing publisher has returned no error")
+	}
+	if published != 1 {
+		t.Errorf("Relay has published %d events to all publishers, want 1", published)
+	}
+	if want := []string{"first", "second", "third"}; !reflect.DeepEqual(healthy.published, want) {
+		t.Errorf("healthy publisher got %v, want %v", healthy.published, want)
+	}
+	// the failed event holds the following ones, so the order is kept
+	if want := []string{"first"}; !reflect.DeepEqual(broken.published, want) {
+		t.Errorf("broken publisher got %v, want %v", broken.published, want)
+	}
+
+	broken.failing = nil
+	published, err = svc.Relay(ctx, now)
+	if err != nil {
+		t.Errorf("Relay after the recovery: %v", err)
+	}
+	if published != 2 {
+		t.Errorf("Relay after the recovery has published %d events to all publishers, want 2", published)
+	}
+	if want := []string{"first", "second", "third"}; !reflect.DeepEqual(healthy.published, want) {
+		t.Errorf("healthy publisher got %v after the recovery, want %v", healthy.published, want)
+	}
+	if want := []string{"first", "second", "third"}; !reflect.DeepEqual(broken.published, want) {
+		t.Errorf("broken publisher got %v after the recovery, want %v", broken.published, want)
+	}
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+)
+
+type profileService struct {
+	storage  adapters.ProfileStorage
+	subjects services.SubjectService
+}
+
+func (svc profileService) GetAll(ctx context.Context) ([]models.Profile, error) {
+	return svc.storage.GetAll(ctx)
+}
+
+func (svc profileService) GetByID(ctx context.Context, id uuid.UUID) (models.Profile, error) {
+	return svc.storage.GetByID(ctx, id)
+}
+
+func (svc profileService) validate(ctx context.Context, info services.ProfileInfo) error {
+	if err := info.Validate(); err != nil {
+		return err
+	}
+
+	subjects, err := svc.subjects.GetAllExisting(ctx)
+	if err != nil {
+		log.Println(err)
+		return errors.New("internal error")
+	}
+
+	existing := make([]uuid.UUID, len(subjects))
+	for i, v := range subjects {
+		existing[i] = v.ID
+	}
+
+	violations := make(validators.Violations, 0)
+	for i, v := range info.Subjects {
+		if !validators.IDExists(existing, v) {
+			violations = append(violations, validators.Violation{Field: fmt.Sprintf("subjects[%d]", i), Message: "does not exist"})
+		}
+	}
+
+	if len(violations) != 0 {
+		return violations
+	}
+	return nil
+}
+
+func (svc profileService) Create(ctx context.Context, info services.ProfileInfo) (models.Profile, error) {
+	if err := svc.validate(ctx, info); err != nil {
+		return models.Profile{}, err
+	}
+
+	profile := buildProfile(uuid.New(), info)
+
+	if err := svc.storage.Add(ctx, profile); err != nil {
+		log.Println(err)
+		return models.Profile{}, errors.New("failed to add")
+	}
+	return profile, nil
+}
+
+func (svc profileService) Update(ctx context.Context, id uuid.UUID, info services.ProfileInfo) (models.Profile, error) {
+	if err := svc.validate(ctx, info); err != nil {
+		return models.Profile{}, err
+	}
+
+	profile := buildProfile(id, info)
+
+	if err := svc.storage.Update(ctx, profile); err != nil {
+		log.Println(err)
+		return models.Profile{}, err
+	}
+	return profile, nil
+}
+
+func (svc profileService) Delete(ctx context.Context, id uuid.UUID) error {
+	return svc.storage.Delete(ctx, id)
+}
+
+func buildProfile(id uuid.UUID, info services.ProfileInfo) models.Profile {
+	subjects := info.Subjects
+	if subjects == nil {
+		subjects = make([]uuid.UUID, 0)
+	}
+
+	return models.Profile{
+		ID:                id,
+		Name:              info.Name,
+		Applicant:         info.Applicant,
+		TRL:               info.TRL,
+		Subjects:          subjects,
+		NeededFunding:     info.NeededFunding,
+		CoFundingCapacity: info.CoFundingCapacity,
+	}
+}
+
+func NewProfileService(storage adapters.ProfileStorage, subjects services.SubjectService) services.ProfileService {
+	return &profileService{
+		storage:  storage,
+		subjects: subjects,
+	}
+}
@@ -10,18 +10,21 @@ import (
 	"github.com/indigowar/map-of-events/internal/domain/adapters"
 	"github.com/indigowar/map-of-events/internal/domain/models"
 	"github.com/indigowar/map-of-events/internal/domain/services"
-	"github.com/indigowar/map-of-events/internal/domain/validators"
 )
 
 type rangeService struct {
-	storage        adapters.RangeStorage
-	validatorsList []func(foundingRange models.RangeModel) error
+	storage adapters.RangeStorage
+	// currency - the maximum range is converted to the reference currency, nil for the ranges without a currency
+	currency services.CurrencyService
 }
 
-func (svc rangeService) GetByID(ctx context.Context, id uuid.UUID) (models.RangeModel, error) {
-	r, err := svc.storage.GetByID(ctx, id)
+func (svc rangeService) GetByEvent(ctx context.Context, event uuid.UUID) (models.RangeModel, error) {
+	r, err := svc.storage.GetByEvent(ctx, event)
 	if err != nil {
 		log.Println(err)
+		if errors.Is(err, adapters.ErrNotFound) {
+			return models.RangeModel{}, err
+		}
 		return models.RangeModel{}, errors.New("internal error")
 	}
 
@@ -29,57 +32,31 @@ func (svc rangeService) GetByID(ctx context.Context, id uuid.UUID) (models.Range
 }
 
 func (svc rangeService) GetMaximumRange(ctx context.Context) (models.RangeModel, error) {
-	result, err := svc.storage.GetMaximumRange(ctx)
+	var factors map[string]float64
+	if svc.currency != nil {
+		factors = svc.currency.Factors()
+	}
+
+	result, err := svc.storage.GetMaximumRange(ctx, factors)
 	if err != nil {
 		log.Println(err)
 		return models.RangeModel{}, errors.New("internal error")
 	}
-	return result, nil
-}
-
-func (svc rangeService) Create(ctx context.Context, low, high int) (models.RangeModel, error) {
-	r := models.RangeModel{ID: uuid.New(), Low: low, High: high}
-
-	for _, validator := range svc.validatorsList {
-		if err := validator(r); err != nil {
-			return models.RangeModel{}, err
-		}
-	}
-
-	return svc.storage.Create(ctx, r)
-}
-
-func (svc rangeService) Delete(ctx context.Context, id uuid.UUID) error {
-	return svc.storage.Delete(ctx, id)
-}
-
-func (svc rangeService) Update(ctx context.Context, foundingRange models.RangeModel) (models.RangeModel, error) {
-	for _, validator := range svc.validatorsList {
-		if err := validator(foundingRange); err != nil {
-			return models.RangeModel{}, err
-		}
+	if svc.currency != nil {
+		result.Currency = svc.currency.Reference()
 	}
-
-	err := svc.Delete(ctx, foundingRange.ID)
-	if err != nil {
-		return models.RangeModel{}, err
-	}
-	return svc.storage.Create(ctx, foundingRange)
+	return result, nil
 }
 
-func NewFoundingRangeService(storage adapters.RangeStorage) services.RangeService {
+func NewFoundingRangeService(storage adapters.RangeStorage, currency services.CurrencyService) services.RangeService {
 	return &rangeService{
-		storage:        storage,
-		validatorsList: []func(foundingRange models.RangeModel) error{validators.ValidateRange},
+		storage:  storage,
+		currency: currency,
 	}
 }
 
 func NewCoFoundingRangeService(storage adapters.RangeStorage) services.RangeService {
 	return &rangeService{
 		storage: storage,
-		validatorsList: []func(foundingRange models.RangeModel) error{
-			validators.ValidateRange,
-			validators.ValidatePercentRange,
-		},
 	}
 }
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+// Weights of the criteria in the score of the recommendation
+const (
+	weightFunding    = 0.25
+	weightCoFunding  = 0.15
+	weightTRL        = 0.2
+	weightSubjects   = 0.25
+	weightCompetitor = 0.15
+)
+
+type recommendationService struct {
+	events      services.EventService
+	competitors services.CompetitorService
+	subjects    services.SubjectService
+	currency    services.CurrencyService
+}
+
+func (svc recommendationService) Recommend(ctx context.Context, profile models.Profile, limit int) ([]models.Recommendation, error) {
+	events, err := svc.events.GetAll(ctx)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("internal error")
+	}
+
+	competitors, e := svc.competitors.GetAll(ctx)
+	if e != nil {
+		log.Println(e.LongErr())
+		return nil, errors.New("internal error")
+	}
+	competitorsByID := make(map[uuid.UUID]models.Competitor, len(competitors))
+	for _, c := range competitors {
+		competitorsByID[c.ID] = c
+	}
+
+	ids := make([]uuid.UUID, len(events))
+	for i, v := range events {
+		ids[i] = v.ID
+	}
+
+	eventSubjects, err := svc.subjects.GetAllForEvents(ctx, ids)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("internal error")
+	}
+
+	catalogue, err := svc.subjects.GetAllExisting(ctx)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("internal error")
+	}
+	parents := make(map[uuid.UUID]uuid.UUID, len(catalogue))
+	for _, s := range catalogue {
+		parents[s.ID] = s.Parent
+	}
+
+	now := time.Now()
+
+	result := make([]models.Recommendation, 0)
+	for _, event := range events {
+		// ineligible applicants can't apply, so these events are not recommended at all
+		if !checkEvent(event, competitorsByID, profile.Applicant, now).Eligible {
+			continue
+		}
+
+		components := []models.ScoreComponent{
+			fundingScore(services.ReferenceRange(svc.currency, event.FoundingRange), profile.NeededFunding),
+			coFundingScore(event.CoFoundingRange, profile.CoFundingCapacity),
+			trlScore(event.TRL, profile.TRL),
+			subjectsScore(eventSubjects[event.ID], profile.Subjects, parents),
+			competitorScore(event, competitorsByID, profile.Applicant),
+		}
+
+		result = append(result, models.Recommendation{
+			EventID:     event.ID,
+			Title:       event.Title,
+			Score:       weightedScore(components),
+			Explanation: components,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// weightedScore - a weighted mean of the components, the components with zero weight are not used
+func weightedScore(components []models.ScoreComponent) float64 {
+	var sum, weights float64
+	for _, c := range components {
+		sum += c.Weight * c.Score
+		weights += c.Weight
+	}
+	if weights == 0 {
+		return 0
+	}
+	return sum / weights
+}
+
+// fundingScore - r is converted to the reference currency, the needed funding is in its major units
+func fundingScore(r models.RangeModel, needed int) models.ScoreComponent {
+	c := models.ScoreComponent{Criterion: services.CriterionFunding, Weight: weightFunding}
+
+	low, high, need := r.LowMoney().Major(), r.HighMoney().Major(), int64(needed)
+	switch {
+	case needed == 0:
+		c.Weight = 0
+		c.Reason = "needed funding is not specified"
+	case r.Currency == "":
+		c.Weight = 0
+		c.Reason = "there is no rate of the currency of the founding range"
+	case low == 0 && high == 0:
+		c.Weight = 0
+		c.Reason = "the event has no founding range"
+	case need > high:
+		c.Score = float64(high) / float64(need)
+		c.Reason = fmt.Sprintf("the event funds at most %d %s of needed %d", high, r.Currency, need)
+	case need < low:
+		c.Score = float64(need) / float64(low)
+		c.Reason = fmt.Sprintf("needed %d %s is below the minimum %d of the event", need, r.Currency, low)
+	default:
+		c.Score = 1
+		c.Reason = fmt.Sprintf("needed %d %s is within the founding range %d-%d", need, r.Currency, low, high)
+	}
+	return c
+}
+
+func coFundingScore(r models.RangeModel, capacity int) models.ScoreComponent {
+	c := models.ScoreComponent{Criterion: services.CriterionCoFunding, Weight: weightCoFunding}
+
+	switch {
+	case r.Low == 0:
+		c.Score = 1
+		c.Reason = "the event does not require co-funding"
+	case int64(capacity) >= r.Low:
+		c.Score = 1
+		c.Reason = fmt.Sprintf("co-funding capacity %d%% covers required %d%%", capacity, r.Low)
+	default:
+		c.Score = float64(capacity) / float64(r.Low)
+		c.Reason = fmt.Sprintf("co-funding capacity %d%% is below required %d%%", capacity, r.Low)
+	}
+	return c
+}
+
+// trlScore - every level of difference between TRLs decreases the score by a quarter
+func trlScore(eventTRL, projectTRL int) models.ScoreComponent {
+	c := models.ScoreComponent{Criterion: services.CriterionTRL, Weight: weightTRL}
+
+	diff := projectTRL - eventTRL
+	switch {
+	case diff == 0:
+		c.Reason = fmt.Sprintf("project TRL %d equals TRL of the event", projectTRL)
+	case diff < 0:
+		diff = -diff
+		c.Reason = fmt.Sprintf("project TRL %d is lower than TRL %d of the event", projectTRL, eventTRL)
+	default:
+		c.Reason = fmt.Sprintf("project TRL %d is higher than TRL %d of the event", projectTRL, eventTRL)
+	}
+
+	c.Score = 1 - 0.25*float64(diff)
+	if c.Score < 0 {
+		c.Score = 0
+	}
+	return c
+}
+
+// subjectsScore - a part of event's subjects, that are subjects of interest or their descendants
+func subjectsScore(eventSubjects []models.Subject, interests []uuid.UUID, parents map[uuid.UUID]uuid.UUID) models.ScoreComponent {
+	c := models.ScoreComponent{Criterion: services.CriterionSubjects, Weight: weightSubjects}
+
+	if len(interests) == 0 {
+		c.Weight = 0
+		c.Reason = "subjects of interest are not specified"
+		return c
+	}
+	if len(eventSubjects) == 0 {
+		c.Reason = "the event has no subjects"
+		return c
+	}
+
+	isInterest := make(map[uuid.UUID]bool, len(interests))
+	for _, v := range interests {
+		isInterest[v] = true
+	}
+
+	matched := make([]string, 0)
+	other := make([]string, 0)
+	for _, s := range eventSubjects {
+		found := false
+		for current := s.ID; current != uuid.Nil; current = parents[current] {
+			if isInterest[current] {
+				found = true
+				break
+			}
+		}
+		if found {
+			matched = append(matched, s.Name)
+		} else {
+			other = append(other, s.Name)
+		}
+	}
+
+	c.Score = float64(len(matched)) / float64(len(eventSubjects))
+	if len(matched) == 0 {
+		c.Reason = "no subjects of interest among: " + strings.Join(other, ", ")
+	} else {
+		c.Reason = "matched subjects: " + strings.Join(matched, ", ")
+	}
+	return c
+}
+
+// competitorScore - the events aimed at the applicant are preferred to the open ones,
+// the score grows with the number of criteria of the best matched competitor from 0.5 for an open event to 1
+func competitorScore(event models.Event, competitors map[uuid.UUID]models.Competitor, applicant models.ApplicantProfile) models.ScoreComponent {
+	c := models.ScoreComponent{Criterion: services.CriterionCompetitor, Weight: weightCompetitor, Score: 0.5}
+
+	var best models.Competitor
+	criteria := -1
+	for _, id := range event.Competitors {
+		competitor, ok := competitors[id]
+		if !ok || len(services.CheckCompetitor(competitor, applicant)) != 0 {
+			continue
+		}
+		if n := competitorCriteria(competitor); n > criteria {
+			best, criteria = competitor, n
+		}
+	}
+
+	if criteria <= 0 {
+		c.Reason = "the event is open to all applicants"
+		return c
+	}
+	c.Score += 0.5 * float64(criteria) / competitorCriteriaCount
+	c.Reason = fmt.Sprintf("the event is aimed at %s, %d of %d criteria are matched", best.Name, criteria, competitorCriteriaCount)
+	return c
+}
+
+// competitorCriteriaCount - the number of criteria checked by services.CheckCompetitor
+const competitorCriteriaCount = 6
+
+// competitorCriteria - the number of criteria, that are set for the competitor
+func competitorCriteria(c models.Competitor) int {
+	n := 0
+	for _, set := range []bool{
+		c.Category != "",
+		c.MinAge != 0,
+		c.MaxAge != 0,
+		c.MinDegree != "",
+		len(c.Regions) != 0,
+		len(c.OrganizationTypes) != 0,
+	} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+func NewRecommendationService(events services.EventService,
+	competitors services.CompetitorService,
+	subjects services.SubjectService,
+	currency services.CurrencyService) services.RecommendationService {
+	return &recommendationService{
+		events:      events,
+		competitors: competitors,
+		subjects:    subjects,
+		currency:    currency,
+	}
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/config"
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/pkg/errors"
+)
+
+type fakeEvents struct {
+	services.EventService
+	events []models.Event
+}
+
+func (f fakeEvents) GetAll(context.Context) ([]models.Event, error) {
+	return f.events, nil
+}
+
+func (f fakeEvents) GetByID(_ context.Context, id uuid.UUID) (models.Event, error) {
+	for _, e := range f.events {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return models.Event{}, adapters.ErrNotFound
+}
+
+type fakeCompetitors struct {
+	services.CompetitorService
+	competitors []models.Competitor
+}
+
+func (f fakeCompetitors) GetAll(context.Context) ([]models.Competitor, errors.Error) {
+	return f.competitors, nil
+}
+
+type fakeSubjects struct {
+	services.SubjectService
+	subjects map[uuid.UUID][]models.Subject
+}
+
+func (f fakeSubjects) GetAllForEvents(context.Context, []uuid.UUID) (map[uuid.UUID][]models.Subject, error) {
+	return f.subjects, nil
+}
+
+func (f fakeSubjects) GetAllExisting(context.Context) ([]models.Subject, error) {
+	result := make([]models.Subject, 0)
+	for _, v := range f.subjects {
+		result = append(result, v...)
+	}
+	return result, nil
+}
+
+func TestCompetitorScore(t *testing.T) {
+	open := models.Competitor{ID: uuid.New(), Name: "Everyone"}
+	individuals := models.Competitor{ID: uuid.New(), Name: "Individuals", Category: models.CompetitorCategoryIndividual}
+	young := models.Competitor{ID: uuid.New(), Name: "Young scientists", Category: models.CompetitorCategoryIndividual, MaxAge: 35, MinDegree: models.DegreeMaster}
+	teams := models.Competitor{ID: uuid.New(), Name: "Teams", Category: models.CompetitorCategoryTeam, MaxAge: 35}
+
+	competitors := map[uuid.UUID]models.Competitor{open.ID: open, individuals.ID: individuals, young.ID: young, teams.ID: teams}
+	applicant := models.ApplicantProfile{Category: models.CompetitorCategoryIndividual, Age: 30, Degree: models.DegreeCandidate}
+
+	tests := []struct {
+		name        string
+		competitors []uuid.UUID
+		score       float64
+		reason      string
+	}{
+		{"no competitors", nil, 0.5, "the event is open to all applicants"},
+		{"competitor without criteria", []uuid.UUID{open.ID}, 0.5, "the event is open to all applicants"},
+		{"deleted competitor", []uuid.UUID{uuid.New()}, 0.5, "the event is open to all applicants"},
+		{"one criterion", []uuid.UUID{individuals.ID}, 0.5 + 0.5/6, "the event is aimed at Individuals, 1 of 6 criteria are matched"},
+		{"best matched competitor", []uuid.UUID{individuals.ID, young.ID}, 0.75, "the event is aimed at Young scientists, 3 of 6 criteria are matched"},
+		{"unmatched competitors are skipped", []uuid.UUID{teams.ID, individuals.ID}, 0.5 + 0.5/6, "the event is aimed at Individuals, 1 of 6 criteria are matched"},
+	}
+
+	for _, tt := range tests {
+		got := competitorScore(models.Event{Competitors: tt.competitors}, competitors, applicant)
+		if math.Abs(got.Score-tt.score) > 1e-9 || got.Reason != tt.reason || got.Weight != weightCompetitor {
+			t.Errorf("%s: competitorScore = %+v, want score %v and reason %q", tt.name, got, tt.score, tt.reason)
+		}
+	}
+}
+
+func TestWeightedScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []models.ScoreComponent
+		score      float64
+	}{
+		{"no components", nil, 0},
+		{"only zero weights", []models.ScoreComponent{{Weight: 0, Score: 1}}, 0},
+		{"equal weights", []models.ScoreComponent{{Weight: 0.2, Score: 1}, {Weight: 0.2, Score: 0.5}}, 0.75},
+		{"weights", []models.ScoreComponent{{Weight: 0.25, Score: 1}, {Weight: 0.75, Score: 0}}, 0.25},
+		{"zero weight is not used", []models.ScoreComponent{{Weight: 0.2, Score: 0.5}, {Weight: 0, Score: 1}}, 0.5},
+	}
+
+	for _, tt := range tests {
+		if got := weightedScore(tt.components); math.Abs(got-tt.score) > 1e-9 {
+			t.Errorf("%s: weightedScore = %v, want %v", tt.name, got, tt.score)
+		}
+	}
+}
+
+func TestRecommendOrdering(t *testing.T) {
+	young := models.Competitor{ID: uuid.New(), Name: "Young scientists", Category: models.CompetitorCategoryIndividual, MaxAge: 35}
+	teams := models.Competitor{ID: uuid.New(), Name: "Teams", Category: models.CompetitorCategoryTeam}
+	algebra := models.Subject{ID: uuid.New(), Name: "Алгебра"}
+	biology := models.Subject{ID: uuid.New(), Name: "Биология"}
+
+	var (
+		// aimed - matches the TRL, the subjects and is aimed at the applicant
+		aimed = models.Event{ID: uuid.New(), Title: "aimed", TRL: 4, Competitors: []uuid.UUID{young.ID}}
+		// open - the same as aimed, but is open to all applicants
+		open = models.Event{ID: uuid.New(), Title: "open", TRL: 4}
+		// far - is open, but has another TRL and other subjects
+		far = models.Event{ID: uuid.New(), Title: "far", TRL: 7}
+		// closed - the applicant does not match its competitors
+		closed = models.Event{ID: uuid.New(), Title: "closed", TRL: 4, Competitors: []uuid.UUID{teams.ID}}
+	)
+
+	currency, err := NewCurrencyService(config.CurrencyConfig{Reference: "RUB"})
+	if err != nil {
+		t.Fatalf("NewCurrencyService: %v", err)
+	}
+	svc := NewRecommendationService(
+		fakeEvents{events: []models.Event{far, closed, open, aimed}},
+		fakeCompetitors{competitors: []models.Competitor{young, teams}},
+		fakeSubjects{subjects: map[uuid.UUID][]models.Subject{
+			aimed.ID:  {algebra},
+			open.ID:   {algebra},
+			far.ID:    {biology},
+			closed.ID: {algebra},
+		}},
+		currency,
+	)
+
+	profile := models.Profile{
+		Applicant: models.ApplicantProfile{Category: models.CompetitorCategoryIndividual, Age: 30},
+		TRL:       4,
+		Subjects:  []uuid.UUID{algebra.ID},
+	}
+
+	recommendations, err := svc.Recommend(context.Background(), profile, 0)
+	if err != nil {
+		t.Fatalf("Recommend: %v", err)
+	}
+
+	titles := make([]string, len(recommendations))
+	for i, v := range recommendations {
+		titles[i] = v.Title
+	}
+	if want := []string{"aimed", "open", "far"}; !reflect.DeepEqual(titles, want) {
+		t.Errorf("recommendations = %q, want %q", titles, want)
+	}
+
+	limited, err := svc.Recommend(context.Background(), profile, 1)
+	if err != nil {
+		t.Fatalf("Recommend: %v", err)
+	}
+	if len(limited) != 1 || limited[0].Title != "aimed" {
+		t.Errorf("recommendations with the limit = %+v, want only aimed", limited)
+	}
+}
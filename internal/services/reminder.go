@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/config"
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+const day = 24 * time.Hour
+
+// reminderData - values available in the templates
+type reminderData struct {
+	Title    string
+	Deadline time.Time
+	DaysLeft int
+	Site     string
+}
+
+type reminderService struct {
+	preferences adapters.NotificationPreferencesStorage
+	deliveries  adapters.ReminderDeliveryStorage
+	favourites  adapters.FavouriteStorage
+	events      services.EventService
+	sender      adapters.MailSender
+
+	defaultDaysBefore []int
+	templates         mailTemplates
+}
+
+func (svc reminderService) GetPreferences(ctx context.Context, user uuid.UUID) (models.NotificationPreferences, error) {
+	p, err := svc.preferences.Get(ctx, user)
+	if err != nil {
+		if errors.Is(err, adapters.ErrNotFound) {
+			return svc.defaultPreferences(user), nil
+		}
+		log.Println(err)
+		return models.NotificationPreferences{}, errors.New("internal error")
+	}
+	return p, nil
+}
+
+// defaultPreferences - nothing is sent until the user gives an email and enables the reminders
+func (svc reminderService) defaultPreferences(user uuid.UUID) models.NotificationPreferences {
+	return models.NotificationPreferences{
+		User:       user,
+		Language:   models.LanguageRussian,
+		DaysBefore: svc.defaultDaysBefore,
+		Enabled:    false,
+	}
+}
+
+func (svc reminderService) SavePreferences(ctx context.Context, p models.NotificationPreferences) error {
+	if err := services.ValidateNotificationPreferences(p); err != nil {
+		return err
+	}
+
+	p.DaysBefore = uniqueSorted(p.DaysBefore)
+	if err := svc.preferences.Save(ctx, p); err != nil {
+		log.Println(err)
+		return errors.New("failed to save the preferences")
+	}
+	return nil
+}
+
+func (svc reminderService) SendDue(ctx context.Context, now time.Time) (int, error) {
+	events, err := svc.events.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	upcoming := make(map[uuid.UUID]models.Event)
+	ids := make([]uuid.UUID, 0)
+	for _, e := range events {
+		if e.SubmissionDeadline.After(now) {
+			upcoming[e.ID] = e
+			ids = append(ids, e.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	favourites, err := svc.favourites.GetByEvents(ctx, ids)
+	if err != nil {
+		log.Println(err)
+		return 0, errors.New("internal error")
+	}
+
+	users := make([]uuid.UUID, 0, len(favourites))
+	for _, f := range favourites {
+		users = append(users, f.User)
+	}
+	preferences, err := svc.preferences.GetByUsers(ctx, users)
+	if err != nil {
+		log.Println(err)
+		return 0, errors.New("internal error")
+	}
+
+	sent := 0
+	for _, f := range favourites {
+		p, ok := preferences[f.User]
+		if !ok || !p.Enabled || p.Email == "" {
+			continue
+		}
+
+		event := upcoming[f.Event]
+		daysBefore, due := svc.dueReminder(p, event.SubmissionDeadline.Sub(now))
+		if !due {
+			continue
+		}
+
+		ok, err := svc.remind(ctx, p, event, daysBefore, now)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// dueReminder - returns the smallest of reminder days, the remaining time fits in,
+// so the reminders missed while the service was down are not sent all at once
+func (svc reminderService) dueReminder(p models.NotificationPreferences, remaining time.Duration) (int, bool) {
+	days := p.DaysBefore
+	if len(days) == 0 {
+		days = svc.defaultDaysBefore
+	}
+
+	result, found := 0, false
+	for _, d := range days {
+		if remaining <= time.Duration(d)*day && (!found || d < result) {
+			result, found = d, true
+		}
+	}
+	return result, found
+}
+
+// remind - records the delivery before sending, so the reminder is not sent twice,
+// the record is removed if sending has failed to retry on the next run.
+func (svc reminderService) remind(ctx context.Context, p models.NotificationPreferences, event models.Event, daysBefore int, now time.Time) (bool, error) {
+	delivery := models.ReminderDelivery{User: p.User, Event: event.ID, DaysBefore: daysBefore, SentAt: now}
+
+	added, err := svc.deliveries.Add(ctx, delivery)
+	if err != nil || !added {
+		return false, err
+	}
+
+	mail, err := svc.render(p, event, now)
+	if err == nil {
+		err = svc.sender.Send(ctx, mail)
+	}
+	if err != nil {
+		if err := svc.deliveries.Remove(ctx, p.User, event.ID, daysBefore); err != nil {
+			log.Println(err)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (svc reminderService) render(p models.NotificationPreferences, event models.Event, now time.Time) (adapters.Mail, error) {
+	data := reminderData{
+		Title:    event.Title,
+		Deadline: event.SubmissionDeadline,
+		DaysLeft: int(math.Ceil(float64(event.SubmissionDeadline.Sub(now)) / float64(day))),
+		Site:     event.Site,
+	}
+	return svc.templates.render(p.Language, p.Email, data)
+}
+
+func uniqueSorted(values []int) []int {
+	seen := make(map[int]bool, len(values))
+	result := make([]int, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+func NewReminderService(
+	preferences adapters.NotificationPreferencesStorage,
+	deliveries adapters.ReminderDeliveryStorage,
+	favourites adapters.FavouriteStorage,
+	events services.EventService,
+	sender adapters.MailSender,
+	cfg config.ReminderConfig,
+) services.ReminderService {
+	return &reminderService{
+		preferences:       preferences,
+		deliveries:        deliveries,
+		favourites:        favourites,
+		events:            events,
+		sender:            sender,
+		defaultDaysBefore: uniqueSorted(cfg.DaysBefore),
+		templates:         parseMailTemplates("reminder"),
+	}
+}
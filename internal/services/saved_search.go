@@ -0,0 +1,309 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
+)
+
+// digestEvent, digestData - values available in the digest templates, the title is the one at the time of matching
+type digestEvent struct {
+	Title    string
+	Reason   string
+	Deadline time.Time
+	Site     string
+}
+
+type digestData struct {
+	Search string
+	Events []digestEvent
+}
+
+type savedSearchService struct {
+	storage     adapters.SavedSearchStorage
+	alerts      adapters.SearchAlertStorage
+	preferences adapters.NotificationPreferencesStorage
+	events      adapters.EventStorage
+	subjects    services.SubjectService
+	currency    services.CurrencyService
+	sender      adapters.MailSender
+
+	templates mailTemplates
+}
+
+func (svc savedSearchService) GetAll(ctx context.Context, user uuid.UUID) ([]models.SavedSearch, error) {
+	return svc.storage.GetByUser(ctx, user)
+}
+
+func (svc savedSearchService) GetByID(ctx context.Context, user, id uuid.UUID) (models.SavedSearch, error) {
+	search, err := svc.storage.GetByID(ctx, id)
+	if err != nil {
+		return models.SavedSearch{}, err
+	}
+	if search.User != user {
+		return models.SavedSearch{}, adapters.ErrNotFound
+	}
+	return search, nil
+}
+
+func (svc savedSearchService) validate(ctx context.Context, info services.SavedSearchInfo) error {
+	if err := info.Validate(); err != nil {
+		return err
+	}
+
+	violations := make(validators.Violations, 0)
+	for i, v := range info.Filter.Subjects {
+		if _, err := svc.subjects.GetByID(ctx, v); err != nil {
+			violations = append(violations, validators.Violation{Field: fmt.Sprintf("filter.subjects[%d]", i), Message: "does not exist"})
+		}
+	}
+
+	if len(violations) != 0 {
+		return violations
+	}
+	return nil
+}
+
+func (svc savedSearchService) Create(ctx context.Context, user uuid.UUID, info services.SavedSearchInfo) (models.SavedSearch, error) {
+	if err := svc.validate(ctx, info); err != nil {
+		return models.SavedSearch{}, err
+	}
+
+	search := models.SavedSearch{
+		ID:        uuid.New(),
+		User:      user,
+		Name:      info.Name,
+		Filter:    info.Filter,
+		Delivery:  info.Delivery,
+		Frequency: info.Frequency,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := svc.storage.Add(ctx, search); err != nil {
+		log.Println(err)
+		return models.SavedSearch{}, errors.New("failed to add")
+	}
+	return search, nil
+}
+
+func (svc savedSearchService) Update(ctx context.Context, user, id uuid.UUID, info services.SavedSearchInfo) (models.SavedSearch, error) {
+	search, err := svc.GetByID(ctx, user, id)
+	if err != nil {
+		return models.SavedSearch{}, err
+	}
+
+	if err := svc.validate(ctx, info); err != nil {
+		return models.SavedSearch{}, err
+	}
+
+	search.Name = info.Name
+	search.Filter = info.Filter
+	search.Delivery = info.Delivery
+	search.Frequency = info.Frequency
+
+	if err := svc.storage.Update(ctx, search); err != nil {
+		log.Println(err)
+		if errors.Is(err, adapters.ErrNotFound) {
+			return models.SavedSearch{}, err
+		}
+		return models.SavedSearch{}, errors.New("failed to update")
+	}
+	return search, nil
+}
+
+func (svc savedSearchService) Delete(ctx context.Context, user, id uuid.UUID) error {
+	if _, err := svc.GetByID(ctx, user, id); err != nil {
+		return err
+	}
+
+	if err := svc.storage.Delete(ctx, id); err != nil {
+		log.Println(err)
+		return errors.New("failed to delete")
+	}
+	return nil
+}
+
+// Changed - stores alerts for all searches matching the created or updated event, the search is alerted
+// about the event once, so an updated event is reported only if it had not matched the search before.
+func (svc savedSearchService) Changed(ctx context.Context, change models.Change) {
+	if change.Type != models.ChangeEventCreated && change.Type != models.ChangeEventUpdated {
+		return
+	}
+	event, ok := change.Object.(models.Event)
+	if !ok {
+		return
+	}
+
+	if err := svc.evaluate(ctx, event, change.Type == models.ChangeEventCreated); err != nil {
+		log.Println("failed to evaluate saved searches for event ", event.ID.String(), ": ", err)
+	}
+}
+
+func (svc savedSearchService) evaluate(ctx context.Context, event models.Event, created bool) error {
+	searches, err := svc.storage.GetAll(ctx)
+	if err != nil || len(searches) == 0 {
+		return err
+	}
+
+	facts := services.EventFacts{Event: event, ReferenceFunding: services.ReferenceRange(svc.currency, event.FoundingRange)}
+	if facts.Subjects, err = svc.subjects.GetAllForEvent(ctx, event.ID); err != nil {
+		return err
+	}
+
+	parents, err := subjectParents(ctx, svc.subjects)
+	if err != nil {
+		return err
+	}
+
+	reason := models.AlertReasonUpdated
+	if created {
+		reason = models.AlertReasonCreated
+	}
+
+	now := time.Now().UTC()
+	alerts := make([]models.SearchAlert, 0)
+	for _, search := range searches {
+		if services.MatchEvent(search.Filter, facts, parents) {
+			alerts = append(alerts, models.SearchAlert{
+				ID:        uuid.New(),
+				Search:    search.ID,
+				Event:     event.ID,
+				Title:     event.Title,
+				Reason:    reason,
+				CreatedAt: now,
+			})
+		}
+	}
+	return svc.alerts.Add(ctx, alerts)
+}
+
+func (svc savedSearchService) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+	searches, err := svc.storage.GetAll(ctx)
+	if err != nil {
+		log.Println(err)
+		return 0, errors.New("internal error")
+	}
+
+	delivered := 0
+	for _, search := range searches {
+		if !search.LastDeliveredAt.IsZero() && now.Sub(search.LastDeliveredAt) < services.AlertPeriod(search.Frequency) {
+			continue
+		}
+
+		n, err := svc.deliver(ctx, search, now)
+		if err != nil {
+			log.Println("failed to deliver alerts of search ", search.ID.String(), ": ", err)
+			continue
+		}
+		delivered += n
+	}
+	return delivered, nil
+}
+
+// deliver - sends the pending alerts of the search in one digest or makes them visible in-app
+func (svc savedSearchService) deliver(ctx context.Context, search models.SavedSearch, now time.Time) (int, error) {
+	pending, err := svc.alerts.GetPending(ctx, search.ID)
+	if err != nil || len(pending) == 0 {
+		return 0, err
+	}
+
+	if search.Delivery == models.AlertDeliveryEmail {
+		if err := svc.sendDigest(ctx, search, pending); err != nil {
+			return 0, err
+		}
+	}
+
+	ids := make([]uuid.UUID, len(pending))
+	for i, v := range pending {
+		ids[i] = v.ID
+	}
+	if err := svc.alerts.MarkDelivered(ctx, ids, now); err != nil {
+		return 0, err
+	}
+	if err := svc.storage.SetDelivered(ctx, search.ID, now); err != nil {
+		return 0, err
+	}
+	return len(pending), nil
+}
+
+// sendDigest - the digest is sent to the email and in the language of the user's notification preferences
+func (svc savedSearchService) sendDigest(ctx context.Context, search models.SavedSearch, alerts []models.SearchAlert) error {
+	preferences, err := svc.preferences.Get(ctx, search.User)
+	if err != nil {
+		return err
+	}
+	if preferences.Email == "" {
+		return errors.New("the user has no email")
+	}
+
+	ids := make([]uuid.UUID, len(alerts))
+	for i, a := range alerts {
+		ids[i] = a.Event
+	}
+	events, err := svc.events.GetByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byID := make(map[uuid.UUID]models.Event, len(events))
+	for _, e := range events {
+		byID[e.ID] = e
+	}
+
+	data := digestData{Search: search.Name, Events: make([]digestEvent, len(alerts))}
+	for i, a := range alerts {
+		e := byID[a.Event]
+		data.Events[i] = digestEvent{Title: a.Title, Reason: a.Reason, Deadline: e.SubmissionDeadline, Site: e.Site}
+	}
+
+	mail, err := svc.templates.render(preferences.Language, preferences.Email, data)
+	if err != nil {
+		return err
+	}
+	return svc.sender.Send(ctx, mail)
+}
+
+func (svc savedSearchService) GetAlerts(ctx context.Context, user uuid.UUID, unreadOnly bool) ([]models.SearchAlert, error) {
+	alerts, err := svc.alerts.GetDelivered(ctx, user, unreadOnly)
+	if err != nil {
+		log.Println(err)
+		return nil, errors.New("internal error")
+	}
+	return alerts, nil
+}
+
+func (svc savedSearchService) MarkAlertsRead(ctx context.Context, user uuid.UUID, ids []uuid.UUID) error {
+	if err := svc.alerts.MarkRead(ctx, user, ids, time.Now().UTC()); err != nil {
+		log.Println(err)
+		return errors.New("failed to mark alerts")
+	}
+	return nil
+}
+
+func NewSavedSearchService(
+	storage adapters.SavedSearchStorage,
+	alerts adapters.SearchAlertStorage,
+	preferences adapters.NotificationPreferencesStorage,
+	events adapters.EventStorage,
+	subjects services.SubjectService,
+	currency services.CurrencyService,
+	sender adapters.MailSender,
+) services.SavedSearchService {
+	return &savedSearchService{
+		storage:     storage,
+		alerts:      alerts,
+		preferences: preferences,
+		events:      events,
+		subjects:    subjects,
+		currency:    currency,
+		sender:      sender,
+		templates:   parseMailTemplates("search_digest"),
+	}
+}
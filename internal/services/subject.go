@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"log"
+	"strings"
 
 	"github.com/google/uuid"
 
 	"github.com/indigowar/map-of-events/internal/domain/adapters"
 	"github.com/indigowar/map-of-events/internal/domain/models"
 	"github.com/indigowar/map-of-events/internal/domain/services"
+	"github.com/indigowar/map-of-events/internal/domain/validators"
 )
 
 type subjectService struct {
@@ -20,46 +22,166 @@ func (svc subjectService) GetAllExisting(ctx context.Context) ([]models.Subject,
 	return svc.storage.GetAll(ctx)
 }
 
+func (svc subjectService) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Subject, error) {
+	return svc.storage.GetByIDs(ctx, ids)
+}
+
 func (svc subjectService) GetAllForEvent(ctx context.Context, eventId uuid.UUID) ([]models.Subject, error) {
-	ids, err := svc.storage.GetByEvent(ctx, eventId)
+	subjects, err := svc.GetAllForEvents(ctx, []uuid.UUID{eventId})
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
-	result := make([]models.Subject, len(ids))
 
-	for i, v := range ids {
-		result[i], _ = svc.storage.GetByID(ctx, v)
+	result := subjects[eventId]
+	if result == nil {
+		result = make([]models.Subject, 0)
 	}
-
 	return result, nil
 }
 
+func (svc subjectService) GetAllForEvents(ctx context.Context, eventIds []uuid.UUID) (map[uuid.UUID][]models.Subject, error) {
+	subjects, err := svc.storage.GetByEvents(ctx, eventIds)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return subjects, nil
+}
+
 func (svc subjectService) GetByID(ctx context.Context, id uuid.UUID) (models.Subject, error) {
 	return svc.storage.GetByID(ctx, id)
 }
 
-func (svc subjectService) Create(ctx context.Context, eventId uuid.UUID, subject string) (models.Subject, error) {
-	s := models.Subject{
-		ID:      uuid.New(),
-		Name:    subject,
-		EventID: eventId,
+// validateParent - the parent should exist and should not be the subject itself or one of its descendants
+func (svc subjectService) validateParent(ctx context.Context, id uuid.UUID, parent uuid.UUID) error {
+	if parent == uuid.Nil {
+		return nil
+	}
+
+	all, err := svc.storage.GetAll(ctx)
+	if err != nil {
+		log.Println(err)
+		return errors.New("internal error")
+	}
+
+	parents := make(map[uuid.UUID]uuid.UUID, len(all))
+	for _, s := range all {
+		parents[s.ID] = s.Parent
+	}
+
+	if _, ok := parents[parent]; !ok {
+		return validators.Violations{{Field: "parent", Message: "does not exist"}}
+	}
+
+	for current := parent; current != uuid.Nil; current = parents[current] {
+		if current == id {
+			return validators.Violations{{Field: "parent", Message: "can not be the subject itself or its descendant"}}
+		}
+	}
+	return nil
+}
+
+func (svc subjectService) Create(ctx context.Context, info services.SubjectInfo) (models.Subject, error) {
+	if err := info.Validate(); err != nil {
+		return models.Subject{}, err
+	}
+
+	subject := models.Subject{
+		ID:       uuid.New(),
+		Name:     strings.TrimSpace(info.Name),
+		Parent:   info.Parent,
+		Synonyms: info.Synonyms,
+		Codes:    info.Codes,
 	}
 
-	if err := svc.storage.Add(ctx, s); err != nil {
+	if err := svc.validateParent(ctx, subject.ID, subject.Parent); err != nil {
+		return models.Subject{}, err
+	}
+
+	if err := svc.storage.Add(ctx, subject); err != nil {
 		log.Println(err)
 		return models.Subject{}, errors.New("failed to add")
 	}
 
-	return s, nil
+	return subject, nil
 }
 
 func (svc subjectService) Delete(ctx context.Context, id uuid.UUID) error {
+	all, err := svc.storage.GetAll(ctx)
+	if err != nil {
+		log.Println(err)
+		return errors.New("internal error")
+	}
+
+	for _, s := range all {
+		if s.Parent == id {
+			return services.ErrSubjectHasChildren
+		}
+	}
+
 	return svc.storage.Delete(ctx, id)
 }
 
-func (svc subjectService) Update(ctx context.Context, subject models.Subject) error {
-	return svc.Update(ctx, subject)
+func (svc subjectService) Update(ctx context.Context, id uuid.UUID, info services.SubjectInfo) (models.Subject, error) {
+	if err := info.Validate(); err != nil {
+		return models.Subject{}, err
+	}
+
+	if _, err := svc.storage.GetByID(ctx, id); err != nil {
+		return models.Subject{}, err
+	}
+
+	if err := svc.validateParent(ctx, id, info.Parent); err != nil {
+		return models.Subject{}, err
+	}
+
+	subject := models.Subject{
+		ID:       id,
+		Name:     strings.TrimSpace(info.Name),
+		Parent:   info.Parent,
+		Synonyms: info.Synonyms,
+		Codes:    info.Codes,
+	}
+
+	if err := svc.storage.Update(ctx, subject); err != nil {
+		log.Println(err)
+		return models.Subject{}, err
+	}
+
+	return subject, nil
+}
+
+func (svc subjectService) Resolve(ctx context.Context, names []string) ([]models.Subject, error) {
+	result := make([]models.Subject, 0, len(names))
+	resolved := make(map[uuid.UUID]bool, len(names))
+
+	for _, name := range names {
+		subject, err := svc.storage.GetByName(ctx, name)
+		if errors.Is(err, adapters.ErrNotFound) {
+			subject, err = svc.Create(ctx, services.SubjectInfo{Name: name})
+		}
+		if err != nil {
+			log.Println("failed to resolve subject ", name, ": ", err)
+			return nil, err
+		}
+
+		// different names can be synonyms of the same subject
+		if resolved[subject.ID] {
+			continue
+		}
+		resolved[subject.ID] = true
+		result = append(result, subject)
+	}
+
+	return result, nil
+}
+
+func (svc subjectService) SetForEvent(ctx context.Context, eventId uuid.UUID, subjects []uuid.UUID) error {
+	return svc.storage.SetForEvent(ctx, eventId, subjects)
+}
+
+func (svc subjectService) Suggest(ctx context.Context, text string, limit int) ([]models.Suggestion, error) {
+	return svc.storage.Suggest(ctx, text, limit)
 }
 
 func NewSubjectService(storage adapters.SubjectStorage) services.SubjectService {
@@ -0,0 +1,352 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/config"
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+// Headers of the webhook requests, the signature is "sha256=" + hex of HMAC-SHA256
+// of "<timestamp>.<body>" with the webhook's secret
+const (
+	WebhookHeaderID        = "X-Webhook-Id"
+	WebhookHeaderEvent     = "X-Webhook-Event"
+	WebhookHeaderTimestamp = "X-Webhook-Timestamp"
+	WebhookHeaderSignature = "X-Webhook-Signature"
+)
+
+const (
+	// webhookBatch - the maximal number of deliveries sent in one run
+	webhookBatch = 100
+	// webhookSecretBytes - length of a generated secret before hex encoding
+	webhookSecretBytes = 32
+)
+
+// webhookPayload - body of the webhook request
+type webhookPayload struct {
+	ID         uuid.UUID   `json:"id"`
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurredAt"`
+	Data       interface{} `json:"data"`
+}
+
+type webhookEventData struct {
+	ID                 uuid.UUID   `json:"id"`
+	Title              string      `json:"title"`
+	Organizer          uuid.UUID   `json:"organizer"`
+	FoundingType       string      `json:"foundingType"`
+	SubmissionDeadline time.Time   `json:"submissionDeadline"`
+	Site               string      `json:"site"`
+	Document           string      `json:"document"`
+	TRL                int         `json:"trl"`
+	Competitors        []uuid.UUID `json:"competitors"`
+	Version            int         `json:"version"`
+}
+
+type webhookOrganizerData struct {
+	ID      uuid.UUID `json:"id"`
+	Name    string    `json:"name"`
+	Logo    string    `json:"logo"`
+	Level   uuid.UUID `json:"level"`
+	Version int       `json:"version"`
+}
+
+// webhookData - the public representation of the changed object
+func webhookData(object interface{}) interface{} {
+	switch o := object.(type) {
+	case models.Event:
+		competitors := o.Competitors
+		if competitors == nil {
+			competitors = make([]uuid.UUID, 0)
+		}
+		return webhookEventData{
+			ID:                 o.ID,
+			Title:              o.Title,
+			Organizer:          o.Organizer,
+			FoundingType:       o.FoundingType,
+			SubmissionDeadline: o.SubmissionDeadline,
+			Site:               o.Site,
+			Document:           o.Document,
+			TRL:                o.TRL,
+			Competitors:        competitors,
+			Version:            o.Version,
+		}
+	case models.Organizer:
+		return webhookOrganizerData{ID: o.ID, Name: o.Name, Logo: o.Logo, Level: o.Level, Version: o.Version}
+	}
+	return object
+}
+
+// SignWebhookPayload - returns the value of X-Webhook-Signature header, receivers should compute it
+// the same way and compare in constant time
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type webhookService struct {
+	storage    adapters.WebhookStorage
+	deliveries adapters.WebhookDeliveryStorage
+	sender     adapters.WebhookSender
+	config     config.WebhookConfig
+}
+
+func (svc webhookService) GetAll(ctx context.Context) ([]models.Webhook, error) {
+	return svc.storage.GetAll(ctx)
+}
+
+func (svc webhookService) GetByID(ctx context.Context, id uuid.UUID) (models.Webhook, error) {
+	return svc.storage.GetByID(ctx, id)
+}
+
+func (svc webhookService) Create(ctx context.Context, info services.WebhookInfo) (models.Webhook, error) {
+	if err := info.Validate(); err != nil {
+		return models.Webhook{}, err
+	}
+
+	secret := info.Secret
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			log.Println(err)
+			return models.Webhook{}, errors.New("failed to generate a secret")
+		}
+		secret = generated
+	}
+
+	webhook := models.Webhook{
+		ID:        uuid.New(),
+		URL:       info.URL,
+		Secret:    secret,
+		Types:     info.Types,
+		Active:    info.Active,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := svc.storage.Add(ctx, webhook); err != nil {
+		log.Println(err)
+		return models.Webhook{}, errors.New("failed to add")
+	}
+	return webhook, nil
+}
+
+func (svc webhookService) Update(ctx context.Context, id uuid.UUID, info services.WebhookInfo) (models.Webhook, error) {
+	if err := info.Validate(); err != nil {
+		return models.Webhook{}, err
+	}
+
+	webhook, err := svc.storage.GetByID(ctx, id)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+
+	webhook.URL = info.URL
+	webhook.Types = info.Types
+	webhook.Active = info.Active
+	if info.Secret != "" {
+		webhook.Secret = info.Secret
+	}
+
+	if err := svc.storage.Update(ctx, webhook); err != nil {
+		log.Println(err)
+		if errors.Is(err, adapters.ErrNotFound) {
+			return models.Webhook{}, err
+		}
+		return models.Webhook{}, errors.New("failed to update")
+	}
+	return webhook, nil
+}
+
+func (svc webhookService) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := svc.storage.Delete(ctx, id); err != nil {
+		log.Println(err)
+		return errors.New("failed to delete")
+	}
+	return nil
+}
+
+func (svc webhookService) GetDeliveries(ctx context.Context, webhook uuid.UUID, status string, limit int) ([]models.WebhookDelivery, error) {
+	if _, err := svc.storage.GetByID(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return svc.deliveries.GetByWebhook(ctx, webhook, status, limit)
+}
+
+func (svc webhookService) GetDeadLetters(ctx context.Context, limit int) ([]models.WebhookDelivery, error) {
+	return svc.deliveries.GetByStatus(ctx, models.WebhookDeliveryDead, limit)
+}
+
+func (svc webhookService) Retry(ctx context.Context, id uuid.UUID) error {
+	delivery, err := svc.deliveries.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if delivery.Status != models.WebhookDeliveryDead {
+		return services.ErrDeliveryIsNotDead
+	}
+	return svc.deliveries.Requeue(ctx, id, time.Now().UTC())
+}
+
+// Changed - queues a delivery for every active webhook subscribed to the change,
+// the deliveries are sent by DeliverDue, so a slow receiver does not slow down the change
+func (svc webhookService) Changed(ctx context.Context, change models.Change) {
+	webhooks, err := svc.storage.GetByType(ctx, change.Type)
+	if err != nil {
+		log.Println("failed to find webhooks for ", change.Type, ": ", err)
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	deliveries := make([]models.WebhookDelivery, 0, len(webhooks))
+	for _, w := range webhooks {
+		// the id is derived from the change, so a change told again does not make another delivery
+		payload := webhookPayload{ID: uuid.NewSHA1(change.ID, w.ID[:]), Type: change.Type, OccurredAt: change.OccurredAt, Data: webhookData(change.Object)}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			log.Println("failed to build a webhook payload: ", err)
+			return
+		}
+
+		deliveries = append(deliveries, models.WebhookDelivery{
+			ID:            payload.ID,
+			Webhook:       w.ID,
+			Type:          change.Type,
+			Payload:       body,
+			Status:        models.WebhookDeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+
+	if err := svc.deliveries.Add(ctx, deliveries); err != nil {
+		log.Println("failed to queue webhooks for ", change.Type, ": ", err)
+	}
+}
+
+func (svc webhookService) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+	due, err := svc.deliveries.GetDue(ctx, now, webhookBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	webhooks := make(map[uuid.UUID]models.Webhook)
+	delivered := 0
+	for _, d := range due {
+		w, ok := webhooks[d.Webhook]
+		if !ok {
+			if w, err = svc.storage.GetByID(ctx, d.Webhook); err != nil {
+				log.Println(err)
+				continue
+			}
+			webhooks[d.Webhook] = w
+		}
+
+		d, attempt := svc.attempt(ctx, w, d)
+		if err := svc.deliveries.Record(ctx, d, attempt); err != nil {
+			log.Println(err)
+			continue
+		}
+		if d.Status == models.WebhookDeliveryDelivered {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// attempt - posts the delivery and returns it with the new status and the attempt's log record,
+// a failed delivery is retried with exponential backoff until it has no attempts left
+func (svc webhookService) attempt(ctx context.Context, w models.Webhook, d models.WebhookDelivery) (models.WebhookDelivery, models.WebhookAttempt) {
+	started := time.Now().UTC()
+	attempt := models.WebhookAttempt{At: started}
+	d.Attempts++
+
+	if !w.Active {
+		attempt.Error = "the webhook is not active"
+		d.Status = models.WebhookDeliveryDead
+		return d, attempt
+	}
+
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	header.Set(WebhookHeaderID, d.ID.String())
+	header.Set(WebhookHeaderEvent, d.Type)
+	header.Set(WebhookHeaderTimestamp, strconv.FormatInt(started.Unix(), 10))
+	header.Set(WebhookHeaderSignature, SignWebhookPayload(w.Secret, started.Unix(), d.Payload))
+
+	status, err := svc.sender.Post(ctx, w.URL, header, d.Payload)
+	attempt.Duration = time.Since(started)
+	attempt.StatusCode = status
+
+	switch {
+	case err != nil:
+		attempt.Error = err.Error()
+	case status < 200 || status > 299:
+		attempt.Error = fmt.Sprintf("the receiver has responded with %d", status)
+	default:
+		d.Status = models.WebhookDeliveryDelivered
+		d.DeliveredAt = time.Now().UTC()
+		return d, attempt
+	}
+
+	if d.Attempts >= svc.config.MaxAttempts {
+		d.Status = models.WebhookDeliveryDead
+		return d, attempt
+	}
+	d.NextAttemptAt = started.Add(svc.backoff(d.Attempts))
+	return d, attempt
+}
+
+// backoff - delay before the next attempt after given number of failed attempts
+func (svc webhookService) backoff(attempts int) time.Duration {
+	delay := svc.config.BackoffBase
+	for i := 1; i < attempts && delay < svc.config.BackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > svc.config.BackoffMax {
+		delay = svc.config.BackoffMax
+	}
+	return delay
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func NewWebhookService(
+	storage adapters.WebhookStorage,
+	deliveries adapters.WebhookDeliveryStorage,
+	sender adapters.WebhookSender,
+	cfg config.WebhookConfig,
+) services.WebhookService {
+	return &webhookService{
+		storage:    storage,
+		deliveries: deliveries,
+		sender:     sender,
+		config:     cfg,
+	}
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/indigowar/map-of-events/internal/config"
+	"github.com/indigowar/map-of-events/internal/domain/adapters"
+	"github.com/indigowar/map-of-events/internal/domain/models"
+	"github.com/indigowar/map-of-events/internal/domain/services"
+)
+
+func TestSignWebhookPayload(t *testing.T) {
+	// the signature computed by a receiver, e.g. in python:
+	// hmac.new(b"secret", b'1700000000.{"id":1}', hashlib.sha256).hexdigest()
+	const want = "sha256=3dd1b9aef568d75f6790a84bd2e5dfa1f44409eef3cbdbd3f10b837376100c11"
+
+	if got := SignWebhookPayload("secret", 1700000000, []byte(`{"id":1}`)); got != want {
+		t.Errorf("SignWebhookPayload = %s, want %s", got, want)
+	}
+
+	// every part of the signed message changes the signature
+	others := []string{
+		SignWebhookPayload("another", 1700000000, []byte(`{"id":1}`)),
+		SignWebhookPayload("secret", 1700000001, []byte(`{"id":1}`)),
+		SignWebhookPayload("secret", 1700000000, []byte(`{"id":2}`)),
+	}
+	for _, v := range others {
+		if v == want {
+			t.Errorf("the signature does not depend on all parts of the message")
+		}
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	svc := webhookService{config: config.WebhookConfig{BackoffBase: time.Second, BackoffMax: 10 * time.Second}}
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second},
+		{100, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := svc.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+// fakeSender - responds with the status or the error and keeps the last request
+type fakeSender struct {
+	status int
+	err    error
+
+	header http.Header
+	body   []byte
+}
+
+func (s *fakeSender) Post(_ context.Context, _ string, header http.Header, body []byte) (int, error) {
+	s.header, s.body = header, body
+	return s.status, s.err
+}
+
+func TestWebhookAttempt(t *testing.T) {
+	cfg := config.WebhookConfig{MaxAttempts: 3, BackoffBase: time.Minute, BackoffMax: time.Hour}
+	webhook := models.Webhook{ID: uuid.New(), URL: "http://receiver", Secret: "secret", Active: true}
+
+	tests := []struct {
+		name     string
+		sender   fakeSender
+		inactive bool
+		// attempts - the failed attempts before this one
+		attempts int
+		status   string
+		// backoff - the delay of the next attempt of a pending delivery
+		backoff time.Duration
+	}{
+		{name: "accepted", sender: fakeSender{status: http.StatusNoContent}, status: models.WebhookDeliveryDelivered},
+		{name: "rejected", sender: fakeSender{status: http.StatusInternalServerError}, status: models.WebhookDeliveryPending, backoff: time.Minute},
+		{name: "no response", sender: fakeSender{err: errors.New("connection refused")}, attempts: 1, status: models.WebhookDeliveryPending, backoff: 2 * time.Minute},
+		{name: "last attempt", sender: fakeSender{status: http.StatusBadGateway}, attempts: 2, status: models.WebhookDeliveryDead},
+		{name: "inactive webhook", sender: fakeSender{status: http.StatusOK}, inactive: true, status: models.WebhookDeliveryDead},
+	}
+
+	for _, tt := range tests {
+		sender := tt.sender
+		svc := webhookService{sender: &sender, config: cfg}
+
+		w := webhook
+		w.Active = !tt.inactive
+		delivery := models.WebhookDelivery{
+			ID:       uuid.New(),
+			Webhook:  w.ID,
+			Type:     models.ChangeEventUpdated,
+			Payload:  []byte(`{"id":1}`),
+			Status:   models.WebhookDeliveryPending,
+			Attempts: tt.attempts,
+		}
+
+		d, attempt := svc.attempt(context.Background(), w, delivery)
+
+		if d.Status != tt.status || d.Attempts != tt.attempts+1 {
+			t.Errorf("%s: status = %s after %d attempts, want %s after %d", tt.name, d.Status, d.Attempts, tt.status, tt.attempts+1)
+		}
+		if (d.Status == models.WebhookDeliveryDelivered) != (attempt.Error == "") {
+			t.Errorf("%s: error of the attempt = %q", tt.name, attempt.Error)
+		}
+		if d.Status == models.WebhookDeliveryPending && d.NextAttemptAt.Sub(attempt.At) != tt.backoff {
+			t.Errorf("%s: next attempt in %s, want %s", tt.name, d.NextAttemptAt.Sub(attempt.At), tt.backoff)
+		}
+
+		if tt.inactive {
+			if sender.header != nil {
+				t.Errorf("%s: the payload is sent to an inactive webhook", tt.name)
+			}
+			continue
+		}
+
+		// the receiver can check the signature by the headers
+		timestamp, err := strconv.ParseInt(sender.header.Get(WebhookHeaderTimestamp), 10, 64)
+		if err != nil {
+			t.Errorf("%s: timestamp header = %q", tt.name, sender.header.Get(WebhookHeaderTimestamp))
+			continue
+		}
+		if got, want := sender.header.Get(WebhookHeaderSignature), SignWebhookPayload(w.Secret, timestamp, sender.body); got != want {
+			t.Errorf("%s: signature header = %s, want %s", tt.name, got, want)
+		}
+		if got := sender.header.Get(WebhookHeaderID); got != delivery.ID.String() {
+			t.Errorf("%s: id header = %s, want %s", tt.name, got, delivery.ID)
+		}
+	}
+}
+
+type fakeWebhooks struct {
+	adapters.WebhookStorage
+	webhook models.Webhook
+}
+
+func (s fakeWebhooks) GetByID(_ context.Context, id uuid.UUID) (models.Webhook, error) {
+	if id != s.webhook.ID {
+		return models.Webhook{}, adapters.ErrNotFound
+	}
+	return s.webhook, nil
+}
+
+// fakeDeliveries - keeps one delivery like the postgres storage
+type fakeDeliveries struct {
+	adapters.WebhookDeliveryStorage
+	delivery models.WebhookDelivery
+}
+
+func (s *fakeDeliveries) GetDue(_ context.Context, now time.Time, _ int) ([]models.WebhookDelivery, error) {
+	if s.delivery.Status != models.WebhookDeliveryPending || s.delivery.NextAttemptAt.After(now) {
+		return nil, nil
+	}
+	return []models.WebhookDelivery{s.delivery}, nil
+}
+
+func (s *fakeDeliveries) GetByID(_ context.Context, id uuid.UUID) (models.WebhookDelivery, error) {
+	if id != s.delivery.ID {
+		return models.WebhookDelivery{}, adapters.ErrNotFound
+	}
+	return s.delivery, nil
+}
+
+func (s *fakeDeliveries) Record(_ context.Context, delivery models.WebhookDelivery, attempt models.WebhookAttempt) error {
+	delivery.History = append(delivery.History, attempt)
+	s.delivery = delivery
+	return nil
+}
+
+func (s *fakeDeliveries) Requeue(_ context.Context, _ uuid.UUID, at time.Time) error {
+	s.delivery.Status = models.WebhookDeliveryPending
+	s.delivery.Attempts = 0
+	s.delivery.NextAttemptAt = at
+	return nil
+}
+
+// the delivery goes from pending through the backoff to the dead letters, and it's delivered after the retry
+func TestWebhookDeliveryTransitions(t *testing.T) {
+	ctx := context.Background()
+	webhook := models.Webhook{ID: uuid.New(), URL: "http://receiver", Secret: "secret", Active: true}
+	now := time.Now().UTC()
+
+	sender := &fakeSender{status: http.StatusServiceUnavailable}
+	deliveries := &fakeDeliveries{delivery: models.WebhookDelivery{
+		ID:            uuid.New(),
+		Webhook:       webhook.ID,
+		Payload:       []byte(`{}`),
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: now,
+	}}
+	svc := NewWebhookService(fakeWebhooks{webhook: webhook}, deliveries, sender,
+		config.WebhookConfig{MaxAttempts: 2, BackoffBase: time.Minute, BackoffMax: time.Hour})
+
+	steps := []struct {
+		name      string
+		at        time.Time
+		status    string
+		attempts  int
+		delivered int
+	}{
+		{"first attempt fails", now, models.WebhookDeliveryPending, 1, 0},
+		{"next attempt is not due yet", now.Add(30 * time.Second), models.WebhookDeliveryPending, 1, 0},
+		{"last attempt fails", now.Add(2 * time.Minute), models.WebhookDeliveryDead, 2, 0},
+		{"dead delivery is not attempted", now.Add(time.Hour), models.WebhookDeliveryDead, 2, 0},
+	}
+	for _, step := range steps {
+		delivered, err := svc.DeliverDue(ctx, step.at)
+		if err != nil {
+			t.Fatalf("%s: DeliverDue: %v", step.name, err)
+		}
+		if d := deliveries.delivery; d.Status != step.status || d.Attempts != step.attempts || delivered != step.delivered {
+			t.Errorf("%s: status = %s after %d attempts, %d delivered, want %s after %d, %d delivered",
+				step.name, d.Status, d.Attempts, delivered, step.status, step.attempts, step.delivered)
+		}
+	}
+
+	if err := svc.Retry(ctx, deliveries.delivery.ID); err != nil {
+		t.Fatalf("Retry of a dead delivery: %v", err)
+	}
+	if err := svc.Retry(ctx, deliveries.delivery.ID); !errors.Is(err, services.ErrDeliveryIsNotDead) {
+		t.Errorf("Retry of a pending delivery = %v, want %v", err, services.ErrDeliveryIsNotDead)
+	}
+
+	sender.status = http.StatusOK
+	delivered, err := svc.DeliverDue(ctx, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("DeliverDue after the retry: %v", err)
+	}
+	if d := deliveries.delivery; delivered != 1 || d.Status != models.WebhookDeliveryDelivered || d.Attempts != 1 {
+		t.Errorf("after the retry: status = %s after %d attempts, %d delivered, want delivered at the first attempt", d.Status, d.Attempts, delivered)
+	}
+}
@@ -0,0 +1,817 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        (unknown)
+// source: eventmap/v1/competitor.proto
+
+package eventmapv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Competitor - zero values of the criteria mean there is no restriction
+type Competitor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// category - individual, team or organization
+	Category string `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	MinAge   int32  `protobuf:"varint,4,opt,name=min_age,json=minAge,proto3" json:"min_age,omitempty"`
+	MaxAge   int32  `protobuf:"varint,5,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	// min_degree - the lowest academic degree an applicant should have
+	MinDegree         string   `protobuf:"bytes,6,opt,name=min_degree,json=minDegree,proto3" json:"min_degree,omitempty"`
+	Regions           []string `protobuf:"bytes,7,rep,name=regions,proto3" json:"regions,omitempty"`
+	OrganizationTypes []string `protobuf:"bytes,8,rep,name=organization_types,json=organizationTypes,proto3" json:"organization_types,omitempty"`
+}
+
+func (x *Competitor) Reset() {
+	*x = Competitor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventmap_v1_competitor_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Competitor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Competitor) ProtoMessage() {}
+
+func (x *Competitor) ProtoReflect() protoreflect.Message {
+	mi := &file_eventmap_v1_competitor_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Competitor.ProtoReflect.Descriptor instead.
+func (*Competitor) Descriptor() ([]byte, []int) {
+	return file_eventmap_v1_competitor_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Competitor) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Competitor) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Competitor) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Competitor) GetMinAge() int32 {
+	if x != nil {
+		return x.MinAge
+	}
+	return 0
+}
+
+func (x *Competitor) GetMaxAge() int32 {
+	if x != nil {
+		return x.MaxAge
+	}
+	return 0
+}
+
+func (x *Competitor) GetMinDegree() string {
+	if x != nil {
+		return x.MinDegree
+	}
+	return ""
+}
+
+func (x *Competitor) GetRegions() []string {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+func (x *Competitor) GetOrganizationTypes() []string {
+	if x != nil {
+		return x.OrganizationTypes
+	}
+	return nil
+}
+
+type CompetitorInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name              string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Category          string   `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	MinAge            int32    `protobuf:"varint,3,opt,name=min_age,json=minAge,proto3" json:"min_age,omitempty"`
+	MaxAge            int32    `protobuf:"varint,4,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	MinDegree         string   `protobuf:"bytes,5,opt,name=min_degree,json=minDegree,proto3" json:"min_degree,omitempty"`
+	Regions           []string `protobuf:"bytes,6,rep,name=regions,proto3" json:"regions,omitempty"`
+	OrganizationTypes []string `protobuf:"bytes,7,rep,name=organization_types,json=organizationTypes,proto3" json:"organization_types,omitempty"`
+}
+
+func (x *CompetitorInput) Reset() {
+	*x = CompetitorInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventmap_v1_competitor_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompetitorInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompetitorInput) ProtoMessage() {}
+
+func (x *CompetitorInput) ProtoReflect() protoreflect.Message {
+	mi := &file_eventmap_v1_competitor_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompetitorInput.ProtoReflect.Descriptor instead.
+func (*CompetitorInput) Descriptor() ([]byte, []int) {
+	return file_eventmap_v1_competitor_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CompetitorInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CompetitorInput) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CompetitorInput) GetMinAge() int32 {
+	if x != nil {
+		return x.MinAge
+	}
+	return 0
+}
+
+func (x *CompetitorInput) GetMaxAge() int32 {
+	if x != nil {
+		return x.MaxAge
+	}
+	return 0
+}
+
+func (x *CompetitorInput) GetMinDegree() string {
+	if x != nil {
+		return x.MinDegree
+	}
+	return ""
+}
+
+func (x *CompetitorInput) GetRegions() []string {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+func (x *CompetitorInput) GetOrganizationTypes() []string {
+	if x != nil {
+		return x.OrganizationTypes
+	}
+	return nil
+}
+
+type ListCompetitorsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListCompetitorsRequest) Reset() {
+	*x = ListCompetitorsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventmap_v1_competitor_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCompetitorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCompetitorsRequest) ProtoMessage() {}
+
+func (x *ListCompetitorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eventmap_v1_competitor_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCompetitorsRequest.ProtoReflect.Descriptor instead.
+func (*ListCompetitorsRequest) Descriptor() ([]byte, []int) {
+	return file_eventmap_v1_competitor_proto_rawDescGZIP(), []int{2}
+}
+
+type ListCompetitorsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Competitors []*Competitor `protobuf:"bytes,1,rep,name=competitors,proto3" json:"competitors,omitempty"`
+}
+
+func (x *ListCompetitorsResponse) Reset() {
+	*x = ListCompetitorsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventmap_v1_competitor_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCompetitorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCompetitorsResponse) ProtoMessage() {}
+
+func (x *ListCompetitorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_eventmap_v1_competitor_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCompetitorsResponse.ProtoReflect.Descriptor instead.
+func (*ListCompetitorsResponse) Descriptor() ([]byte, []int) {
+	return file_eventmap_v1_competitor_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListCompetitorsResponse) GetCompetitors() []*Competitor {
+	if x != nil {
+		return x.Competitors
+	}
+	return nil
+}
+
+type GetCompetitorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetCompetitorRequest) Reset() {
+	*x = GetCompetitorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventmap_v1_competitor_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCompetitorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCompetitorRequest) ProtoMessage() {}
+
+func (x *GetCompetitorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eventmap_v1_competitor_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCompetitorRequest.ProtoReflect.Descriptor instead.
+func (*GetCompetitorRequest) Descriptor() ([]byte, []int) {
+	return file_eventmap_v1_competitor_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCompetitorRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateCompetitorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Competitor *CompetitorInput `protobuf:"bytes,1,opt,name=competitor,proto3" json:"competitor,omitempty"`
+}
+
+func (x *CreateCompetitorRequest) Reset() {
+	*x = CreateCompetitorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventmap_v1_competitor_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateCompetitorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCompetitorRequest) ProtoMessage() {}
+
+func (x *CreateCompetitorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eventmap_v1_competitor_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCompetitorRequest.ProtoReflect.Descriptor instead.
+func (*CreateCompetitorRequest) Descriptor() ([]byte, []int) {
+	return file_eventmap_v1_competitor_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateCompetitorRequest) GetCompetitor() *CompetitorInput {
+	if x != nil {
+		return x.Competitor
+	}
+	return nil
+}
+
+type UpdateCompetitorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Competitor *CompetitorInput `protobuf:"bytes,2,opt,name=competitor,proto3" json:"competitor,omitempty"`
+}
+
+func (x *UpdateCompetitorRequest) Reset() {
+	*x = UpdateCompetitorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventmap_v1_competitor_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateCompetitorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCompetitorRequest) ProtoMessage() {}
+
+func (x *UpdateCompetitorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eventmap_v1_competitor_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCompetitorRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCompetitorRequest) Descriptor() ([]byte, []int) {
+	return file_eventmap_v1_competitor_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateCompetitorRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateCompetitorRequest) GetCompetitor() *CompetitorInput {
+	if x != nil {
+		return x.Competitor
+	}
+	return nil
+}
+
+type DeleteCompetitorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteCompetitorRequest) Reset() {
+	*x = DeleteCompetitorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventmap_v1_competitor_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteCompetitorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCompetitorRequest) ProtoMessage() {}
+
+func (x *DeleteCompetitorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eventmap_v1_competitor_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCompetitorRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCompetitorRequest) Descriptor() ([]byte, []int) {
+	return file_eventmap_v1_competitor_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteCompetitorRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteCompetitorResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteCompetitorResponse) Reset() {
+	*x = DeleteCompetitorResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_eventmap_v1_competitor_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteCompetitorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCompetitorResponse) ProtoMessage() {}
+
+func (x *DeleteCompetitorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_eventmap_v1_competitor_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCompetitorResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCompetitorResponse) Descriptor() ([]byte, []int) {
+	return file_eventmap_v1_competitor_proto_rawDescGZIP(), []int{8}
+}
+
+var File_eventmap_v1_competitor_proto protoreflect.FileDescriptor
+
+var file_eventmap_v1_competitor_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x6d, 0x61, 0x70, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f,
+	0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x6d, 0x61, 0x70, 0x2e, 0x76, 0x31, 0x22, 0xe6, 0x01, 0x0a, 0x0a,
+	0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x69,
+	0x6e, 0x5f, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6d, 0x69, 0x6e,
+	0x41, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x67, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x6d, 0x69, 0x6e, 0x5f, 0x64, 0x65, 0x67, 0x72, 0x65, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6d, 0x69, 0x6e, 0x44, 0x65, 0x67, 0x72, 0x65, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72,
+	0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65,
+	0x67, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x6f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x11, 0x6f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x79, 0x70, 0x65, 0x73, 0x22, 0xdb, 0x01, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69,
+	0x74, 0x6f, 0x72, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x69, 0x6e, 0x5f,
+	0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6d, 0x69, 0x6e, 0x41, 0x67,
+	0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x69,
+	0x6e, 0x5f, 0x64, 0x65, 0x67, 0x72, 0x65, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6d, 0x69, 0x6e, 0x44, 0x65, 0x67, 0x72, 0x65, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x67,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x67, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x6f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x11, 0x6f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70,
+	0x65, 0x73, 0x22, 0x18, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74,
+	0x69, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x54, 0x0a, 0x17,
+	0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x65,
+	0x74, 0x69, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x6d, 0x61, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x65,
+	0x74, 0x69, 0x74, 0x6f, 0x72, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f,
+	0x72, 0x73, 0x22, 0x26, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69,
+	0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x57, 0x0a, 0x17, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69,
+	0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x6d, 0x61, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74,
+	0x6f, 0x72, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69,
+	0x74, 0x6f, 0x72, 0x22, 0x67, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d,
+	0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3c,
+	0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x6d, 0x61, 0x70, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x49, 0x6e, 0x70, 0x75, 0x74,
+	0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x22, 0x29, 0x0a, 0x17,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x1a, 0x0a, 0x18, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x32, 0xc5, 0x03, 0x0a, 0x11, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74,
+	0x6f, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5c, 0x0a, 0x0f, 0x4c, 0x69, 0x73,
+	0x74, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x23, 0x2e, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x6d, 0x61, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43,
+	0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x24, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x6d, 0x61, 0x70, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x43, 0x6f,
+	0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x12, 0x21, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x6d, 0x61, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74,
+	0x69, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x6d, 0x61, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74,
+	0x69, 0x74, 0x6f, 0x72, 0x12, 0x51, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f,
+	0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x12, 0x24, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x6d, 0x61, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d,
+	0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
+	0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x6d, 0x61, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d,
+	0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x12, 0x51, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x12, 0x24, 0x2e, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x6d, 0x61, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x17, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x6d, 0x61, 0x70, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x12, 0x5f, 0x0a, 0x10, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x12, 0x24,
+	0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x6d, 0x61, 0x70, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x6d, 0x61, 0x70, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69,
+	0x74, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x43, 0x5a, 0x41, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6e, 0x64, 0x69, 0x67, 0x6f,
+	0x77, 0x61, 0x72, 0x2f, 0x6d, 0x61, 0x70, 0x2d, 0x6f, 0x66, 0x2d, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x6d,
+	0x61, 0x70, 0x2f, 0x76, 0x31, 0x3b, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x6d, 0x61, 0x70, 0x76, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_eventmap_v1_competitor_proto_rawDescOnce sync.Once
+	file_eventmap_v1_competitor_proto_rawDescData = file_eventmap_v1_competitor_proto_rawDesc
+)
+
+func file_eventmap_v1_competitor_proto_rawDescGZIP() []byte {
+	file_eventmap_v1_competitor_proto_rawDescOnce.Do(func() {
+		file_eventmap_v1_competitor_proto_rawDescData = protoimpl.X.CompressGZIP(file_eventmap_v1_competitor_proto_rawDescData)
+	})
+	return file_eventmap_v1_competitor_proto_rawDescData
+}
+
+var file_eventmap_v1_competitor_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_eventmap_v1_competitor_proto_goTypes = []interface{}{
+	(*Competitor)(nil),               // 0: eventmap.v1.Competitor
+	(*CompetitorInput)(nil),          // 1: eventmap.v1.CompetitorInput
+	(*ListCompetitorsRequest)(nil),   // 2: eventmap.v1.ListCompetitorsRequest
+	(*ListCompetitorsResponse)(nil),  // 3: eventmap.v1.ListCompetitorsResponse
+	(*GetCompetitorRequest)(nil),     // 4: eventmap.v1.GetCompetitorRequest
+	(*CreateCompetitorRequest)(nil),  // 5: eventmap.v1.CreateCompetitorRequest
+	(*UpdateCompetitorRequest)(nil),  // 6: eventmap.v1.UpdateCompetitorRequest
+	(*DeleteCompetitorRequest)(nil),  // 7: eventmap.v1.DeleteCompetitorRequest
+	(*DeleteCompetitorResponse)(nil), // 8: eventmap.v1.DeleteCompetitorResponse
+}
+var file_eventmap_v1_competitor_proto_depIdxs = []int32{
+	0, // 0: eventmap.v1.ListCompetitorsResponse.competitors:type_name -> eventmap.v1.Competitor
+	1, // 1: eventmap.v1.CreateCompetitorRequest.competitor:type_name -> eventmap.v1.CompetitorInput
+	1, // 2: eventmap.v1.UpdateCompetitorRequest.competitor:type_name -> eventmap.v1.CompetitorInput
+	2, // 3: eventmap.v1.CompetitorService.ListCompetitors:input_type -> eventmap.v1.ListCompetitorsRequest
+	4, // 4: eventmap.v1.CompetitorService.GetCompetitor:input_type -> eventmap.v1.GetCompetitorRequest
+	5, // 5: eventmap.v1.CompetitorService.CreateCompetitor:input_type -> eventmap.v1.CreateCompetitorRequest
+	6, // 6: eventmap.v1.CompetitorService.UpdateCompetitor:input_type -> eventmap.v1.UpdateCompetitorRequest
+	7, // 7: eventmap.v1.CompetitorService.DeleteCompetitor:input_type -> eventmap.v1.DeleteCompetitorRequest
+	3, // 8: eventmap.v1.CompetitorService.ListCompetitors:output_type -> eventmap.v1.ListCompetitorsResponse
+	0, // 9: eventmap.v1.CompetitorService.GetCompetitor:output_type -> eventmap.v1.Competitor
+	0, // 10: eventmap.v1.CompetitorService.CreateCompetitor:output_type -> eventmap.v1.Competitor
+	0, // 11: eventmap.v1.CompetitorService.UpdateCompetitor:output_type -> eventmap.v1.Competitor
+	8, // 12: eventmap.v1.CompetitorService.DeleteCompetitor:output_type -> eventmap.v1.DeleteCompetitorResponse
+	8, // [8:13] is the sub-list for method output_type
+	3, // [3:8] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_eventmap_v1_competitor_proto_init() }
+func file_eventmap_v1_competitor_proto_init() {
+	if File_eventmap_v1_competitor_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_eventmap_v1_competitor_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Competitor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventmap_v1_competitor_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompetitorInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventmap_v1_competitor_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCompetitorsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventmap_v1_competitor_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCompetitorsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventmap_v1_competitor_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCompetitorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventmap_v1_competitor_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateCompetitorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventmap_v1_competitor_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateCompetitorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventmap_v1_competitor_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteCompetitorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_eventmap_v1_competitor_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteCompetitorResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_eventmap_v1_competitor_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_eventmap_v1_competitor_proto_goTypes,
+		DependencyIndexes: file_eventmap_v1_competitor_proto_depIdxs,
+		MessageInfos:      file_eventmap_v1_competitor_proto_msgTypes,
+	}.Build()
+	File_eventmap_v1_competitor_proto = out.File
+	file_eventmap_v1_competitor_proto_rawDesc = nil
+	file_eventmap_v1_competitor_proto_goTypes = nil
+	file_eventmap_v1_competitor_proto_depIdxs = nil
+}
@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: eventmap/v1/competitor.proto
+
+package eventmapv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	CompetitorService_ListCompetitors_FullMethodName  = "/eventmap.v1.CompetitorService/ListCompetitors"
+	CompetitorService_GetCompetitor_FullMethodName    = "/eventmap.v1.CompetitorService/GetCompetitor"
+	CompetitorService_CreateCompetitor_FullMethodName = "/eventmap.v1.CompetitorService/CreateCompetitor"
+	CompetitorService_UpdateCompetitor_FullMethodName = "/eventmap.v1.CompetitorService/UpdateCompetitor"
+	CompetitorService_DeleteCompetitor_FullMethodName = "/eventmap.v1.CompetitorService/DeleteCompetitor"
+)
+
+// CompetitorServiceClient is the client API for CompetitorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CompetitorServiceClient interface {
+	ListCompetitors(ctx context.Context, in *ListCompetitorsRequest, opts ...grpc.CallOption) (*ListCompetitorsResponse, error)
+	GetCompetitor(ctx context.Context, in *GetCompetitorRequest, opts ...grpc.CallOption) (*Competitor, error)
+	CreateCompetitor(ctx context.Context, in *CreateCompetitorRequest, opts ...grpc.CallOption) (*Competitor, error)
+	UpdateCompetitor(ctx context.Context, in *UpdateCompetitorRequest, opts ...grpc.CallOption) (*Competitor, error)
+	DeleteCompetitor(ctx context.Context, in *DeleteCompetitorRequest, opts ...grpc.CallOption) (*DeleteCompetitorResponse, error)
+}
+
+type competitorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCompetitorServiceClient(cc grpc.ClientConnInterface) CompetitorServiceClient {
+	return &competitorServiceClient{cc}
+}
+
+func (c *competitorServiceClient) ListCompetitors(ctx context.Context, in *ListCompetitorsRequest, opts ...grpc.CallOption) (*ListCompetitorsResponse, error) {
+	out := new(ListCompetitorsResponse)
+	err := c.cc.Invoke(ctx, CompetitorService_ListCompetitors_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *competitorServiceClient) GetCompetitor(ctx context.Context, in *GetCompetitorRequest, opts ...grpc.CallOption) (*Competitor, error) {
+	out := new(Competitor)
+	err := c.cc.Invoke(ctx, CompetitorService_GetCompetitor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *competitorServiceClient) CreateCompetitor(ctx context.Context, in *CreateCompetitorRequest, opts ...grpc.CallOption) (*Competitor, error) {
+	out := new(Competitor)
+	err := c.cc.Invoke(ctx, CompetitorService_CreateCompetitor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *competitorServiceClient) UpdateCompetitor(ctx context.Context, in *UpdateCompetitorRequest, opts ...grpc.CallOption) (*Competitor, error) {
+	out := new(Competitor)
+	err := c.cc.Invoke(ctx, CompetitorService_UpdateCompetitor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *competitorServiceClient) DeleteCompetitor(ctx context.Context, in *DeleteCompetitorRequest, opts ...grpc.CallOption) (*DeleteCompetitorResponse, error) {
+	out := new(DeleteCompetitorResponse)
+	err := c.cc.Invoke(ctx, CompetitorService_DeleteCompetitor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CompetitorServiceServer is the server API for CompetitorService service.
+// All implementations must embed UnimplementedCompetitorServiceServer
+// for forward compatibility
+type CompetitorServiceServer interface {
+	ListCompetitors(context.Context, *ListCompetitorsRequest) (*ListCompetitorsResponse, error)
+	GetCompetitor(context.Context, *GetCompetitorRequest) (*Competitor, error)
+	CreateCompetitor(context.Context, *CreateCompetitorRequest) (*Competitor, error)
+	UpdateCompetitor(context.Context, *UpdateCompetitorRequest) (*Competitor, error)
+	DeleteCompetitor(context.Context, *DeleteCompetitorRequest) (*DeleteCompetitorResponse, error)
+	mustEmbedUnimplementedCompetitorServiceServer()
+}
+
+// UnimplementedCompetitorServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCompetitorServiceServer struct {
+}
+
+func (UnimplementedCompetitorServiceServer) ListCompetitors(context.Context, *ListCompetitorsRequest) (*ListCompetitorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCompetitors not implemented")
+}
+func (UnimplementedCompetitorServiceServer) GetCompetitor(context.Context, *GetCompetitorRequest) (*Competitor, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCompetitor not implemented")
+}
+func (UnimplementedCompetitorServiceServer) CreateCompetitor(context.Context, *CreateCompetitorRequest) (*Competitor, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCompetitor not implemented")
+}
+func (UnimplementedCompetitorServiceServer) UpdateCompetitor(context.Context, *UpdateCompetitorRequest) (*Competitor, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCompetitor not implemented")
+}
+func (UnimplementedCompetitorServiceServer) DeleteCompetitor(context.Context, *DeleteCompetitorRequest) (*DeleteCompetitorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCompetitor not implemented")
+}
+func (UnimplementedCompetitorServiceServer) mustEmbedUnimplementedCompetitorServiceServer() {}
+
+// UnsafeCompetitorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CompetitorServiceServer will
+// result in compilation errors.
+type UnsafeCompetitorServiceServer interface {
+	mustEmbedUnimplementedCompetitorServiceServer()
+}
+
+func RegisterCompetitorServiceServer(s grpc.ServiceRegistrar, srv CompetitorServiceServer) {
+	s.RegisterService(&CompetitorService_ServiceDesc, srv)
+}
+
+func _CompetitorService_ListCompetitors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCompetitorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompetitorServiceServer).ListCompetitors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CompetitorService_ListCompetitors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompetitorServiceServer).ListCompetitors(ctx, req.(*ListCompetitorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompetitorService_GetCompetitor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCompetitorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompetitorServiceServer).GetCompetitor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CompetitorService_GetCompetitor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompetitorServiceServer).GetCompetitor(ctx, req.(*GetCompetitorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompetitorService_CreateCompetitor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCompetitorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompetitorServiceServer).CreateCompetitor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CompetitorService_CreateCompetitor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompetitorServiceServer).CreateCompetitor(ctx, req.(*CreateCompetitorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompetitorService_UpdateCompetitor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCompetitorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompetitorServiceServer).UpdateCompetitor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CompetitorService_UpdateCompetitor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompetitorServiceServer).UpdateCompetitor(ctx, req.(*UpdateCompetitorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompetitorService_DeleteCompetitor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCompetitorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompetitorServiceServer).DeleteCompetitor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CompetitorService_DeleteCompetitor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompetitorServiceServer).DeleteCompetitor(ctx, req.(*DeleteCompetitorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CompetitorService_ServiceDesc is the grpc.ServiceDesc for CompetitorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CompetitorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "eventmap.v1.CompetitorService",
+	HandlerType: (*CompetitorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListCompetitors",
+			Handler:    _CompetitorService_ListCompetitors_Handler,
+		},
+		{
+			MethodName: "GetCompetitor",
+			Handler:    _CompetitorService_GetCompetitor_Handler,
+		},
+		{
+			MethodName: "CreateCompetitor",
+			Handler:    _CompetitorService_CreateCompetitor_Handler,
+		},
+		{
+			MethodName: "UpdateCompetitor",
+			Handler:    _CompetitorService_UpdateCompetitor_Handler,
+		},
+		{
+			MethodName: "DeleteCompetitor",
+			Handler:    _CompetitorService_DeleteCompetitor_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "eventmap/v1/competitor.proto",
+}
@@ -0,0 +1,4 @@
+// Package eventmapv1 - the code of the gRPC API generated from api/eventmap/v1 by buf
+package eventmapv1
+
+//go:generate buf generate --template ../../../../buf.gen.yaml --output ../../../.. ../../../../api
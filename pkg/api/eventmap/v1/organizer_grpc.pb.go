@@ -0,0 +1,333 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: eventmap/v1/organizer.proto
+
+package eventmapv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	OrganizerService_ListOrganizers_FullMethodName       = "/eventmap.v1.OrganizerService/ListOrganizers"
+	OrganizerService_GetOrganizer_FullMethodName         = "/eventmap.v1.OrganizerService/GetOrganizer"
+	OrganizerService_CreateOrganizer_FullMethodName      = "/eventmap.v1.OrganizerService/CreateOrganizer"
+	OrganizerService_UpdateOrganizer_FullMethodName      = "/eventmap.v1.OrganizerService/UpdateOrganizer"
+	OrganizerService_DeleteOrganizer_FullMethodName      = "/eventmap.v1.OrganizerService/DeleteOrganizer"
+	OrganizerService_ListOrganizerLevels_FullMethodName  = "/eventmap.v1.OrganizerService/ListOrganizerLevels"
+	OrganizerService_CreateOrganizerLevel_FullMethodName = "/eventmap.v1.OrganizerService/CreateOrganizerLevel"
+)
+
+// OrganizerServiceClient is the client API for OrganizerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OrganizerServiceClient interface {
+	ListOrganizers(ctx context.Context, in *ListOrganizersRequest, opts ...grpc.CallOption) (*ListOrganizersResponse, error)
+	GetOrganizer(ctx context.Context, in *GetOrganizerRequest, opts ...grpc.CallOption) (*Organizer, error)
+	CreateOrganizer(ctx context.Context, in *CreateOrganizerRequest, opts ...grpc.CallOption) (*Organizer, error)
+	// UpdateOrganizer - fails with FAILED_PRECONDITION if the organizer was changed since the version
+	UpdateOrganizer(ctx context.Context, in *UpdateOrganizerRequest, opts ...grpc.CallOption) (*Organizer, error)
+	DeleteOrganizer(ctx context.Context, in *DeleteOrganizerRequest, opts ...grpc.CallOption) (*DeleteOrganizerResponse, error)
+	ListOrganizerLevels(ctx context.Context, in *ListOrganizerLevelsRequest, opts ...grpc.CallOption) (*ListOrganizerLevelsResponse, error)
+	CreateOrganizerLevel(ctx context.Context, in *CreateOrganizerLevelRequest, opts ...grpc.CallOption) (*OrganizerLevel, error)
+}
+
+type organizerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrganizerServiceClient(cc grpc.ClientConnInterface) OrganizerServiceClient {
+	return &organizerServiceClient{cc}
+}
+
+func (c *organizerServiceClient) ListOrganizers(ctx context.Context, in *ListOrganizersRequest, opts ...grpc.CallOption) (*ListOrganizersResponse, error) {
+	out := new(ListOrganizersResponse)
+	err := c.cc.Invoke(ctx, OrganizerService_ListOrganizers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizerServiceClient) GetOrganizer(ctx context.Context, in *GetOrganizerRequest, opts ...grpc.CallOption) (*Organizer, error) {
+	out := new(Organizer)
+	err := c.cc.Invoke(ctx, OrganizerService_GetOrganizer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizerServiceClient) CreateOrganizer(ctx context.Context, in *CreateOrganizerRequest, opts ...grpc.CallOption) (*Organizer, error) {
+	out := new(Organizer)
+	err := c.cc.Invoke(ctx, OrganizerService_CreateOrganizer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizerServiceClient) UpdateOrganizer(ctx context.Context, in *UpdateOrganizerRequest, opts ...grpc.CallOption) (*Organizer, error) {
+	out := new(Organizer)
+	err := c.cc.Invoke(ctx, OrganizerService_UpdateOrganizer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizerServiceClient) DeleteOrganizer(ctx context.Context, in *DeleteOrganizerRequest, opts ...grpc.CallOption) (*DeleteOrganizerResponse, error) {
+	out := new(DeleteOrganizerResponse)
+	err := c.cc.Invoke(ctx, OrganizerService_DeleteOrganizer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizerServiceClient) ListOrganizerLevels(ctx context.Context, in *ListOrganizerLevelsRequest, opts ...grpc.CallOption) (*ListOrganizerLevelsResponse, error) {
+	out := new(ListOrganizerLevelsResponse)
+	err := c.cc.Invoke(ctx, OrganizerService_ListOrganizerLevels_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizerServiceClient) CreateOrganizerLevel(ctx context.Context, in *CreateOrganizerLevelRequest, opts ...grpc.CallOption) (*OrganizerLevel, error) {
+	out := new(OrganizerLevel)
+	err := c.cc.Invoke(ctx, OrganizerService_CreateOrganizerLevel_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrganizerServiceServer is the server API for OrganizerService service.
+// All implementations must embed UnimplementedOrganizerServiceServer
+// for forward compatibility
+type OrganizerServiceServer interface {
+	ListOrganizers(context.Context, *ListOrganizersRequest) (*ListOrganizersResponse, error)
+	GetOrganizer(context.Context, *GetOrganizerRequest) (*Organizer, error)
+	CreateOrganizer(context.Context, *CreateOrganizerRequest) (*Organizer, error)
+	// UpdateOrganizer - fails with FAILED_PRECONDITION if the organizer was changed since the version
+	UpdateOrganizer(context.Context, *UpdateOrganizerRequest) (*Organizer, error)
+	DeleteOrganizer(context.Context, *DeleteOrganizerRequest) (*DeleteOrganizerResponse, error)
+	ListOrganizerLevels(context.Context, *ListOrganizerLevelsRequest) (*ListOrganizerLevelsResponse, error)
+	CreateOrganizerLevel(context.Context, *CreateOrganizerLevelRequest) (*OrganizerLevel, error)
+	mustEmbedUnimplementedOrganizerServiceServer()
+}
+
+// UnimplementedOrganizerServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedOrganizerServiceServer struct {
+}
+
+func (UnimplementedOrganizerServiceServer) ListOrganizers(context.Context, *ListOrganizersRequest) (*ListOrganizersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrganizers not implemented")
+}
+func (UnimplementedOrganizerServiceServer) GetOrganizer(context.Context, *GetOrganizerRequest) (*Organizer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrganizer not implemented")
+}
+func (UnimplementedOrganizerServiceServer) CreateOrganizer(context.Context, *CreateOrganizerRequest) (*Organizer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrganizer not implemented")
+}
+func (UnimplementedOrganizerServiceServer) UpdateOrganizer(context.Context, *UpdateOrganizerRequest) (*Organizer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrganizer not implemented")
+}
+func (UnimplementedOrganizerServiceServer) DeleteOrganizer(context.Context, *DeleteOrganizerRequest) (*DeleteOrganizerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteOrganizer not implemented")
+}
+func (UnimplementedOrganizerServiceServer) ListOrganizerLevels(context.Context, *ListOrganizerLevelsRequest) (*ListOrganizerLevelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrganizerLevels not implemented")
+}
+func (UnimplementedOrganizerServiceServer) CreateOrganizerLevel(context.Context, *CreateOrganizerLevelRequest) (*OrganizerLevel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrganizerLevel not implemented")
+}
+func (UnimplementedOrganizerServiceServer) mustEmbedUnimplementedOrganizerServiceServer() {}
+
+// UnsafeOrganizerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrganizerServiceServer will
+// result in compilation errors.
+type UnsafeOrganizerServiceServer interface {
+	mustEmbedUnimplementedOrganizerServiceServer()
+}
+
+func RegisterOrganizerServiceServer(s grpc.ServiceRegistrar, srv OrganizerServiceServer) {
+	s.RegisterService(&OrganizerService_ServiceDesc, srv)
+}
+
+func _OrganizerService_ListOrganizers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrganizersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizerServiceServer).ListOrganizers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizerService_ListOrganizers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizerServiceServer).ListOrganizers(ctx, req.(*ListOrganizersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizerService_GetOrganizer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrganizerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizerServiceServer).GetOrganizer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizerService_GetOrganizer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizerServiceServer).GetOrganizer(ctx, req.(*GetOrganizerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizerService_CreateOrganizer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrganizerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizerServiceServer).CreateOrganizer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizerService_CreateOrganizer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizerServiceServer).CreateOrganizer(ctx, req.(*CreateOrganizerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizerService_UpdateOrganizer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOrganizerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizerServiceServer).UpdateOrganizer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizerService_UpdateOrganizer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizerServiceServer).UpdateOrganizer(ctx, req.(*UpdateOrganizerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizerService_DeleteOrganizer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteOrganizerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizerServiceServer).DeleteOrganizer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizerService_DeleteOrganizer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizerServiceServer).DeleteOrganizer(ctx, req.(*DeleteOrganizerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizerService_ListOrganizerLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrganizerLevelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizerServiceServer).ListOrganizerLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizerService_ListOrganizerLevels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizerServiceServer).ListOrganizerLevels(ctx, req.(*ListOrganizerLevelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizerService_CreateOrganizerLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrganizerLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizerServiceServer).CreateOrganizerLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizerService_CreateOrganizerLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizerServiceServer).CreateOrganizerLevel(ctx, req.(*CreateOrganizerLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OrganizerService_ServiceDesc is the grpc.ServiceDesc for OrganizerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OrganizerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "eventmap.v1.OrganizerService",
+	HandlerType: (*OrganizerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListOrganizers",
+			Handler:    _OrganizerService_ListOrganizers_Handler,
+		},
+		{
+			MethodName: "GetOrganizer",
+			Handler:    _OrganizerService_GetOrganizer_Handler,
+		},
+		{
+			MethodName: "CreateOrganizer",
+			Handler:    _OrganizerService_CreateOrganizer_Handler,
+		},
+		{
+			MethodName: "UpdateOrganizer",
+			Handler:    _OrganizerService_UpdateOrganizer_Handler,
+		},
+		{
+			MethodName: "DeleteOrganizer",
+			Handler:    _OrganizerService_DeleteOrganizer_Handler,
+		},
+		{
+			MethodName: "ListOrganizerLevels",
+			Handler:    _OrganizerService_ListOrganizerLevels_Handler,
+		},
+		{
+			MethodName: "CreateOrganizerLevel",
+			Handler:    _OrganizerService_CreateOrganizerLevel_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "eventmap/v1/organizer.proto",
+}
@@ -0,0 +1,261 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: eventmap/v1/subject.proto
+
+package eventmapv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	SubjectService_ListSubjects_FullMethodName  = "/eventmap.v1.SubjectService/ListSubjects"
+	SubjectService_GetSubject_FullMethodName    = "/eventmap.v1.SubjectService/GetSubject"
+	SubjectService_CreateSubject_FullMethodName = "/eventmap.v1.SubjectService/CreateSubject"
+	SubjectService_UpdateSubject_FullMethodName = "/eventmap.v1.SubjectService/UpdateSubject"
+	SubjectService_DeleteSubject_FullMethodName = "/eventmap.v1.SubjectService/DeleteSubject"
+)
+
+// SubjectServiceClient is the client API for SubjectService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SubjectServiceClient interface {
+	ListSubjects(ctx context.Context, in *ListSubjectsRequest, opts ...grpc.CallOption) (*ListSubjectsResponse, error)
+	GetSubject(ctx context.Context, in *GetSubjectRequest, opts ...grpc.CallOption) (*Subject, error)
+	CreateSubject(ctx context.Context, in *CreateSubjectRequest, opts ...grpc.CallOption) (*Subject, error)
+	// UpdateSubject - the subject can not be moved under itself or its descendants
+	UpdateSubject(ctx context.Context, in *UpdateSubjectRequest, opts ...grpc.CallOption) (*Subject, error)
+	// DeleteSubject - fails with FAILED_PRECONDITION if other subjects are placed under it
+	DeleteSubject(ctx context.Context, in *DeleteSubjectRequest, opts ...grpc.CallOption) (*DeleteSubjectResponse, error)
+}
+
+type subjectServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSubjectServiceClient(cc grpc.ClientConnInterface) SubjectServiceClient {
+	return &subjectServiceClient{cc}
+}
+
+func (c *subjectServiceClient) ListSubjects(ctx context.Context, in *ListSubjectsRequest, opts ...grpc.CallOption) (*ListSubjectsResponse, error) {
+	out := new(ListSubjectsResponse)
+	err := c.cc.Invoke(ctx, SubjectService_ListSubjects_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subjectServiceClient) GetSubject(ctx context.Context, in *GetSubjectRequest, opts ...grpc.CallOption) (*Subject, error) {
+	out := new(Subject)
+	err := c.cc.Invoke(ctx, SubjectService_GetSubject_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subjectServiceClient) CreateSubject(ctx context.Context, in *CreateSubjectRequest, opts ...grpc.CallOption) (*Subject, error) {
+	out := new(Subject)
+	err := c.cc.Invoke(ctx, SubjectService_CreateSubject_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subjectServiceClient) UpdateSubject(ctx context.Context, in *UpdateSubjectRequest, opts ...grpc.CallOption) (*Subject, error) {
+	out := new(Subject)
+	err := c.cc.Invoke(ctx, SubjectService_UpdateSubject_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subjectServiceClient) DeleteSubject(ctx context.Context, in *DeleteSubjectRequest, opts ...grpc.CallOption) (*DeleteSubjectResponse, error) {
+	out := new(DeleteSubjectResponse)
+	err := c.cc.Invoke(ctx, SubjectService_DeleteSubject_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SubjectServiceServer is the server API for SubjectService service.
+// All implementations must embed UnimplementedSubjectServiceServer
+// for forward compatibility
+type SubjectServiceServer interface {
+	ListSubjects(context.Context, *ListSubjectsRequest) (*ListSubjectsResponse, error)
+	GetSubject(context.Context, *GetSubjectRequest) (*Subject, error)
+	CreateSubject(context.Context, *CreateSubjectRequest) (*Subject, error)
+	// UpdateSubject - the subject can not be moved under itself or its descendants
+	UpdateSubject(context.Context, *UpdateSubjectRequest) (*Subject, error)
+	// DeleteSubject - fails with FAILED_PRECONDITION if other subjects are placed under it
+	DeleteSubject(context.Context, *DeleteSubjectRequest) (*DeleteSubjectResponse, error)
+	mustEmbedUnimplementedSubjectServiceServer()
+}
+
+// UnimplementedSubjectServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSubjectServiceServer struct {
+}
+
+func (UnimplementedSubjectServiceServer) ListSubjects(context.Context, *ListSubjectsRequest) (*ListSubjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubjects not implemented")
+}
+func (UnimplementedSubjectServiceServer) GetSubject(context.Context, *GetSubjectRequest) (*Subject, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSubject not implemented")
+}
+func (UnimplementedSubjectServiceServer) CreateSubject(context.Context, *CreateSubjectRequest) (*Subject, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSubject not implemented")
+}
+func (UnimplementedSubjectServiceServer) UpdateSubject(context.Context, *UpdateSubjectRequest) (*Subject, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSubject not implemented")
+}
+func (UnimplementedSubjectServiceServer) DeleteSubject(context.Context, *DeleteSubjectRequest) (*DeleteSubjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSubject not implemented")
+}
+func (UnimplementedSubjectServiceServer) mustEmbedUnimplementedSubjectServiceServer() {}
+
+// UnsafeSubjectServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SubjectServiceServer will
+// result in compilation errors.
+type UnsafeSubjectServiceServer interface {
+	mustEmbedUnimplementedSubjectServiceServer()
+}
+
+func RegisterSubjectServiceServer(s grpc.ServiceRegistrar, srv SubjectServiceServer) {
+	s.RegisterService(&SubjectService_ServiceDesc, srv)
+}
+
+func _SubjectService_ListSubjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubjectServiceServer).ListSubjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubjectService_ListSubjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubjectServiceServer).ListSubjects(ctx, req.(*ListSubjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubjectService_GetSubject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSubjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubjectServiceServer).GetSubject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubjectService_GetSubject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubjectServiceServer).GetSubject(ctx, req.(*GetSubjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubjectService_CreateSubject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSubjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubjectServiceServer).CreateSubject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubjectService_CreateSubject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubjectServiceServer).CreateSubject(ctx, req.(*CreateSubjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubjectService_UpdateSubject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSubjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubjectServiceServer).UpdateSubject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubjectService_UpdateSubject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubjectServiceServer).UpdateSubject(ctx, req.(*UpdateSubjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubjectService_DeleteSubject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSubjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubjectServiceServer).DeleteSubject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubjectService_DeleteSubject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubjectServiceServer).DeleteSubject(ctx, req.(*DeleteSubjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SubjectService_ServiceDesc is the grpc.ServiceDesc for SubjectService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SubjectService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "eventmap.v1.SubjectService",
+	HandlerType: (*SubjectServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSubjects",
+			Handler:    _SubjectService_ListSubjects_Handler,
+		},
+		{
+			MethodName: "GetSubject",
+			Handler:    _SubjectService_GetSubject_Handler,
+		},
+		{
+			MethodName: "CreateSubject",
+			Handler:    _SubjectService_CreateSubject_Handler,
+		},
+		{
+			MethodName: "UpdateSubject",
+			Handler:    _SubjectService_UpdateSubject_Handler,
+		},
+		{
+			MethodName: "DeleteSubject",
+			Handler:    _SubjectService_DeleteSubject_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "eventmap/v1/subject.proto",
+}
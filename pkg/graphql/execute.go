@@ -0,0 +1,469 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+/*
+
+File contains the execution of the operations. The fields are resolved level by level:
+a field is resolved for all objects of the level at once, so N events need one call
+to load their organizers, not N calls.
+
+*/
+
+// listSize - the expected number of items of a list field, it multiplies the complexity of the list's fields
+const listSize = 10
+
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type Response struct {
+	// Data - nil if the request has failed before the execution
+	Data   *OrderedObject `json:"data,omitempty"`
+	Errors []Error        `json:"errors,omitempty"`
+}
+
+type Error struct {
+	Message string `json:"message"`
+	// Path - response keys of the field, that has failed
+	Path []string `json:"path,omitempty"`
+	// Extensions - details of the error given by ExtendedError
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// ExtendedError - an error of a resolver with details for the clients, e.g. a code of the error
+type ExtendedError interface {
+	error
+	Extensions() map[string]interface{}
+}
+
+func fieldError(err error, path []string) Error {
+	result := Error{Message: err.Error(), Path: path}
+	if extended, ok := err.(ExtendedError); ok {
+		result.Extensions = extended.Extensions()
+	}
+	return result
+}
+
+// Limits - the operations exceeding the limits are rejected before the execution, zero limits are not checked
+type Limits struct {
+	// MaxDepth - the maximum nesting of the selections
+	MaxDepth int
+	// MaxComplexity - the maximum number of the resolved fields, every field of a list counts listSize times
+	MaxComplexity int
+}
+
+// OrderedObject - a JSON object with the keys in the order of the selections
+type OrderedObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedObject() *OrderedObject {
+	return &OrderedObject{values: make(map[string]interface{})}
+}
+
+func (o *OrderedObject) set(key string, value interface{}) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// Get - returns the value of the key, it's used to read the results in Go
+func (o *OrderedObject) Get(key string) interface{} {
+	return o.values[key]
+}
+
+func (o *OrderedObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, k := range o.keys {
+		if i != 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		b.Write(key)
+		b.WriteByte(':')
+		value, err := json.Marshal(o.values[k])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+type executor struct {
+	schema    *Schema
+	document  *document
+	variables map[string]interface{}
+	errors    []Error
+}
+
+// Execute - runs the operation of the request, errors of the fields are returned with the data of the other fields
+func (s *Schema) Execute(ctx context.Context, request Request, limits Limits) Response {
+	doc, err := parse(request.Query)
+	if err != nil {
+		return failed(err.Error())
+	}
+
+	op, err := selectOperation(doc, request.OperationName)
+	if err != nil {
+		return failed(err.Error())
+	}
+
+	root := s.Query
+	if op.kind == "mutation" {
+		if s.Mutation == nil {
+			return failed("the schema has no mutations")
+		}
+		root = s.Mutation
+	}
+
+	e := &executor{schema: s, document: doc, variables: make(map[string]interface{})}
+	for _, v := range op.variables {
+		value, ok := request.Variables[v.name]
+		if !ok {
+			value = v.defaultValue
+		}
+		if value == nil && v.nonNull {
+			return failed(fmt.Sprintf("variable $%s is required", v.name))
+		}
+		e.variables[v.name] = value
+	}
+
+	if _, err := e.validate(root, op.selections, 1, limits); err != nil {
+		return failed(err.Error())
+	}
+
+	results := e.executeObjects(ctx, root, []interface{}{nil}, op.selections, nil)
+	return Response{Data: results[0], Errors: e.errors}
+}
+
+func failed(message string) Response {
+	return Response{Errors: []Error{{Message: message}}}
+}
+
+func selectOperation(doc *document, name string) (*operation, error) {
+	if name == "" {
+		if len(doc.operations) != 1 {
+			return nil, fmt.Errorf("operationName is required for a document with several operations")
+		}
+		return doc.operations[0], nil
+	}
+	for _, op := range doc.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %s", name)
+}
+
+// collectedField - the fields with the same response key, their selections are merged
+type collectedField struct {
+	key   string
+	nodes []*fieldNode
+}
+
+func (c collectedField) selections() []selection {
+	if len(c.nodes) == 1 {
+		return c.nodes[0].selections
+	}
+	merged := make([]selection, 0)
+	for _, n := range c.nodes {
+		merged = append(merged, n.selections...)
+	}
+	return merged
+}
+
+// collectFields - the fields of the selections in the order of their first appearance,
+// fragments are expanded and the fields excluded by @skip and @include are dropped
+func (e *executor) collectFields(t *Object, selections []selection) ([]collectedField, error) {
+	result := make([]collectedField, 0)
+	index := make(map[string]int)
+	visited := make(map[string]bool)
+
+	var collect func(selections []selection) error
+	collect = func(selections []selection) error {
+		for _, s := range selections {
+			switch s := s.(type) {
+			case *fieldNode:
+				included, err := e.included(s.directives)
+				if err != nil {
+					return err
+				}
+				if !included {
+					continue
+				}
+				key := s.responseKey()
+				if i, ok := index[key]; ok {
+					if result[i].nodes[0].name != s.name {
+						return fmt.Errorf("fields %s and %s can not have the same response key %s", result[i].nodes[0].name, s.name, key)
+					}
+					result[i].nodes = append(result[i].nodes, s)
+					continue
+				}
+				index[key] = len(result)
+				result = append(result, collectedField{key: key, nodes: []*fieldNode{s}})
+			case *inlineFragment:
+				included, err := e.included(s.directives)
+				if err != nil {
+					return err
+				}
+				if !included {
+					continue
+				}
+				if s.typeCondition != "" && s.typeCondition != t.Name {
+					return fmt.Errorf("fragment on %s can not be spread in %s", s.typeCondition, t.Name)
+				}
+				if err := collect(s.selections); err != nil {
+					return err
+				}
+			case *fragmentSpread:
+				included, err := e.included(s.directives)
+				if err != nil {
+					return err
+				}
+				if !included {
+					continue
+				}
+				f, ok := e.document.fragments[s.name]
+				if !ok {
+					return fmt.Errorf("unknown fragment %s", s.name)
+				}
+				if f.typeCondition != t.Name {
+					return fmt.Errorf("fragment %s on %s can not be spread in %s", f.name, f.typeCondition, t.Name)
+				}
+				if visited[s.name] {
+					continue
+				}
+				visited[s.name] = true
+				// a fragment is expanded once per selection set, even if it's spread in several places,
+				// so the fragments spreading each other many times can not make the collection exponential
+				if err := collect(f.selections); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	return result, collect(selections)
+}
+
+func (e *executor) included(directives []directiveNode) (bool, error) {
+	for _, d := range directives {
+		if d.name != "skip" && d.name != "include" {
+			return false, fmt.Errorf("unknown directive @%s", d.name)
+		}
+		args, err := e.arguments([]*Argument{{Name: "if", Type: &NonNull{Of: Boolean}}}, d.arguments)
+		if err != nil {
+			return false, fmt.Errorf("@%s: %w", d.name, err)
+		}
+		if args["if"].(bool) == (d.name == "skip") {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (e *executor) arguments(definitions []*Argument, nodes []argumentNode) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(nodes))
+	for _, a := range nodes {
+		values[a.name] = resolveVariables(a.value, e.variables)
+	}
+	return coerceFields(definitions, values)
+}
+
+// validate - checks the selections by the schema and the limits, returns the complexity of the selections.
+// It stops as soon as the complexity exceeds the limit, so the aliased copies of a field
+// can not make the validation itself expensive
+func (e *executor) validate(t *Object, selections []selection, depth int, limits Limits) (int, error) {
+	if limits.MaxDepth > 0 && depth > limits.MaxDepth {
+		return 0, fmt.Errorf("the query is too deep, the limit is %d", limits.MaxDepth)
+	}
+
+	fields, err := e.collectFields(t, selections)
+	if err != nil {
+		return 0, err
+	}
+
+	complexity := 0
+	for _, f := range fields {
+		node := f.nodes[0]
+		if node.name == "__typename" {
+			complexity++
+			continue
+		}
+
+		definition := t.Field(node.name)
+		if definition == nil {
+			return 0, fmt.Errorf("unknown field %s of %s", node.name, t.Name)
+		}
+		if _, err := e.arguments(definition.Args, node.arguments); err != nil {
+			return 0, fmt.Errorf("%s.%s: %w", t.Name, node.name, err)
+		}
+
+		object, isObject := namedType(definition.Type).(*Object)
+		selections := f.selections()
+		switch {
+		case isObject && len(selections) == 0:
+			return 0, fmt.Errorf("%s.%s of type %s should have a selection of fields", t.Name, node.name, definition.Type)
+		case !isObject && len(selections) != 0:
+			return 0, fmt.Errorf("%s.%s of type %s can not have a selection of fields", t.Name, node.name, definition.Type)
+		case !isObject:
+			complexity++
+			if limits.MaxComplexity > 0 && complexity > limits.MaxComplexity {
+				return 0, fmt.Errorf("the query is too complex, the limit is %d", limits.MaxComplexity)
+			}
+			continue
+		}
+
+		nested, err := e.validate(object, selections, depth+1, limits)
+		if err != nil {
+			return 0, err
+		}
+		if isList(definition.Type) {
+			nested *= listSize
+		}
+		complexity += 1 + nested
+		if limits.MaxComplexity > 0 && complexity > limits.MaxComplexity {
+			return 0, fmt.Errorf("the query is too complex, the limit is %d", limits.MaxComplexity)
+		}
+	}
+	return complexity, nil
+}
+
+func isList(t Type) bool {
+	if n, ok := t.(*NonNull); ok {
+		t = n.Of
+	}
+	_, ok := t.(*List)
+	return ok
+}
+
+// executeObjects - resolves the selections of all parents of the type, the result has an object for every parent
+func (e *executor) executeObjects(ctx context.Context, t *Object, parents []interface{}, selections []selection, path []string) []*OrderedObject {
+	results := make([]*OrderedObject, len(parents))
+	for i := range results {
+		results[i] = newOrderedObject()
+	}
+
+	// the selections are validated, so there are no errors
+	fields, _ := e.collectFields(t, selections)
+	for _, f := range fields {
+		node := f.nodes[0]
+		if node.name == "__typename" {
+			for _, r := range results {
+				r.set(f.key, t.Name)
+			}
+			continue
+		}
+
+		fieldPath := append(append([]string{}, path...), f.key)
+		definition := t.Field(node.name)
+		args, _ := e.arguments(definition.Args, node.arguments)
+
+		values, err := definition.Resolve(ctx, parents, args)
+		if err == nil && len(values) != len(parents) {
+			err = fmt.Errorf("the field has resolved %d values for %d objects", len(values), len(parents))
+		}
+		if err != nil {
+			e.errors = append(e.errors, fieldError(err, fieldPath))
+			for _, r := range results {
+				r.set(f.key, nil)
+			}
+			continue
+		}
+
+		completed := e.complete(ctx, definition.Type, values, f.selections(), fieldPath)
+		for i, r := range results {
+			r.set(f.key, completed[i])
+		}
+	}
+	return results
+}
+
+// complete - converts the resolved values to the values of the response by the type
+func (e *executor) complete(ctx context.Context, t Type, values []interface{}, selections []selection, path []string) []interface{} {
+	result := make([]interface{}, len(values))
+
+	switch t := t.(type) {
+	case *NonNull:
+		return e.complete(ctx, t.Of, values, selections, path)
+
+	case *Scalar:
+		for i, v := range values {
+			if !isNull(v) {
+				result[i] = t.Serialize(v)
+			}
+		}
+
+	case *List:
+		// the items of all lists are completed together, then they're split back
+		items := make([]interface{}, 0)
+		sizes := make([]int, len(values))
+		for i, v := range values {
+			if v == nil {
+				sizes[i] = -1
+				continue
+			}
+			list := reflect.ValueOf(v)
+			if list.Kind() != reflect.Slice {
+				sizes[i] = -1
+				e.errors = append(e.errors, Error{Message: "the resolved value is not a list", Path: path})
+				continue
+			}
+			sizes[i] = list.Len()
+			for j := 0; j < list.Len(); j++ {
+				items = append(items, list.Index(j).Interface())
+			}
+		}
+
+		completed := e.complete(ctx, t.Of, items, selections, path)
+		offset := 0
+		for i, size := range sizes {
+			if size < 0 {
+				continue
+			}
+			result[i] = completed[offset : offset+size]
+			offset += size
+		}
+
+	case *Object:
+		parents := make([]interface{}, 0, len(values))
+		for _, v := range values {
+			if !isNull(v) {
+				parents = append(parents, v)
+			}
+		}
+		objects := e.executeObjects(ctx, t, parents, selections, path)
+		next := 0
+		for i, v := range values {
+			if !isNull(v) {
+				result[i] = objects[next]
+				next++
+			}
+		}
+	}
+	return result
+}
+
+// isNull - reports if the resolved value is null: nil or a nil pointer
+func isNull(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	r := reflect.ValueOf(v)
+	return r.Kind() == reflect.Ptr && r.IsNil()
+}
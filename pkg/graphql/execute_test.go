@@ -0,0 +1,287 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testBook struct {
+	title  string
+	author string
+}
+
+// testSchema - books and their authors, every author has written all books,
+// so the queries can be nested as deep as needed
+func testSchema() *Schema {
+	books := []testBook{{title: "Dune", author: "Herbert"}, {title: "Solaris", author: "Lem"}}
+
+	book := &Object{Name: "Book"}
+	author := &Object{Name: "Author"}
+	author.Fields = []*Field{
+		{Name: "name", Type: &NonNull{Of: String}, Resolve: Property(func(p interface{}) interface{} { return p })},
+		{Name: "books", Type: ListOf(book), Resolve: Property(func(interface{}) interface{} { return books })},
+	}
+	book.Fields = []*Field{
+		{Name: "title", Type: &NonNull{Of: String}, Resolve: Property(func(p interface{}) interface{} { return p.(testBook).title })},
+		{Name: "author", Type: author, Resolve: Property(func(p interface{}) interface{} { return p.(testBook).author })},
+		{Name: "broken", Type: String, Resolve: ResolveEach(
+			func(context.Context, interface{}, map[string]interface{}) (interface{}, error) {
+				return nil, errors.New("broken")
+			})},
+	}
+
+	query := &Object{Name: "Query", Fields: []*Field{
+		{Name: "hello", Type: &NonNull{Of: String}, Args: []*Argument{{Name: "name", Type: String}},
+			Resolve: ResolveEach(func(_ context.Context, _ interface{}, args map[string]interface{}) (interface{}, error) {
+				name, _ := args["name"].(string)
+				if name == "" {
+					name = "world"
+				}
+				return "hello " + name, nil
+			})},
+		{Name: "book", Type: book, Args: []*Argument{{Name: "index", Type: &NonNull{Of: Int}}},
+			Resolve: ResolveEach(func(_ context.Context, _ interface{}, args map[string]interface{}) (interface{}, error) {
+				i := args["index"].(int)
+				if i < 0 || i >= len(books) {
+					return nil, nil
+				}
+				return books[i], nil
+			})},
+		{Name: "books", Type: ListOf(book), Resolve: Property(func(interface{}) interface{} { return books })},
+	}}
+
+	return &Schema{Query: query}
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		// response - the response in JSON
+		response string
+	}{
+		{
+			name:     "shorthand query",
+			query:    `{ hello }`,
+			response: `{"data":{"hello":"hello world"}}`,
+		},
+		{
+			name:     "arguments, aliases and comments",
+			query:    "query Greet { # a comment\n a: hello(name: \"you\"), b: hello }",
+			response: `{"data":{"a":"hello you","b":"hello world"}}`,
+		},
+		{
+			name:     "nested objects and lists",
+			query:    `{ book(index: 1) { title author { name } } books { title } }`,
+			response: `{"data":{"book":{"title":"Solaris","author":{"name":"Lem"}},"books":[{"title":"Dune"},{"title":"Solaris"}]}}`,
+		},
+		{
+			name:     "missing object is null",
+			query:    `{ book(index: 5) { title } }`,
+			response: `{"data":{"book":null}}`,
+		},
+		{
+			name:      "variables and defaults",
+			query:     `query ($i: Int!, $name: String = "default") { book(index: $i) { title } hello(name: $name) }`,
+			variables: map[string]interface{}{"i": 0},
+			response:  `{"data":{"book":{"title":"Dune"},"hello":"hello default"}}`,
+		},
+		{
+			name:     "required variable",
+			query:    `query ($i: Int!) { book(index: $i) { title } }`,
+			response: `{"errors":[{"message":"variable $i is required"}]}`,
+		},
+		{
+			name:     "named and inline fragments are merged",
+			query:    `{ book(index: 0) { ...Title ... on Book { author { name } } title } } fragment Title on Book { title }`,
+			response: `{"data":{"book":{"title":"Dune","author":{"name":"Herbert"}}}}`,
+		},
+		{
+			name:      "skip and include",
+			query:     `query ($on: Boolean!) { a: hello @skip(if: $on) b: hello @include(if: $on) c: hello @include(if: false) }`,
+			variables: map[string]interface{}{"on": true},
+			response:  `{"data":{"b":"hello world"}}`,
+		},
+		{
+			name:     "typename",
+			query:    `{ __typename book(index: 0) { __typename } }`,
+			response: `{"data":{"__typename":"Query","book":{"__typename":"Book"}}}`,
+		},
+		{
+			name:     "error of a field keeps the other fields",
+			query:    `{ book(index: 0) { title broken } }`,
+			response: `{"data":{"book":{"title":"Dune","broken":null}},"errors":[{"message":"broken","path":["book","broken"]}]}`,
+		},
+		{
+			name:     "unknown field",
+			query:    `{ book(index: 0) { pages } }`,
+			response: `{"errors":[{"message":"unknown field pages of Book"}]}`,
+		},
+		{
+			name:     "object without selection",
+			query:    `{ book(index: 0) }`,
+			response: `{"errors":[{"message":"Query.book of type Book should have a selection of fields"}]}`,
+		},
+		{
+			name:     "unknown fragment",
+			query:    `{ book(index: 0) { ...Missing } }`,
+			response: `{"errors":[{"message":"unknown fragment Missing"}]}`,
+		},
+		{
+			name:     "fragment on another type",
+			query:    `{ book(index: 0) { ...Name } } fragment Name on Author { name }`,
+			response: `{"errors":[{"message":"fragment Name on Author can not be spread in Book"}]}`,
+		},
+		{
+			name:     "unknown directive",
+			query:    `{ hello @deprecated }`,
+			response: `{"errors":[{"message":"unknown directive @deprecated"}]}`,
+		},
+		{
+			name:     "fragments spreading each other",
+			query:    `{ book(index: 0) { ...A } } fragment A on Book { title ...B } fragment B on Book { ...A }`,
+			response: `{"data":{"book":{"title":"Dune"}}}`,
+		},
+	}
+
+	schema := testSchema()
+	for _, tt := range tests {
+		response := schema.Execute(context.Background(), Request{Query: tt.query, Variables: tt.variables}, Limits{})
+		got, err := json.Marshal(response)
+		if err != nil {
+			t.Errorf("%s: failed to marshal the response: %v", tt.name, err)
+			continue
+		}
+		if string(got) != tt.response {
+			t.Errorf("%s: response = %s, want %s", tt.name, got, tt.response)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name, query, message string
+	}{
+		{"empty document", ``, "the document has no operations"},
+		{"unclosed selection set", `{ hello`, "unexpected end of the document"},
+		{"empty selection set", `{ }`, "empty selection set"},
+		{"unterminated string", `{ hello(name: "you) }`, "unterminated string"},
+		{"fragment defined twice", `{ hello } fragment A on Query { hello } fragment A on Query { hello }`, "fragment A is defined twice"},
+		{"variable in a default value", `query ($a: String = $b) { hello }`, "variables are not allowed here"},
+		{"nested selections", strings.Repeat("{ book(index: 0) ", 100), "the document is nested too deeply"},
+		{"nested lists", `{ hello(name: ` + strings.Repeat("[", 100) + `) }`, "the document is nested too deeply"},
+		{"nested types", `query ($a: ` + strings.Repeat("[", 100) + `) { hello }`, "the document is nested too deeply"},
+	}
+
+	for _, tt := range tests {
+		_, err := parse(tt.query)
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("%s: parse = %v, want a syntax error", tt.name, err)
+			continue
+		}
+		if !strings.Contains(syntaxErr.Message, tt.message) {
+			t.Errorf("%s: parse = %q, want %q", tt.name, syntaxErr.Message, tt.message)
+		}
+	}
+}
+
+func TestExecuteLimits(t *testing.T) {
+	limits := Limits{MaxDepth: 3, MaxComplexity: 50}
+
+	tests := []struct {
+		name  string
+		query string
+		// message - the error of the query, empty if the query is within the limits
+		message string
+	}{
+		{
+			name:  "within the limits",
+			query: `{ books { title author { name } } }`,
+		},
+		{
+			name:    "too deep",
+			query:   `{ books { author { books { title } } } }`,
+			message: "the query is too deep, the limit is 3",
+		},
+		{
+			name:    "too deep through a fragment",
+			query:   `{ books { ...Deep } } fragment Deep on Book { author { books { title } } }`,
+			message: "the query is too deep, the limit is 3",
+		},
+		{
+			// every field of the list is counted 10 times
+			name:    "too complex",
+			query:   `{ books { title author { name } } a: books { title } b: books { title } }`,
+			message: "the query is too complex, the limit is 50",
+		},
+	}
+
+	schema := testSchema()
+	for _, tt := range tests {
+		response := schema.Execute(context.Background(), Request{Query: tt.query}, limits)
+		switch {
+		case tt.message == "" && len(response.Errors) != 0:
+			t.Errorf("%s: errors = %v, want none", tt.name, response.Errors)
+		case tt.message != "" && (len(response.Errors) != 1 || response.Errors[0].Message != tt.message):
+			t.Errorf("%s: errors = %v, want %q", tt.name, response.Errors, tt.message)
+		case tt.message != "" && response.Data != nil:
+			t.Errorf("%s: the query has been executed", tt.name)
+		}
+	}
+}
+
+// the fragments spreading the next one twice are expanded 2^n times, if a fragment is collected on every spread
+func TestFragmentBomb(t *testing.T) {
+	const fragments = 40
+
+	var b strings.Builder
+	b.WriteString("{ book(index: 0) { ...F0 } }\n")
+	for i := 0; i < fragments; i++ {
+		fmt.Fprintf(&b, "fragment F%d on Book { title ...F%d ...F%d }\n", i, i+1, i+1)
+	}
+	fmt.Fprintf(&b, "fragment F%d on Book { title }\n", fragments)
+
+	start := time.Now()
+	response := testSchema().Execute(context.Background(), Request{Query: b.String()}, Limits{MaxDepth: 10, MaxComplexity: 2000})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("the fragment bomb has taken %s", elapsed)
+	}
+
+	got, _ := json.Marshal(response)
+	if want := `{"data":{"book":{"title":"Dune"}}}`; string(got) != want {
+		t.Errorf("response = %s, want %s", got, want)
+	}
+}
+
+// the aliased copies of a field spreading the next fragment multiply the work of the validation,
+// it should stop at the complexity limit
+func TestAliasBomb(t *testing.T) {
+	const fragments = 10
+
+	var b strings.Builder
+	b.WriteString("{ books { ...F0 } }\n")
+	for i := 0; i < fragments; i++ {
+		fmt.Fprintf(&b, "fragment F%d on Book {", i)
+		for j := 0; j < 10; j++ {
+			fmt.Fprintf(&b, " a%d: author { books { ...F%d } }", j, i+1)
+		}
+		b.WriteString(" }\n")
+	}
+	fmt.Fprintf(&b, "fragment F%d on Book { title }\n", fragments)
+
+	start := time.Now()
+	response := testSchema().Execute(context.Background(), Request{Query: b.String()}, Limits{MaxComplexity: 2000})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("the alias bomb has taken %s", elapsed)
+	}
+	if len(response.Errors) != 1 || response.Errors[0].Message != "the query is too complex, the limit is 2000" {
+		t.Errorf("errors = %v, want the complexity error", response.Errors)
+	}
+}
@@ -0,0 +1,541 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+
+File contains a parser of executable GraphQL documents: operations with variables,
+fields with aliases and arguments, fragments and directives. Type system definitions are not supported.
+
+*/
+
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	// kind - "query" or "mutation"
+	kind       string
+	name       string
+	variables  []variableDefinition
+	selections []selection
+}
+
+type variableDefinition struct {
+	name         string
+	nonNull      bool
+	defaultValue interface{}
+}
+
+type fragment struct {
+	name          string
+	typeCondition string
+	selections    []selection
+}
+
+type selection interface{}
+
+type fieldNode struct {
+	alias      string
+	name       string
+	arguments  []argumentNode
+	directives []directiveNode
+	selections []selection
+}
+
+// responseKey - the key of the field in the response
+func (f *fieldNode) responseKey() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+type fragmentSpread struct {
+	name       string
+	directives []directiveNode
+}
+
+type inlineFragment struct {
+	typeCondition string
+	directives    []directiveNode
+	selections    []selection
+}
+
+type argumentNode struct {
+	name  string
+	value interface{}
+}
+
+type directiveNode struct {
+	name      string
+	arguments []argumentNode
+}
+
+// Values of the document are Go values: int, float64, string, bool, nil,
+// []interface{} for lists and these types for the others
+type (
+	variableValue string
+	enumValue     string
+	objectValue   []argumentNode
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// maxNesting - the maximum nesting of the selection sets, lists, input objects and types of a document,
+// the parser is recursive, so the documents nested deeper are rejected before they exhaust the stack
+const maxNesting = 64
+
+type parser struct {
+	source string
+	pos    int
+	token  token
+	// nesting - the nesting of the current token
+	nesting int
+}
+
+// SyntaxError - the document is not a valid GraphQL document
+type SyntaxError struct {
+	Message string
+	Pos     int
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at %d: %s", e.Pos, e.Message)
+}
+
+func parse(source string) (doc *document, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			syntaxErr, ok := r.(*SyntaxError)
+			if !ok {
+				panic(r)
+			}
+			err = syntaxErr
+		}
+	}()
+
+	p := &parser{source: source}
+	p.next()
+
+	doc = &document{fragments: make(map[string]*fragment)}
+	for p.token.kind != tokenEOF {
+		switch {
+		case p.peek(tokenPunctuator, "{"):
+			doc.operations = append(doc.operations, &operation{kind: "query", selections: p.selectionSet()})
+		case p.peek(tokenName, "query"), p.peek(tokenName, "mutation"):
+			doc.operations = append(doc.operations, p.operation())
+		case p.peek(tokenName, "fragment"):
+			f := p.fragment()
+			if _, exists := doc.fragments[f.name]; exists {
+				p.fail("fragment " + f.name + " is defined twice")
+			}
+			doc.fragments[f.name] = f
+		default:
+			p.fail("unexpected " + p.token.value)
+		}
+	}
+
+	if len(doc.operations) == 0 {
+		p.fail("the document has no operations")
+	}
+	return doc, nil
+}
+
+func (p *parser) fail(message string) {
+	panic(&SyntaxError{Message: message, Pos: p.token.pos})
+}
+
+// enter - starts a nested part of the document, leave ends it
+func (p *parser) enter() {
+	p.nesting++
+	if p.nesting > maxNesting {
+		p.fail("the document is nested too deeply")
+	}
+}
+
+func (p *parser) leave() {
+	p.nesting--
+}
+
+func (p *parser) peek(kind tokenKind, value string) bool {
+	return p.token.kind == kind && p.token.value == value
+}
+
+// skip - moves to the next token if the current one is the punctuator
+func (p *parser) skip(value string) bool {
+	if p.peek(tokenPunctuator, value) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(value string) {
+	if !p.skip(value) {
+		p.fail("expected " + value + ", got " + strconv.Quote(p.token.value))
+	}
+}
+
+func (p *parser) name() string {
+	if p.token.kind != tokenName {
+		p.fail("expected a name, got " + strconv.Quote(p.token.value))
+	}
+	name := p.token.value
+	p.next()
+	return name
+}
+
+func (p *parser) operation() *operation {
+	op := &operation{kind: p.name()}
+	if p.token.kind == tokenName {
+		op.name = p.name()
+	}
+
+	if p.skip("(") {
+		for !p.skip(")") {
+			p.expect("$")
+			v := variableDefinition{name: p.name()}
+			p.expect(":")
+			v.nonNull = p.typeReference()
+			if p.skip("=") {
+				v.defaultValue = p.value(true)
+			}
+			op.variables = append(op.variables, v)
+		}
+	}
+
+	p.directives()
+	op.selections = p.selectionSet()
+	return op
+}
+
+// typeReference - skips the type of the variable, the values are checked by the types of the arguments,
+// returns if the type is non-null
+func (p *parser) typeReference() bool {
+	if p.skip("[") {
+		p.enter()
+		p.typeReference()
+		p.expect("]")
+		p.leave()
+	} else {
+		p.name()
+	}
+	return p.skip("!")
+}
+
+func (p *parser) fragment() *fragment {
+	p.next()
+	f := &fragment{name: p.name()}
+	if p.name() != "on" {
+		p.fail("expected on")
+	}
+	f.typeCondition = p.name()
+	p.directives()
+	f.selections = p.selectionSet()
+	return f
+}
+
+func (p *parser) selectionSet() []selection {
+	p.expect("{")
+	p.enter()
+	defer p.leave()
+	selections := make([]selection, 0)
+	for !p.skip("}") {
+		if p.token.kind == tokenEOF {
+			p.fail("unexpected end of the document")
+		}
+		selections = append(selections, p.selection())
+	}
+	if len(selections) == 0 {
+		p.fail("empty selection set")
+	}
+	return selections
+}
+
+func (p *parser) selection() selection {
+	if p.skip("...") {
+		if p.token.kind == tokenName && p.token.value != "on" {
+			return &fragmentSpread{name: p.name(), directives: p.directives()}
+		}
+		f := &inlineFragment{}
+		if p.token.kind == tokenName {
+			p.next()
+			f.typeCondition = p.name()
+		}
+		f.directives = p.directives()
+		f.selections = p.selectionSet()
+		return f
+	}
+
+	f := &fieldNode{name: p.name()}
+	if p.skip(":") {
+		f.alias = f.name
+		f.name = p.name()
+	}
+	f.arguments = p.arguments(false)
+	f.directives = p.directives()
+	if p.peek(tokenPunctuator, "{") {
+		f.selections = p.selectionSet()
+	}
+	return f
+}
+
+func (p *parser) arguments(constant bool) []argumentNode {
+	if !p.skip("(") {
+		return nil
+	}
+	arguments := make([]argumentNode, 0)
+	for !p.skip(")") {
+		a := argumentNode{name: p.name()}
+		p.expect(":")
+		a.value = p.value(constant)
+		arguments = append(arguments, a)
+	}
+	return arguments
+}
+
+func (p *parser) directives() []directiveNode {
+	directives := make([]directiveNode, 0)
+	for p.skip("@") {
+		directives = append(directives, directiveNode{name: p.name(), arguments: p.arguments(false)})
+	}
+	return directives
+}
+
+// value - parses a value, variables are not allowed in the constant values(defaults of the variables)
+func (p *parser) value(constant bool) interface{} {
+	t := p.token
+	switch t.kind {
+	case tokenInt:
+		p.next()
+		n, err := strconv.Atoi(t.value)
+		if err != nil {
+			p.fail("invalid integer " + t.value)
+		}
+		return n
+	case tokenFloat:
+		p.next()
+		f, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			p.fail("invalid float " + t.value)
+		}
+		return f
+	case tokenString:
+		p.next()
+		return t.value
+	case tokenName:
+		p.next()
+		switch t.value {
+		case "true":
+			return true
+		case "false":
+			return false
+		case "null":
+			return nil
+		}
+		return enumValue(t.value)
+	}
+
+	switch {
+	case p.skip("$"):
+		if constant {
+			p.fail("variables are not allowed here")
+		}
+		return variableValue(p.name())
+	case p.skip("["):
+		p.enter()
+		defer p.leave()
+		list := make([]interface{}, 0)
+		for !p.skip("]") {
+			list = append(list, p.value(constant))
+		}
+		return list
+	case p.skip("{"):
+		p.enter()
+		defer p.leave()
+		object := make(objectValue, 0)
+		for !p.skip("}") {
+			field := argumentNode{name: p.name()}
+			p.expect(":")
+			field.value = p.value(constant)
+			object = append(object, field)
+		}
+		return object
+	}
+
+	p.fail("unexpected " + strconv.Quote(t.value))
+	return nil
+}
+
+// next - reads the next token, white spaces, commas and comments are skipped
+func (p *parser) next() {
+	for p.pos < len(p.source) {
+		c := p.source[p.pos]
+		if c == '#' {
+			for p.pos < len(p.source) && p.source[p.pos] != '\n' {
+				p.pos++
+			}
+			continue
+		}
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' && c != ',' {
+			break
+		}
+		p.pos++
+	}
+
+	start := p.pos
+	if p.pos >= len(p.source) {
+		p.token = token{kind: tokenEOF, pos: start}
+		return
+	}
+
+	c := p.source[p.pos]
+	switch {
+	case strings.HasPrefix(p.source[p.pos:], "..."):
+		p.pos += 3
+		p.token = token{kind: tokenPunctuator, value: "...", pos: start}
+	case strings.IndexByte("!$():=@[]{}|", c) >= 0:
+		p.pos++
+		p.token = token{kind: tokenPunctuator, value: string(c), pos: start}
+	case c == '_' || isLetter(c):
+		for p.pos < len(p.source) && (p.source[p.pos] == '_' || isLetter(p.source[p.pos]) || isDigit(p.source[p.pos])) {
+			p.pos++
+		}
+		p.token = token{kind: tokenName, value: p.source[start:p.pos], pos: start}
+	case c == '-' || isDigit(c):
+		p.number()
+	case c == '"':
+		p.string()
+	default:
+		p.token = token{kind: tokenPunctuator, value: string(c), pos: start}
+		p.fail("unexpected character " + strconv.QuoteRune(rune(c)))
+	}
+}
+
+func (p *parser) number() {
+	start := p.pos
+	kind := tokenInt
+	if p.source[p.pos] == '-' {
+		p.pos++
+	}
+	digits := func() {
+		for p.pos < len(p.source) && isDigit(p.source[p.pos]) {
+			p.pos++
+		}
+	}
+	digits()
+	if p.pos < len(p.source) && p.source[p.pos] == '.' {
+		kind = tokenFloat
+		p.pos++
+		digits()
+	}
+	if p.pos < len(p.source) && (p.source[p.pos] == 'e' || p.source[p.pos] == 'E') {
+		kind = tokenFloat
+		p.pos++
+		if p.pos < len(p.source) && (p.source[p.pos] == '+' || p.source[p.pos] == '-') {
+			p.pos++
+		}
+		digits()
+	}
+	p.token = token{kind: kind, value: p.source[start:p.pos], pos: start}
+}
+
+func (p *parser) string() {
+	start := p.pos
+
+	if strings.HasPrefix(p.source[p.pos:], `"""`) {
+		end := strings.Index(p.source[p.pos+3:], `"""`)
+		if end < 0 {
+			p.token = token{pos: start}
+			p.fail("unterminated string")
+		}
+		value := p.source[p.pos+3 : p.pos+3+end]
+		p.pos += end + 6
+		p.token = token{kind: tokenString, value: strings.TrimSpace(value), pos: start}
+		return
+	}
+
+	var b strings.Builder
+	p.pos++
+	for {
+		if p.pos >= len(p.source) || p.source[p.pos] == '\n' {
+			p.token = token{pos: start}
+			p.fail("unterminated string")
+		}
+		c := p.source[p.pos]
+		if c == '"' {
+			p.pos++
+			break
+		}
+		if c != '\\' {
+			r, size := utf8.DecodeRuneInString(p.source[p.pos:])
+			b.WriteRune(r)
+			p.pos += size
+			continue
+		}
+
+		p.pos++
+		if p.pos >= len(p.source) {
+			continue
+		}
+		escaped := p.source[p.pos]
+		p.pos++
+		switch escaped {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u':
+			if p.pos+4 > len(p.source) {
+				p.token = token{pos: start}
+				p.fail("invalid unicode escape")
+			}
+			code, err := strconv.ParseUint(p.source[p.pos:p.pos+4], 16, 32)
+			if err != nil {
+				p.token = token{pos: start}
+				p.fail("invalid unicode escape")
+			}
+			b.WriteRune(rune(code))
+			p.pos += 4
+		default:
+			b.WriteByte(escaped)
+		}
+	}
+	p.token = token{kind: tokenString, value: b.String(), pos: start}
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
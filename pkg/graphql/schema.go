@@ -0,0 +1,215 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+/*
+
+File contains the type system of the schema. Types are built in Go, the schema can be printed
+in the schema definition language(SDL) for the clients.
+
+*/
+
+// Type - *Scalar, *Object, *InputObject, *List or *NonNull
+type Type interface {
+	String() string
+}
+
+// Scalar - a leaf type, Serialize converts a resolved value to its JSON value, Coerce checks an input value
+type Scalar struct {
+	Name      string
+	Serialize func(v interface{}) interface{}
+	// Coerce - returns the input value(int, float64, string or bool) as the value given to the resolvers
+	Coerce func(v interface{}) (interface{}, error)
+}
+
+func (s *Scalar) String() string { return s.Name }
+
+// BatchResolver - resolves the field for all parents at once, so the related objects of a list are loaded together,
+// it returns a value for every parent in the same order
+type BatchResolver func(ctx context.Context, parents []interface{}, args map[string]interface{}) ([]interface{}, error)
+
+// Field - a field of an object, the values of an object type are any values its fields can resolve,
+// the values of a list type are slices
+type Field struct {
+	Name        string
+	Description string
+	Type        Type
+	Args        []*Argument
+	Resolve     BatchResolver
+}
+
+// Argument - an argument of a field or a field of an input object
+type Argument struct {
+	Name        string
+	Description string
+	Type        Type
+}
+
+type Object struct {
+	Name        string
+	Description string
+	Fields      []*Field
+}
+
+func (o *Object) String() string { return o.Name }
+
+// Field - returns the field with the name, nil if there is no such field
+func (o *Object) Field(name string) *Field {
+	for _, f := range o.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// InputObject - a type of the arguments, its values are given to the resolvers as map[string]interface{}
+type InputObject struct {
+	Name        string
+	Description string
+	Fields      []*Argument
+}
+
+func (o *InputObject) String() string { return o.Name }
+
+type List struct {
+	Of Type
+}
+
+func (l *List) String() string { return "[" + l.Of.String() + "]" }
+
+type NonNull struct {
+	Of Type
+}
+
+func (n *NonNull) String() string { return n.Of.String() + "!" }
+
+// ListOf - shortcut for [T!]!
+func ListOf(t Type) Type {
+	return &NonNull{Of: &List{Of: &NonNull{Of: t}}}
+}
+
+// Schema - root types of the operations, Mutation is optional
+type Schema struct {
+	Query    *Object
+	Mutation *Object
+}
+
+// ResolveEach - a resolver of the field of every parent separately, for the fields that need no batching
+func ResolveEach(resolve func(ctx context.Context, parent interface{}, args map[string]interface{}) (interface{}, error)) BatchResolver {
+	return func(ctx context.Context, parents []interface{}, args map[string]interface{}) ([]interface{}, error) {
+		result := make([]interface{}, len(parents))
+		for i, p := range parents {
+			v, err := resolve(ctx, p, args)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = v
+		}
+		return result, nil
+	}
+}
+
+// Property - a resolver of a value of the parent
+func Property(get func(parent interface{}) interface{}) BatchResolver {
+	return func(_ context.Context, parents []interface{}, _ map[string]interface{}) ([]interface{}, error) {
+		result := make([]interface{}, len(parents))
+		for i, p := range parents {
+			result[i] = get(p)
+		}
+		return result, nil
+	}
+}
+
+// SDL - prints the schema in the schema definition language
+func (s *Schema) SDL() string {
+	var b strings.Builder
+
+	b.WriteString("schema {\n  query: " + s.Query.Name + "\n")
+	if s.Mutation != nil {
+		b.WriteString("  mutation: " + s.Mutation.Name + "\n")
+	}
+	b.WriteString("}\n")
+
+	printed := make(map[string]bool)
+	queue := []Type{s.Query}
+	if s.Mutation != nil {
+		queue = append(queue, s.Mutation)
+	}
+
+	for len(queue) != 0 {
+		t := namedType(queue[0])
+		queue = queue[1:]
+		if printed[t.String()] {
+			continue
+		}
+		printed[t.String()] = true
+
+		switch t := t.(type) {
+		case *Object:
+			b.WriteString("\n")
+			writeDescription(&b, "", t.Description)
+			b.WriteString("type " + t.Name + " {\n")
+			for _, f := range t.Fields {
+				writeDescription(&b, "  ", f.Description)
+				b.WriteString("  " + f.Name + printArguments(f.Args) + ": " + f.Type.String() + "\n")
+				queue = append(queue, f.Type)
+				for _, a := range f.Args {
+					queue = append(queue, a.Type)
+				}
+			}
+			b.WriteString("}\n")
+		case *InputObject:
+			b.WriteString("\n")
+			writeDescription(&b, "", t.Description)
+			b.WriteString("input " + t.Name + " {\n")
+			for _, f := range t.Fields {
+				writeDescription(&b, "  ", f.Description)
+				b.WriteString("  " + f.Name + ": " + f.Type.String() + "\n")
+				queue = append(queue, f.Type)
+			}
+			b.WriteString("}\n")
+		case *Scalar:
+			if !builtInScalars[t.Name] {
+				b.WriteString("\nscalar " + t.Name + "\n")
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func printArguments(args []*Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+	printed := make([]string, len(args))
+	for i, a := range args {
+		printed[i] = a.Name + ": " + a.Type.String()
+	}
+	return "(" + strings.Join(printed, ", ") + ")"
+}
+
+func writeDescription(b *strings.Builder, indent, description string) {
+	if description != "" {
+		b.WriteString(indent + fmt.Sprintf("%q", description) + "\n")
+	}
+}
+
+// namedType - the type without List and NonNull wrappers
+func namedType(t Type) Type {
+	for {
+		switch w := t.(type) {
+		case *List:
+			t = w.Of
+		case *NonNull:
+			t = w.Of
+		default:
+			return t
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+/*
+
+File contains the built-in scalars and the coercion of the input values by the types of the arguments.
+
+*/
+
+var (
+	Int = &Scalar{
+		Name:      "Int",
+		Serialize: func(v interface{}) interface{} { return v },
+		Coerce: func(v interface{}) (interface{}, error) {
+			switch n := v.(type) {
+			case int:
+				return n, nil
+			case float64:
+				// numbers of the variables are decoded from JSON as float64
+				if n == math.Trunc(n) && math.Abs(n) <= math.MaxInt32 {
+					return int(n), nil
+				}
+			}
+			return nil, errors.New("should be an integer")
+		},
+	}
+	Float = &Scalar{
+		Name:      "Float",
+		Serialize: func(v interface{}) interface{} { return v },
+		Coerce: func(v interface{}) (interface{}, error) {
+			switch n := v.(type) {
+			case int:
+				return float64(n), nil
+			case float64:
+				return n, nil
+			}
+			return nil, errors.New("should be a number")
+		},
+	}
+	String = &Scalar{
+		Name:      "String",
+		Serialize: func(v interface{}) interface{} { return fmt.Sprint(v) },
+		Coerce:    coerceString("should be a string"),
+	}
+	Boolean = &Scalar{
+		Name:      "Boolean",
+		Serialize: func(v interface{}) interface{} { return v },
+		Coerce: func(v interface{}) (interface{}, error) {
+			if b, ok := v.(bool); ok {
+				return b, nil
+			}
+			return nil, errors.New("should be a boolean")
+		},
+	}
+	// ID - resolved values are serialized by fmt.Sprint, so any fmt.Stringer(e.g. uuid.UUID) can be an ID
+	ID = &Scalar{
+		Name:      "ID",
+		Serialize: func(v interface{}) interface{} { return fmt.Sprint(v) },
+		Coerce:    coerceString("should be an id"),
+	}
+
+	builtInScalars = map[string]bool{"Int": true, "Float": true, "String": true, "Boolean": true, "ID": true}
+)
+
+func coerceString(message string) func(v interface{}) (interface{}, error) {
+	return func(v interface{}) (interface{}, error) {
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+		return nil, errors.New(message)
+	}
+}
+
+// coerce - checks the value by the type and converts it to the values given to the resolvers,
+// variables should be already replaced by their values
+func coerce(t Type, v interface{}) (interface{}, error) {
+	if n, ok := t.(*NonNull); ok {
+		if v == nil {
+			return nil, errors.New("should not be null")
+		}
+		return coerce(n.Of, v)
+	}
+	if v == nil {
+		return nil, nil
+	}
+
+	switch t := t.(type) {
+	case *Scalar:
+		if e, ok := v.(enumValue); ok {
+			v = string(e)
+		}
+		return t.Coerce(v)
+	case *List:
+		items, ok := v.([]interface{})
+		if !ok {
+			// a single value is a list of one item
+			items = []interface{}{v}
+		}
+		result := make([]interface{}, len(items))
+		for i, item := range items {
+			c, err := coerce(t.Of, item)
+			if err != nil {
+				return nil, fmt.Errorf("[%d] %w", i, err)
+			}
+			result[i] = c
+		}
+		return result, nil
+	case *InputObject:
+		fields, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("should be an object")
+		}
+		return coerceFields(t.Fields, fields)
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// coerceFields - coerces the fields of an input object or the arguments of a field,
+// unknown fields are not allowed, missing fields are not set in the result
+func coerceFields(definitions []*Argument, values map[string]interface{}) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(values))
+	for _, d := range definitions {
+		v, ok := values[d.Name]
+		if !ok {
+			if _, required := d.Type.(*NonNull); required {
+				return nil, fmt.Errorf("%s is required", d.Name)
+			}
+			continue
+		}
+		c, err := coerce(d.Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("%s %w", d.Name, err)
+		}
+		result[d.Name] = c
+	}
+
+	for name := range values {
+		known := false
+		for _, d := range definitions {
+			known = known || d.Name == name
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown field %s", name)
+		}
+	}
+	return result, nil
+}
+
+// resolveVariables - replaces the variables in the value of the document by their values,
+// object values become map[string]interface{}
+func resolveVariables(v interface{}, variables map[string]interface{}) interface{} {
+	switch v := v.(type) {
+	case variableValue:
+		return variables[string(v)]
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = resolveVariables(item, variables)
+		}
+		return result
+	case objectValue:
+		result := make(map[string]interface{}, len(v))
+		for _, f := range v {
+			result[f.name] = resolveVariables(f.value, variables)
+		}
+		return result
+	}
+	return v
+}
@@ -0,0 +1,91 @@
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+/*
+
+File contains an implementation of JSON Merge Patch (RFC 7396).
+
+*/
+
+const ContentType = "application/merge-patch+json"
+
+var (
+	ErrInvalidPatch = errors.New("merge patch is not a valid json")
+	// ErrUnknownMember - the patch sets a member, that the patched value doesn't have
+	ErrUnknownMember = errors.New("merge patch has an unknown member")
+)
+
+// Apply - applies the patch to the target json document and returns the patched document
+func Apply(target, patch []byte) ([]byte, error) {
+	var t, p interface{}
+
+	if err := json.Unmarshal(target, &t); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, ErrInvalidPatch
+	}
+
+	return json.Marshal(merge(t, p))
+}
+
+// ApplyTo - applies the patch to the json representation of v,
+// v should be a pointer, it will contain only values of the patched document.
+// The members unknown to v are not dropped silently, the patch is rejected with ErrUnknownMember
+func ApplyTo(v interface{}, patch []byte) error {
+	target, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	patched, err := Apply(target, patch)
+	if err != nil {
+		return err
+	}
+
+	// the fields removed by the patch should not keep their old values
+	value := reflect.ValueOf(v).Elem()
+	value.Set(reflect.Zero(value.Type()))
+
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		// encoding/json has no type for this error, so it's recognized by the message
+		if name := strings.TrimPrefix(err.Error(), "json: unknown field "); name != err.Error() {
+			return fmt.Errorf("%w: %s", ErrUnknownMember, name)
+		}
+		return err
+	}
+	return nil
+}
+
+func merge(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+
+	for k, v := range patchObject {
+		if v == nil {
+			delete(targetObject, k)
+			continue
+		}
+		targetObject[k] = merge(targetObject[k], v)
+	}
+
+	return targetObject
+}
@@ -0,0 +1,112 @@
+package mergepatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestApply - the examples of RFC 7396, Appendix A
+func TestApply(t *testing.T) {
+	tests := []struct {
+		target, patch, result string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, test := range tests {
+		patched, err := Apply([]byte(test.target), []byte(test.patch))
+		if err != nil {
+			t.Errorf("%s patched by %s: unexpected error %v", test.target, test.patch, err)
+			continue
+		}
+		if !equalJSON(t, patched, []byte(test.result)) {
+			t.Errorf("%s patched by %s = %s, expected %s", test.target, test.patch, patched, test.result)
+		}
+	}
+}
+
+func TestApplyInvalidPatch(t *testing.T) {
+	if _, err := Apply([]byte(`{"a":"b"}`), []byte(`{"a":`)); !errors.Is(err, ErrInvalidPatch) {
+		t.Errorf("expected ErrInvalidPatch, got %v", err)
+	}
+}
+
+type patchedValue struct {
+	Name   string   `json:"name"`
+	Levels []string `json:"levels"`
+	Nested struct {
+		Code string `json:"code"`
+	} `json:"nested"`
+}
+
+func TestApplyTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   string
+		result  patchedValue
+		wantErr error
+	}{
+		{name: "changes a member", patch: `{"name":"new"}`,
+			result: patchedValue{Name: "new", Levels: []string{"a"}, Nested: struct {
+				Code string `json:"code"`
+			}{"c"}}},
+		{name: "removed member is zero", patch: `{"levels":null,"nested":{"code":null}}`,
+			result: patchedValue{Name: "old"}},
+		{name: "unknown member", patch: `{"title":"new"}`, wantErr: ErrUnknownMember},
+		{name: "unknown nested member", patch: `{"nested":{"id":1}}`, wantErr: ErrUnknownMember},
+		{name: "removal of an unknown member is a no-op", patch: `{"title":null}`,
+			result: patchedValue{Name: "old", Levels: []string{"a"}, Nested: struct {
+				Code string `json:"code"`
+			}{"c"}}},
+		{name: "invalid patch", patch: `{`, wantErr: ErrInvalidPatch},
+	}
+
+	for _, test := range tests {
+		v := patchedValue{Name: "old", Levels: []string{"a"}}
+		v.Nested.Code = "c"
+
+		err := ApplyTo(&v, []byte(test.patch))
+		if test.wantErr != nil {
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("%s: expected %v, got %v", test.name, test.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(v, test.result) {
+			t.Errorf("%s: got %+v, expected %+v", test.name, v, test.result)
+		}
+	}
+}
+
+func equalJSON(t *testing.T, a, b []byte) bool {
+	t.Helper()
+
+	var x, y interface{}
+	if err := json.Unmarshal(a, &x); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &y); err != nil {
+		t.Fatal(err)
+	}
+	return reflect.DeepEqual(x, y)
+}